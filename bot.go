@@ -25,8 +25,13 @@ type BotAPI struct {
 	Debug  bool   `json:"debug"`
 	Buffer int    `json:"buffer"`
 
-	Self            User       `json:"-"`
-	Client          HTTPClient `json:"-"`
+	Self   User       `json:"-"`
+	Client HTTPClient `json:"-"`
+	// CheckMarkup makes Request validate formatted texts with
+	// ValidateMarkup before sending them, so errors are reported
+	// without making a request. Texts, captions, including those of
+	// media groups, and poll questions and explanations are checked.
+	CheckMarkup     bool `json:"-"`
 	shutdownChannel chan interface{}
 
 	apiEndpoint string
@@ -316,6 +321,12 @@ func (bot *BotAPI) Request(c Chattable) (*APIResponse, error) {
 		return nil, err
 	}
 
+	if bot.CheckMarkup {
+		if err := validateParamsMarkup(params); err != nil {
+			return nil, err
+		}
+	}
+
 	if t, ok := c.(Fileable); ok {
 		files := t.files()
 
@@ -719,6 +730,40 @@ func (bot *BotAPI) GetMyDefaultAdministratorRights(config GetMyDefaultAdministra
 	return rights, err
 }
 
+// CreateInvoiceLink creates a link for an invoice and returns it.
+func (bot *BotAPI) CreateInvoiceLink(config InvoiceLinkConfig) (string, error) {
+	resp, err := bot.Request(config)
+	if err != nil {
+		return "", err
+	}
+
+	var link string
+	err = json.Unmarshal(resp.Result, &link)
+
+	return link, err
+}
+
+// GetAllStarTransactions gets all the Telegram Star transactions of the bot,
+// requesting them by pages of the maximum size.
+func (bot *BotAPI) GetAllStarTransactions() ([]StarTransaction, error) {
+	var transactions []StarTransaction
+
+	config := GetStarTransactionsConfig{Limit: 100}
+	for {
+		page, err := bot.GetStarTransactions(config)
+		if err != nil {
+			return transactions, err
+		}
+
+		transactions = append(transactions, page.Transactions...)
+		if len(page.Transactions) < config.Limit {
+			return transactions, nil
+		}
+
+		config.Offset += len(page.Transactions)
+	}
+}
+
 // EscapeText takes an input text and escape Telegram markup symbols.
 // In this way we can send a text without being afraid of having to escape the characters manually.
 // Note that you don't have to include the formatting style in the input text, or it will be escaped too.
@@ -0,0 +1,229 @@
+// Code generated by tgbotapi-gen from schema/api.json; DO NOT EDIT.
+
+package tgbotapi
+
+import "encoding/json"
+
+// CopyMessages makes a copyMessages request and returns its result.
+func (bot *BotAPI) CopyMessages(config CopyMessagesConfig) ([]MessageID, error) {
+	var result []MessageID
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// CreateChatSubscriptionInviteLink makes a createChatSubscriptionInviteLink request and returns its result.
+func (bot *BotAPI) CreateChatSubscriptionInviteLink(config CreateChatSubscriptionInviteLinkConfig) (ChatInviteLink, error) {
+	var result ChatInviteLink
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// CreateForumTopic makes a createForumTopic request and returns its result.
+func (bot *BotAPI) CreateForumTopic(config CreateForumTopicConfig) (ForumTopic, error) {
+	var result ForumTopic
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// EditChatSubscriptionInviteLink makes a editChatSubscriptionInviteLink request and returns its result.
+func (bot *BotAPI) EditChatSubscriptionInviteLink(config EditChatSubscriptionInviteLinkConfig) (ChatInviteLink, error) {
+	var result ChatInviteLink
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// ForwardMessages makes a forwardMessages request and returns its result.
+func (bot *BotAPI) ForwardMessages(config ForwardMessagesConfig) ([]MessageID, error) {
+	var result []MessageID
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetAvailableGifts makes a getAvailableGifts request and returns its result.
+func (bot *BotAPI) GetAvailableGifts(config GetAvailableGiftsConfig) (Gifts, error) {
+	var result Gifts
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetBusinessConnection makes a getBusinessConnection request and returns its result.
+func (bot *BotAPI) GetBusinessConnection(config GetBusinessConnectionConfig) (BusinessConnection, error) {
+	var result BusinessConnection
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetChatMemberCount makes a getChatMemberCount request and returns its result.
+func (bot *BotAPI) GetChatMemberCount(config GetChatMemberCountConfig) (int, error) {
+	var result int
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetCustomEmojiStickers makes a getCustomEmojiStickers request and returns its result.
+func (bot *BotAPI) GetCustomEmojiStickers(config GetCustomEmojiStickersConfig) ([]Sticker, error) {
+	var result []Sticker
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetForumTopicIconStickers makes a getForumTopicIconStickers request and returns its result.
+func (bot *BotAPI) GetForumTopicIconStickers(config GetForumTopicIconStickersConfig) ([]Sticker, error) {
+	var result []Sticker
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetMyDescription makes a getMyDescription request and returns its result.
+func (bot *BotAPI) GetMyDescription(config GetMyDescriptionConfig) (BotDescription, error) {
+	var result BotDescription
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetMyName makes a getMyName request and returns its result.
+func (bot *BotAPI) GetMyName(config GetMyNameConfig) (BotName, error) {
+	var result BotName
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetMyShortDescription makes a getMyShortDescription request and returns its result.
+func (bot *BotAPI) GetMyShortDescription(config GetMyShortDescriptionConfig) (BotShortDescription, error) {
+	var result BotShortDescription
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetStarTransactions makes a getStarTransactions request and returns its result.
+func (bot *BotAPI) GetStarTransactions(config GetStarTransactionsConfig) (StarTransactions, error) {
+	var result StarTransactions
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// GetUserChatBoosts makes a getUserChatBoosts request and returns its result.
+func (bot *BotAPI) GetUserChatBoosts(config GetUserChatBoostsConfig) (UserChatBoosts, error) {
+	var result UserChatBoosts
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
+
+// SavePreparedInlineMessage makes a savePreparedInlineMessage request and returns its result.
+func (bot *BotAPI) SavePreparedInlineMessage(config SavePreparedInlineMessageConfig) (PreparedInlineMessage, error) {
+	var result PreparedInlineMessage
+
+	resp, err := bot.Request(config)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp.Result, &result)
+
+	return result, err
+}
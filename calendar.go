@@ -0,0 +1,502 @@
+package tgbotapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CalendarLocale contains the names used when rendering a Calendar.
+type CalendarLocale struct {
+	// Months are the month names, starting with January.
+	Months [12]string
+	// Weekdays are the short weekday names, starting with Sunday.
+	Weekdays [7]string
+	// FirstWeekday is the day each calendar row starts with.
+	FirstWeekday time.Weekday
+}
+
+// CalendarLocaleEnglish is the default locale used by calendars.
+var CalendarLocaleEnglish = CalendarLocale{
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June", "July",
+		"August", "September", "October", "November", "December",
+	},
+	Weekdays:     [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+	FirstWeekday: time.Monday,
+}
+
+// calendarLocales are the known calendar locales, keyed by lower case IETF
+// language tag. They are guarded by calendarLocalesMu, as
+// RegisterCalendarLocale may run while calendars are rendered.
+var (
+	calendarLocalesMu sync.RWMutex
+	calendarLocales   = map[string]CalendarLocale{
+		"en": CalendarLocaleEnglish,
+		"de": {
+			Months: [12]string{
+				"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli",
+				"August", "September", "Oktober", "November", "Dezember",
+			},
+			Weekdays:     [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+			FirstWeekday: time.Monday,
+		},
+		"es": {
+			Months: [12]string{
+				"Enero", "Febrero", "Marzo", "Abril", "Mayo", "Junio", "Julio",
+				"Agosto", "Septiembre", "Octubre", "Noviembre", "Diciembre",
+			},
+			Weekdays:     [7]string{"Do", "Lu", "Ma", "Mi", "Ju", "Vi", "Sá"},
+			FirstWeekday: time.Monday,
+		},
+		"fr": {
+			Months: [12]string{
+				"Janvier", "Février", "Mars", "Avril", "Mai", "Juin", "Juillet",
+				"Août", "Septembre", "Octobre", "Novembre", "Décembre",
+			},
+			Weekdays:     [7]string{"Di", "Lu", "Ma", "Me", "Je", "Ve", "Sa"},
+			FirstWeekday: time.Monday,
+		},
+		"it": {
+			Months: [12]string{
+				"Gennaio", "Febbraio", "Marzo", "Aprile", "Maggio", "Giugno", "Luglio",
+				"Agosto", "Settembre", "Ottobre", "Novembre", "Dicembre",
+			},
+			Weekdays:     [7]string{"Do", "Lu", "Ma", "Me", "Gi", "Ve", "Sa"},
+			FirstWeekday: time.Monday,
+		},
+		"pt": {
+			Months: [12]string{
+				"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho", "Julho",
+				"Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
+			},
+			Weekdays:     [7]string{"Do", "Se", "Te", "Qa", "Qi", "Sx", "Sá"},
+			FirstWeekday: time.Sunday,
+		},
+		"ru": {
+			Months: [12]string{
+				"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль",
+				"Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь",
+			},
+			Weekdays:     [7]string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+			FirstWeekday: time.Monday,
+		},
+		"uk": {
+			Months: [12]string{
+				"Січень", "Лютий", "Березень", "Квітень", "Травень", "Червень", "Липень",
+				"Серпень", "Вересень", "Жовтень", "Листопад", "Грудень",
+			},
+			Weekdays:     [7]string{"Нд", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+			FirstWeekday: time.Monday,
+		},
+	}
+)
+
+// RegisterCalendarLocale adds or replaces the calendar locale of an IETF
+// language tag, such as "nl" or "pt-BR". It is safe to call while calendars
+// are rendered.
+func RegisterCalendarLocale(languageCode string, locale CalendarLocale) {
+	calendarLocalesMu.Lock()
+	defer calendarLocalesMu.Unlock()
+
+	calendarLocales[strings.ToLower(languageCode)] = locale
+}
+
+// CalendarLocaleFor returns the calendar locale for an IETF language tag,
+// such as User.LanguageCode. Regional variants fall back to the base
+// language, and unknown languages fall back to CalendarLocaleEnglish.
+func CalendarLocaleFor(languageCode string) CalendarLocale {
+	calendarLocalesMu.RLock()
+	defer calendarLocalesMu.RUnlock()
+
+	tag := strings.ToLower(languageCode)
+
+	for tag != "" {
+		if locale, ok := calendarLocales[tag]; ok {
+			return locale
+		}
+
+		i := strings.LastIndexAny(tag, "-_")
+		if i == -1 {
+			break
+		}
+		tag = tag[:i]
+	}
+
+	return CalendarLocaleEnglish
+}
+
+const (
+	widgetActionNoop = "noop"
+	widgetActionNav  = "nav"
+	widgetActionPick = "pick"
+
+	calendarMonthLayout = "200601"
+	calendarDayLayout   = "20060102"
+)
+
+// Calendar is an inline keyboard for choosing a date.
+//
+// Calendars render a month grid with navigation buttons. Callback queries
+// produced by the keyboard should be passed to HandleCallback, which moves
+// between months by editing the message and calls OnSelect once a day has
+// been picked.
+type Calendar struct {
+	// Prefix is prepended to the callback data of every button. It must be
+	// unique among the widgets handling the same callback queries.
+	Prefix string
+	// MinDate is the earliest date that may be selected.
+	//
+	// optional
+	MinDate time.Time
+	// MaxDate is the latest date that may be selected.
+	//
+	// optional
+	MaxDate time.Time
+	// Location is the time zone of the selected dates. Defaults to UTC.
+	//
+	// optional
+	Location *time.Location
+	// Locale overrides the names used in the calendar. If it is not set,
+	// the locale is chosen from the language of the user.
+	//
+	// optional
+	Locale *CalendarLocale
+	// OnSelect is called after the user has picked a date.
+	OnSelect func(bot Sender, query *CallbackQuery, date time.Time) error
+}
+
+// NewCalendar creates a new Calendar with the given callback data prefix.
+func NewCalendar(prefix string, onSelect func(bot Sender, query *CallbackQuery, date time.Time) error) *Calendar {
+	return &Calendar{
+		Prefix:   prefix,
+		OnSelect: onSelect,
+	}
+}
+
+// Markup renders the calendar keyboard for the month containing the given
+// date, using the default locale.
+func (c *Calendar) Markup(month time.Time) InlineKeyboardMarkup {
+	return c.markup(month, c.locale(""))
+}
+
+// MarkupFor renders the calendar keyboard for the month containing the given
+// date, using the locale of the user.
+func (c *Calendar) MarkupFor(user *User, month time.Time) InlineKeyboardMarkup {
+	var languageCode string
+	if user != nil {
+		languageCode = user.LanguageCode
+	}
+
+	return c.markup(month, c.locale(languageCode))
+}
+
+// HandleCallback processes a callback query produced by the calendar.
+//
+// It returns false if the query does not belong to this calendar, in which
+// case nothing is sent to Telegram.
+func (c *Calendar) HandleCallback(bot Sender, query *CallbackQuery) (bool, error) {
+	action, value, ok := parseWidgetData(c.Prefix, query)
+	if !ok {
+		return false, nil
+	}
+
+	switch action {
+	case widgetActionNav:
+		month, err := time.ParseInLocation(calendarMonthLayout, value, c.location())
+		if err != nil {
+			return true, err
+		}
+
+		markup := c.MarkupFor(query.From, month)
+		if _, err := bot.Request(newEditMarkupForQuery(query, markup)); err != nil {
+			return true, err
+		}
+	case widgetActionPick:
+		date, err := time.ParseInLocation(calendarDayLayout, value, c.location())
+		if err != nil {
+			return true, err
+		}
+
+		if !c.inRange(date) {
+			return true, errors.New("selected date is out of range")
+		}
+
+		if _, err := bot.Request(NewCallback(query.ID, "")); err != nil {
+			return true, err
+		}
+
+		if c.OnSelect != nil {
+			return true, c.OnSelect(bot, query, date)
+		}
+
+		return true, nil
+	}
+
+	_, err := bot.Request(NewCallback(query.ID, ""))
+
+	return true, err
+}
+
+func (c *Calendar) location() *time.Location {
+	if c.Location != nil {
+		return c.Location
+	}
+
+	return time.UTC
+}
+
+func (c *Calendar) locale(languageCode string) CalendarLocale {
+	if c.Locale != nil {
+		return *c.Locale
+	}
+
+	return CalendarLocaleFor(languageCode)
+}
+
+func (c *Calendar) inRange(date time.Time) bool {
+	if !c.MinDate.IsZero() && date.Before(truncateDay(c.MinDate.In(c.location()))) {
+		return false
+	}
+	if !c.MaxDate.IsZero() && date.After(truncateDay(c.MaxDate.In(c.location()))) {
+		return false
+	}
+
+	return true
+}
+
+func (c *Calendar) markup(month time.Time, locale CalendarLocale) InlineKeyboardMarkup {
+	loc := c.location()
+	month = month.In(loc)
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	last := first.AddDate(0, 1, -1)
+	noop := widgetData(c.Prefix, widgetActionNoop, "")
+
+	prev, next := NewInlineKeyboardButtonData(" ", noop), NewInlineKeyboardButtonData(" ", noop)
+	if c.MinDate.IsZero() || first.After(c.MinDate.In(loc)) {
+		prev = NewInlineKeyboardButtonData("«", widgetData(c.Prefix, widgetActionNav, first.AddDate(0, -1, 0).Format(calendarMonthLayout)))
+	}
+	if c.MaxDate.IsZero() || last.Before(truncateDay(c.MaxDate.In(loc))) {
+		next = NewInlineKeyboardButtonData("»", widgetData(c.Prefix, widgetActionNav, first.AddDate(0, 1, 0).Format(calendarMonthLayout)))
+	}
+
+	title := fmt.Sprintf("%s %d", locale.Months[first.Month()-1], first.Year())
+	rows := [][]InlineKeyboardButton{
+		NewInlineKeyboardRow(prev, NewInlineKeyboardButtonData(title, noop), next),
+	}
+
+	weekdays := make([]InlineKeyboardButton, 7)
+	for i := range weekdays {
+		weekdays[i] = NewInlineKeyboardButtonData(locale.Weekdays[(int(locale.FirstWeekday)+i)%7], noop)
+	}
+	rows = append(rows, weekdays)
+
+	week := make([]InlineKeyboardButton, 0, 7)
+	for i := (int(first.Weekday()) - int(locale.FirstWeekday) + 7) % 7; i > 0; i-- {
+		week = append(week, NewInlineKeyboardButtonData(" ", noop))
+	}
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if c.inRange(day) {
+			week = append(week, NewInlineKeyboardButtonData(strconv.Itoa(day.Day()), widgetData(c.Prefix, widgetActionPick, day.Format(calendarDayLayout))))
+		} else {
+			week = append(week, NewInlineKeyboardButtonData("·", noop))
+		}
+
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = make([]InlineKeyboardButton, 0, 7)
+		}
+	}
+
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, NewInlineKeyboardButtonData(" ", noop))
+		}
+		rows = append(rows, week)
+	}
+
+	return NewInlineKeyboardMarkup(rows...)
+}
+
+// TimePicker is an inline keyboard for choosing a time of day.
+//
+// The keyboard shows the current hour and minute with buttons to change them.
+// Callback queries produced by the keyboard should be passed to
+// HandleCallback, which updates the message as values change and calls
+// OnSelect once the user confirms.
+type TimePicker struct {
+	// Prefix is prepended to the callback data of every button. It must be
+	// unique among the widgets handling the same callback queries.
+	Prefix string
+	// MinuteStep is the amount minutes change by. Defaults to 5.
+	//
+	// optional
+	MinuteStep int
+	// Location is the time zone of the selected time. Defaults to UTC.
+	//
+	// optional
+	Location *time.Location
+	// ConfirmText is the text on the confirmation button. Defaults to "OK".
+	//
+	// optional
+	ConfirmText string
+	// OnSelect is called after the user has confirmed a time.
+	OnSelect func(bot Sender, query *CallbackQuery, t time.Time) error
+}
+
+// NewTimePicker creates a new TimePicker with the given callback data prefix.
+func NewTimePicker(prefix string, onSelect func(bot Sender, query *CallbackQuery, t time.Time) error) *TimePicker {
+	return &TimePicker{
+		Prefix:   prefix,
+		OnSelect: onSelect,
+	}
+}
+
+const timePickerLayout = "200601021504"
+
+// Markup renders the time picker keyboard starting at the given time.
+//
+// The date of t is kept and included in the time passed to OnSelect, so a
+// date picked from a Calendar can be completed with a time.
+func (tp *TimePicker) Markup(t time.Time) InlineKeyboardMarkup {
+	t = t.In(tp.location()).Truncate(time.Minute)
+	noop := widgetData(tp.Prefix, widgetActionNoop, "")
+
+	step := func(d time.Duration) string {
+		next := t.Add(d)
+		// Keep the date fixed, only the time of day changes.
+		next = time.Date(t.Year(), t.Month(), t.Day(), next.Hour(), next.Minute(), 0, 0, t.Location())
+		return widgetData(tp.Prefix, widgetActionNav, next.Format(timePickerLayout))
+	}
+
+	minutes := time.Duration(tp.minuteStep()) * time.Minute
+
+	confirm := tp.ConfirmText
+	if confirm == "" {
+		confirm = "OK"
+	}
+
+	return NewInlineKeyboardMarkup(
+		NewInlineKeyboardRow(
+			NewInlineKeyboardButtonData("▲", step(time.Hour)),
+			NewInlineKeyboardButtonData(" ", noop),
+			NewInlineKeyboardButtonData("▲", step(minutes)),
+		),
+		NewInlineKeyboardRow(
+			NewInlineKeyboardButtonData(fmt.Sprintf("%02d", t.Hour()), noop),
+			NewInlineKeyboardButtonData(":", noop),
+			NewInlineKeyboardButtonData(fmt.Sprintf("%02d", t.Minute()), noop),
+		),
+		NewInlineKeyboardRow(
+			NewInlineKeyboardButtonData("▼", step(-time.Hour)),
+			NewInlineKeyboardButtonData(" ", noop),
+			NewInlineKeyboardButtonData("▼", step(-minutes)),
+		),
+		NewInlineKeyboardRow(
+			NewInlineKeyboardButtonData(confirm, widgetData(tp.Prefix, widgetActionPick, t.Format(timePickerLayout))),
+		),
+	)
+}
+
+// HandleCallback processes a callback query produced by the time picker.
+//
+// It returns false if the query does not belong to this time picker, in
+// which case nothing is sent to Telegram.
+func (tp *TimePicker) HandleCallback(bot Sender, query *CallbackQuery) (bool, error) {
+	action, value, ok := parseWidgetData(tp.Prefix, query)
+	if !ok {
+		return false, nil
+	}
+
+	switch action {
+	case widgetActionNav:
+		t, err := time.ParseInLocation(timePickerLayout, value, tp.location())
+		if err != nil {
+			return true, err
+		}
+
+		if _, err := bot.Request(newEditMarkupForQuery(query, tp.Markup(t))); err != nil {
+			return true, err
+		}
+	case widgetActionPick:
+		t, err := time.ParseInLocation(timePickerLayout, value, tp.location())
+		if err != nil {
+			return true, err
+		}
+
+		if _, err := bot.Request(NewCallback(query.ID, "")); err != nil {
+			return true, err
+		}
+
+		if tp.OnSelect != nil {
+			return true, tp.OnSelect(bot, query, t)
+		}
+
+		return true, nil
+	}
+
+	_, err := bot.Request(NewCallback(query.ID, ""))
+
+	return true, err
+}
+
+func (tp *TimePicker) location() *time.Location {
+	if tp.Location != nil {
+		return tp.Location
+	}
+
+	return time.UTC
+}
+
+func (tp *TimePicker) minuteStep() int {
+	if tp.MinuteStep > 0 {
+		return tp.MinuteStep
+	}
+
+	return 5
+}
+
+// widgetData builds the callback data for a widget button.
+func widgetData(prefix, action, value string) string {
+	return prefix + ":" + action + ":" + value
+}
+
+// parseWidgetData splits the callback data of a query created by widgetData.
+func parseWidgetData(prefix string, query *CallbackQuery) (action, value string, ok bool) {
+	if query == nil || !strings.HasPrefix(query.Data, prefix+":") {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(query.Data, prefix+":"), ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// newEditMarkupForQuery creates a request replacing the keyboard of the
+// message a callback query originated from.
+func newEditMarkupForQuery(query *CallbackQuery, markup InlineKeyboardMarkup) EditMessageReplyMarkupConfig {
+	config := EditMessageReplyMarkupConfig{
+		BaseEdit: BaseEdit{
+			InlineMessageID: query.InlineMessageID,
+			ReplyMarkup:     &markup,
+		},
+	}
+
+	if query.Message != nil {
+		config.ChatID = query.Message.Chat.ID
+		config.MessageID = query.Message.MessageID
+	}
+
+	return config
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,171 @@
+package tgbotapi
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingClient is an HTTPClient that records requests and replies with
+// a successful empty result.
+type recordingClient struct {
+	requests []url.Values
+	methods  []string
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	c.requests = append(c.requests, values)
+	c.methods = append(c.methods, req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:])
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":true}`)),
+	}, nil
+}
+
+func newRecordingBot() (*BotAPI, *recordingClient) {
+	client := &recordingClient{}
+
+	return &BotAPI{
+		Token:       "token",
+		Client:      client,
+		apiEndpoint: APIEndpoint,
+	}, client
+}
+
+func newWidgetQuery(data string) *CallbackQuery {
+	return &CallbackQuery{
+		ID:   "query",
+		From: &User{ID: 1, LanguageCode: "de"},
+		Message: &Message{
+			MessageID: 10,
+			Chat:      &Chat{ID: 20},
+		},
+		Data: data,
+	}
+}
+
+func TestCalendarMarkup(t *testing.T) {
+	calendar := NewCalendar("cal", nil)
+	calendar.MinDate = time.Date(2024, time.May, 10, 12, 0, 0, 0, time.UTC)
+
+	markup := calendar.Markup(time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC))
+	rows := markup.InlineKeyboard
+
+	if rows[0][1].Text != "May 2024" {
+		t.Fatalf("unexpected title %q", rows[0][1].Text)
+	}
+	if rows[0][0].Text != " " || rows[0][2].Text != "»" {
+		t.Fatal("previous month should be unavailable before MinDate")
+	}
+	if rows[1][0].Text != "Mo" {
+		t.Fatalf("weeks should start on Monday, got %q", rows[1][0].Text)
+	}
+
+	// May 1st, 2024 was a Wednesday.
+	if rows[2][1].Text != " " || rows[2][2].Text != "·" {
+		t.Fatalf("unexpected first week %v", rows[2])
+	}
+
+	for _, row := range rows[2:] {
+		if len(row) != 7 {
+			t.Fatalf("week has %d days", len(row))
+		}
+		for _, button := range row {
+			if button.Text == "10" && *button.CallbackData != "cal:pick:20240510" {
+				t.Fatalf("unexpected callback data %q", *button.CallbackData)
+			}
+		}
+	}
+}
+
+func TestCalendarLocaleFor(t *testing.T) {
+	if CalendarLocaleFor("pt-BR").Months[0] != "Janeiro" {
+		t.Fatal("expected pt-BR to fall back to pt")
+	}
+	if CalendarLocaleFor("xx").Months[0] != "January" {
+		t.Fatal("expected unknown languages to fall back to English")
+	}
+
+	dutch := CalendarLocaleEnglish
+	dutch.Months[0] = "Januari"
+	RegisterCalendarLocale("NL", dutch)
+	if CalendarLocaleFor("nl-BE").Months[0] != "Januari" {
+		t.Fatal("expected registered locale for nl-BE")
+	}
+}
+
+func TestCalendarHandleCallback(t *testing.T) {
+	bot, client := newRecordingBot()
+
+	var selected time.Time
+	calendar := NewCalendar("cal", func(bot Sender, query *CallbackQuery, date time.Time) error {
+		selected = date
+		return nil
+	})
+
+	handled, err := calendar.HandleCallback(bot, newWidgetQuery("other:pick:20240510"))
+	if handled || err != nil || len(client.requests) != 0 {
+		t.Fatal("foreign callback queries must be ignored")
+	}
+
+	if _, err := calendar.HandleCallback(bot, newWidgetQuery("cal:nav:202406")); err != nil {
+		t.Fatal(err)
+	}
+	if client.methods[0] != "editMessageReplyMarkup" || client.methods[1] != "answerCallbackQuery" {
+		t.Fatalf("unexpected requests %v", client.methods)
+	}
+	if !strings.Contains(client.requests[0].Get("reply_markup"), "Juni 2024") {
+		t.Fatal("expected calendar in the language of the user")
+	}
+
+	if _, err := calendar.HandleCallback(bot, newWidgetQuery("cal:pick:20240510")); err != nil {
+		t.Fatal(err)
+	}
+	if !selected.Equal(time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected selected date %v", selected)
+	}
+}
+
+func TestTimePickerHandleCallback(t *testing.T) {
+	bot, client := newRecordingBot()
+
+	var selected time.Time
+	picker := NewTimePicker("tp", func(bot Sender, query *CallbackQuery, t time.Time) error {
+		selected = t
+		return nil
+	})
+
+	markup := picker.Markup(time.Date(2024, time.May, 10, 23, 55, 0, 0, time.UTC))
+	up := *markup.InlineKeyboard[0][2].CallbackData
+	if up != "tp:nav:202405100000" {
+		t.Fatalf("minutes should wrap within the same day, got %q", up)
+	}
+
+	if _, err := picker.HandleCallback(bot, newWidgetQuery(up)); err != nil {
+		t.Fatal(err)
+	}
+	if client.methods[0] != "editMessageReplyMarkup" {
+		t.Fatalf("unexpected requests %v", client.methods)
+	}
+
+	if _, err := picker.HandleCallback(bot, newWidgetQuery("tp:pick:202405100930")); err != nil {
+		t.Fatal(err)
+	}
+	if !selected.Equal(time.Date(2024, time.May, 10, 9, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected selected time %v", selected)
+	}
+}
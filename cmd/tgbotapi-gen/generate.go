@@ -0,0 +1,546 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]string{
+	"id":    "ID",
+	"ids":   "IDs",
+	"url":   "URL",
+	"urls":  "URLs",
+	"html":  "HTML",
+	"api":   "API",
+	"ip":    "IP",
+	"json":  "JSON",
+	"http":  "HTTP",
+	"gif":   "GIF",
+	"mpeg4": "MPEG4",
+}
+
+// aliases are the Bot API types the package represents with another type.
+// Like CallbackQuery.Message, a MaybeInaccessibleMessage is decoded as a
+// Message, which has a zero Date when it is inaccessible.
+var aliases = map[string]string{
+	"InputFile":                "RequestFileData",
+	"MaybeInaccessibleMessage": "Message",
+}
+
+type generator struct {
+	schema *Schema
+	pkg    *Package
+	source string
+}
+
+func newGenerator(s *Schema, pkg *Package, source string) *generator {
+	return &generator{schema: s, pkg: pkg, source: source}
+}
+
+// generate returns the content of the generated files by name. A nil
+// content means the file has nothing to declare and should be removed.
+func (g *generator) generate() (map[string][]byte, error) {
+	var types, configs, getters bytes.Buffer
+	var typeImports []string
+
+	for _, name := range g.schema.typeNames() {
+		o := g.schema.Types[name]
+		if aliases[name] != "" || g.merged(o) || g.separate(o) || g.pkg.Types[goTypeName(name)] {
+			continue
+		}
+
+		if g.writeType(&types, o) {
+			typeImports = []string{"encoding/json"}
+		}
+	}
+
+	for _, name := range g.schema.methodNames() {
+		m := g.schema.Methods[name]
+		if g.pkg.Configs[name] != "" || g.pkg.Requests[name] {
+			continue
+		}
+
+		config := configName(name)
+		if g.pkg.Types[config] {
+			return nil, fmt.Errorf("%s is declared but does not return %s from Method", config, name)
+		}
+
+		g.writeConfig(&configs, m)
+
+		if getter, ok := g.getterName(m); ok {
+			g.writeGetter(&getters, m, getter)
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, file := range []struct {
+		name    string
+		imports []string
+		decls   *bytes.Buffer
+	}{
+		{"types_gen.go", typeImports, &types},
+		{"configs_gen.go", nil, &configs},
+		{"bot_gen.go", []string{"encoding/json"}, &getters},
+	} {
+		if file.decls.Len() == 0 {
+			files[file.name] = nil
+			continue
+		}
+
+		content, err := g.file(file.imports, file.decls.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.name, err)
+		}
+		files[file.name] = content
+	}
+
+	return files, nil
+}
+
+func (g *generator) file(imports []string, decls []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by tgbotapi-gen from %s; DO NOT EDIT.\n\n", g.source)
+	buf.WriteString("package tgbotapi\n")
+	for _, path := range imports {
+		fmt.Fprintf(&buf, "\nimport %q\n", path)
+	}
+	buf.Write(decls)
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, buf.String())
+	}
+
+	return formatted, nil
+}
+
+// writeType writes the struct of a type. It reports if the struct has chat
+// ID fields, which are paired with a username field and encoded by the
+// methods written by writeChatIDJSON.
+func (g *generator) writeType(buf *bytes.Buffer, o *Object) bool {
+	name := goTypeName(o.Name)
+
+	fmt.Fprintf(buf, "\n")
+	writeDoc(buf, "", name+" "+lowerFirst(strings.TrimPrefix(strings.Join(o.Description, " "), "This object ")))
+	if len(o.Subtypes) > 0 {
+		buf.WriteString("//\n")
+		writeDoc(buf, "", "It is one of "+joinWords(o.Subtypes)+", merged in a single type.")
+	}
+	fields := g.schema.fields(o)
+	if len(fields) == 0 {
+		fmt.Fprintf(buf, "type %s struct{}\n", name)
+		return false
+	}
+
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+
+	var chatIDs []Field
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		goName := goFieldName(field.Name)
+		writeDoc(buf, "\t", fieldDoc(goName, field.Description))
+		if !field.Required {
+			buf.WriteString("\t//\n\t// optional\n")
+		}
+
+		if field.isChatID() {
+			chatIDs = append(chatIDs, field)
+			fmt.Fprintf(buf, "\t%s int64 `json:\"-\"`\n\n", goName)
+			fmt.Fprintf(buf, "\t// %s is the username of the chat, used if %s is 0.\n", channelUsernameName(field.Name), goName)
+			buf.WriteString("\t//\n\t// optional\n")
+			fmt.Fprintf(buf, "\t%s string `json:\"-\"`\n", channelUsernameName(field.Name))
+			continue
+		}
+
+		typ := g.goType(field, false)
+		tag := field.Name
+		if !field.Required {
+			tag += ",omitempty"
+			if g.isObject(typ) {
+				typ = "*" + typ
+			}
+		}
+
+		fmt.Fprintf(buf, "\t%s %s `json:%q`\n", goName, typ, tag)
+	}
+
+	buf.WriteString("}\n")
+
+	if len(chatIDs) == 0 {
+		return false
+	}
+
+	writeChatIDJSON(buf, name, chatIDs)
+
+	return true
+}
+
+// writeChatIDJSON writes the JSON methods of a type with chat ID fields,
+// which are encoded as the ID or, if it is 0, as the username.
+func writeChatIDJSON(buf *bytes.Buffer, name string, chatIDs []Field) {
+	var fields, values, names []string
+	for _, field := range chatIDs {
+		goName := goFieldName(field.Name)
+		tag := field.Name
+		if !field.Required {
+			tag += ",omitempty"
+		}
+
+		fields = append(fields, fmt.Sprintf("\t\t%s interface{} `json:%q`\n", goName, tag))
+		values = append(values, fmt.Sprintf("chatIDValue(t.%s, t.%s)", goName, channelUsernameName(field.Name)))
+		names = append(names, goName)
+	}
+
+	fmt.Fprintf(buf, "\n// MarshalJSON encodes %s, or the username if it is 0.\n", joinWords(names))
+	fmt.Fprintf(buf, "func (t %s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprintf(buf, "\ttype plain %s\n\n", name)
+	buf.WriteString("\treturn json.Marshal(struct {\n\t\tplain\n")
+	for _, field := range fields {
+		buf.WriteString(field)
+	}
+	fmt.Fprintf(buf, "\t}{plain(t), %s})\n}\n", strings.Join(values, ", "))
+
+	fmt.Fprintf(buf, "\n// UnmarshalJSON decodes %s, which may be a username.\n", joinWords(names))
+	fmt.Fprintf(buf, "func (t *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(buf, "\ttype plain %s\n\n", name)
+	buf.WriteString("\tv := struct {\n\t\t*plain\n")
+	for _, field := range chatIDs {
+		fmt.Fprintf(buf, "\t\t%s json.RawMessage `json:%q`\n", goFieldName(field.Name), field.Name)
+	}
+	buf.WriteString("\t}{plain: (*plain)(t)}\n\n")
+	buf.WriteString("\tif err := json.Unmarshal(data, &v); err != nil {\n\t\treturn err\n\t}\n")
+	for i, field := range chatIDs {
+		goName := goFieldName(field.Name)
+		call := fmt.Sprintf("setChatIDValue(v.%s, &t.%s, &t.%s)", goName, goName, channelUsernameName(field.Name))
+		if i == len(chatIDs)-1 {
+			fmt.Fprintf(buf, "\n\treturn %s\n", call)
+			continue
+		}
+		fmt.Fprintf(buf, "\tif err := %s; err != nil {\n\t\treturn err\n\t}\n", call)
+	}
+	buf.WriteString("}\n")
+}
+
+func (g *generator) writeConfig(buf *bytes.Buffer, m *Method) {
+	name := configName(m.Name)
+
+	fmt.Fprintf(buf, "\n// %s contains information about a %s request.\n//\n", name, m.Name)
+	writeDoc(buf, "", strings.Join(m.Description, " "))
+	if len(m.Fields) == 0 {
+		fmt.Fprintf(buf, "type %s struct{}\n", name)
+	} else {
+		fmt.Fprintf(buf, "type %s struct {\n", name)
+	}
+
+	for _, field := range m.Fields {
+		required := ""
+		if field.Required {
+			required = " // required"
+		}
+
+		if field.isChatID() {
+			fmt.Fprintf(buf, "\t%s int64%s\n", goFieldName(field.Name), required)
+			fmt.Fprintf(buf, "\t%s string\n", channelUsernameName(field.Name))
+			continue
+		}
+
+		fmt.Fprintf(buf, "\t%s %s%s\n", goFieldName(field.Name), g.goType(field, true), required)
+	}
+
+	if len(m.Fields) > 0 {
+		buf.WriteString("}\n")
+	}
+
+	fmt.Fprintf(buf, "\n// Method returns %s.\nfunc (config %s) Method() string {\n\treturn %q\n}\n", m.Name, name, m.Name)
+
+	fmt.Fprintf(buf, "\n// Params returns the params of the request.\nfunc (config %s) Params() (Params, error) {\n\tparams := make(Params)\n\n", name)
+
+	var files []Field
+	for _, field := range m.Fields {
+		goName := "config." + goFieldName(field.Name)
+
+		if field.isChatID() {
+			fmt.Fprintf(buf, "\tparams.AddFirstValid(%q, %s, config.%s)\n", field.Name, goName, channelUsernameName(field.Name))
+			continue
+		}
+
+		switch typ := g.goType(field, true); typ {
+		case "RequestFileData":
+			files = append(files, field)
+		case "int":
+			fmt.Fprintf(buf, "\tparams.AddNonZero(%q, %s)\n", field.Name, goName)
+		case "int64":
+			fmt.Fprintf(buf, "\tparams.AddNonZero64(%q, %s)\n", field.Name, goName)
+		case "string":
+			fmt.Fprintf(buf, "\tparams.AddNonEmpty(%q, %s)\n", field.Name, goName)
+		case "bool":
+			fmt.Fprintf(buf, "\tparams.AddBool(%q, %s)\n", field.Name, goName)
+		case "float64":
+			fmt.Fprintf(buf, "\tparams.AddNonZeroFloat(%q, %s)\n", field.Name, goName)
+		default:
+			add := fmt.Sprintf("if err := params.AddInterface(%q, %s); err != nil {\n\treturn params, err\n}\n", field.Name, goName)
+			if strings.HasPrefix(typ, "[]") {
+				add = fmt.Sprintf("if len(%s) > 0 {\n%s}\n", goName, add)
+			}
+			buf.WriteString(add)
+		}
+	}
+
+	buf.WriteString("\n\treturn params, nil\n}\n")
+
+	if len(files) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "\nfunc (config %s) files() []RequestFile {\n\tvar files []RequestFile\n\n", name)
+	for _, field := range files {
+		goName := "config." + goFieldName(field.Name)
+		add := fmt.Sprintf("files = append(files, RequestFile{Name: %q, Data: %s})\n", field.Name, goName)
+		if !field.Required {
+			add = fmt.Sprintf("if %s != nil {\n%s}\n", goName, add)
+		}
+		buf.WriteString(add)
+	}
+	buf.WriteString("\n\treturn files\n}\n")
+}
+
+// getterName returns the name of the BotAPI method returning the result of
+// a Bot API method. Methods returning True or a Message have no getter, as
+// Request and Send already cover them.
+func (g *generator) getterName(m *Method) (string, bool) {
+	if len(m.Returns) != 1 {
+		return "", false
+	}
+
+	switch m.Returns[0] {
+	case "True", "Boolean", "Message":
+		return "", false
+	}
+
+	name := upperFirst(m.Name)
+
+	return name, !g.pkg.BotMethods[name]
+}
+
+func (g *generator) writeGetter(buf *bytes.Buffer, m *Method, name string) {
+	typ := g.goType(Field{Types: m.Returns}, false)
+
+	fmt.Fprintf(buf, "\n// %s makes a %s request and returns its result.\n", name, m.Name)
+	fmt.Fprintf(buf, "func (bot *BotAPI) %s(config %s) (%s, error) {\n", name, configName(m.Name), typ)
+	fmt.Fprintf(buf, "\tvar result %s\n\n", typ)
+	buf.WriteString("\tresp, err := bot.Request(config)\n\tif err != nil {\n\t\treturn result, err\n\t}\n\n")
+	buf.WriteString("\terr = json.Unmarshal(resp.Result, &result)\n\n\treturn result, err\n}\n")
+}
+
+// goType returns the Go type of a field. Files are RequestFileData in
+// configs, which can upload them or refer to existing ones, and strings in
+// types, holding a file ID, a URL or an attach:// reference.
+func (g *generator) goType(field Field, config bool) string {
+	if len(field.Types) == 0 {
+		return "interface{}"
+	}
+
+	for _, t := range field.Types {
+		if t == "InputFile" && config {
+			return "RequestFileData"
+		} else if t == "InputFile" {
+			return "string"
+		}
+	}
+
+	if len(field.Types) > 1 {
+		if field.isChatID() {
+			return "int64"
+		}
+		if field.Types[0] == "Integer" && field.Types[1] == "String" {
+			return "string"
+		}
+		return "interface{}"
+	}
+
+	t := field.Types[0]
+	if strings.HasPrefix(t, "Array of ") {
+		elem := field
+		elem.Types = []string{strings.TrimPrefix(t, "Array of ")}
+		return "[]" + g.goType(elem, config)
+	}
+
+	switch t {
+	case "Integer":
+		if field.is64Bit() {
+			return "int64"
+		}
+		return "int"
+	case "Float", "Float number":
+		return "float64"
+	case "String":
+		return "string"
+	case "Boolean", "True":
+		return "bool"
+	}
+
+	if alias := aliases[t]; alias != "" {
+		return alias
+	}
+
+	if o := g.schema.Types[t]; o != nil {
+		if parent := g.mergedIn(o); parent != "" {
+			t = parent
+		} else if g.separate(o) && !g.pkg.Types[goTypeName(t)] {
+			return "interface{}"
+		}
+	}
+
+	return goTypeName(t)
+}
+
+// separate reports if the subtypes of a type are separate types, as some of
+// them are declared by hand, such as InlineQueryResultArticle. The subtypes
+// of other types are merged in a single struct.
+func (g *generator) separate(o *Object) bool {
+	for _, name := range o.Subtypes {
+		if g.pkg.Types[goTypeName(name)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// merged reports if a type is a subtype merged in the struct of its parent.
+func (g *generator) merged(o *Object) bool {
+	return g.mergedIn(o) != ""
+}
+
+// mergedIn returns the parent a subtype is merged in, or an empty string.
+func (g *generator) mergedIn(o *Object) string {
+	for _, name := range o.SubtypeOf {
+		if parent := g.schema.Types[name]; parent != nil && !g.separate(parent) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// isObject reports if a Go type is a struct, which is a pointer when it
+// is optional.
+func (g *generator) isObject(typ string) bool {
+	for name := range g.schema.Types {
+		if goTypeName(name) == typ {
+			return true
+		}
+	}
+
+	return g.pkg.structs[typ] != nil
+}
+
+// fieldDoc returns the doc comment of a field, which starts with its name.
+// Descriptions already starting with the name, such as "Date of the change",
+// are kept as they are.
+func fieldDoc(goName, description string) string {
+	words := strings.Fields(description)
+	if len(words) > 0 && strings.EqualFold(words[0], goName) {
+		return goName + strings.TrimPrefix(description, words[0])
+	}
+
+	return goName + " " + lowerFirst(description)
+}
+
+func configName(method string) string {
+	return upperFirst(method) + "Config"
+}
+
+// channelUsernameName returns the name of the username field paired with a
+// chat ID field, such as FromChannelUsername for from_chat_id.
+func channelUsernameName(param string) string {
+	return goFieldName(strings.TrimSuffix(param, "chat_id")) + "ChannelUsername"
+}
+
+// goFieldName converts a snake case name to a Go name.
+func goFieldName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if initialism, ok := initialisms[word]; ok {
+			b.WriteString(initialism)
+		} else {
+			b.WriteString(upperFirst(word))
+		}
+	}
+
+	return b.String()
+}
+
+// goTypeName fixes the initialisms of a Bot API type name, such as
+// MessageId.
+func goTypeName(name string) string {
+	var b strings.Builder
+
+	start := 0
+	for i := 1; i <= len(name); i++ {
+		if i < len(name) && !unicode.IsUpper(rune(name[i])) {
+			continue
+		}
+
+		word := name[start:i]
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			word = initialism
+		}
+		b.WriteString(word)
+		start = i
+	}
+
+	return b.String()
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func lowerFirst(s string) string {
+	if s == "" || strings.HasPrefix(s, "True") || strings.HasPrefix(s, "False") {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func joinWords(words []string) string {
+	if len(words) < 2 {
+		return strings.Join(words, "")
+	}
+
+	return strings.Join(words[:len(words)-1], ", ") + " or " + words[len(words)-1]
+}
+
+// writeDoc writes a comment wrapped to 77 columns.
+func writeDoc(buf *bytes.Buffer, indent, text string) {
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(indent)+3+len(line)+1+len(word) > 77 {
+			fmt.Fprintf(buf, "%s// %s\n", indent, line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+
+	if line != "" {
+		fmt.Fprintf(buf, "%s// %s\n", indent, line)
+	}
+}
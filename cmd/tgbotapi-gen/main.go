@@ -0,0 +1,69 @@
+// Command tgbotapi-gen generates types, configs and BotAPI getters of the
+// tgbotapi package from a Bot API schema, and writes a report of which
+// methods and fields the package covers.
+//
+// The schema uses the JSON format of the telegram-bot-api-spec project. Only
+// the methods and types which are not declared by hand-written files are
+// generated, so helpers and configs with special behaviour keep living in
+// their own files.
+//
+// Usage, from the root of the repository:
+//
+//	go run ./cmd/tgbotapi-gen -schema schema/api.json -dir . -report docs/compatibility.md
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "schema/api.json", "Bot API schema")
+	dir := flag.String("dir", ".", "directory of the tgbotapi package")
+	report := flag.String("report", "docs/compatibility.md", "compatibility report, or empty to skip it")
+	flag.Parse()
+
+	data, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Fatalf("schema %s: %s", *schemaPath, err)
+	}
+
+	src, err := scanPackage(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := newGenerator(&s, src, filepath.ToSlash(*schemaPath))
+	files, err := g.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(*dir, name)
+		if content == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Fatal(err)
+			}
+			continue
+		}
+
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *report != "" {
+		if err := os.WriteFile(filepath.Join(*dir, *report), g.report(), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../schema/api.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, err := scanPackage("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := newGenerator(&s, pkg, "schema/api.json")
+	files, err := g.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["docs/compatibility.md"] = g.report()
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join("../..", name))
+		if want == nil {
+			if !os.IsNotExist(err) {
+				t.Errorf("%s should not exist, run go generate", name)
+			}
+			continue
+		}
+
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate", name)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{goFieldName("message_ids"), "MessageIDs"},
+		{goFieldName("web_app_url"), "WebAppURL"},
+		{goTypeName("MessageId"), "MessageID"},
+		{goTypeName("InlineQueryResultGif"), "InlineQueryResultGIF"},
+		{goTypeName("InlineQueryResultCachedMpeg4Gif"), "InlineQueryResultCachedMPEG4GIF"},
+		{channelUsernameName("chat_id"), "ChannelUsername"},
+		{channelUsernameName("from_chat_id"), "FromChannelUsername"},
+		{configName("getUserChatBoosts"), "GetUserChatBoostsConfig"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %s, want %s", test.got, test.want)
+		}
+	}
+}
+
+func TestMergedSubtypeFields(t *testing.T) {
+	s := &Schema{Types: map[string]*Object{
+		"Source": {Name: "Source", Subtypes: []string{"A", "B"}},
+		"A": {Name: "A", SubtypeOf: []string{"Source"}, Fields: []Field{
+			{Name: "source", Types: []string{"String"}, Required: true},
+			{Name: "user", Types: []string{"User"}, Required: true},
+		}},
+		"B": {Name: "B", SubtypeOf: []string{"Source"}, Fields: []Field{
+			{Name: "source", Types: []string{"String"}, Required: true},
+		}},
+	}}
+
+	fields := s.fields(s.Types["Source"])
+	if len(fields) != 2 || !fields[0].Required || fields[1].Required {
+		t.Errorf("unexpected merged fields %+v", fields)
+	}
+}
+
+func TestSeparateSubtypes(t *testing.T) {
+	s := &Schema{Types: map[string]*Object{
+		"Result":        {Name: "Result", Subtypes: []string{"ResultArticle", "ResultGif"}},
+		"ResultArticle": {Name: "ResultArticle", SubtypeOf: []string{"Result"}},
+		"ResultGif":     {Name: "ResultGif", SubtypeOf: []string{"Result"}},
+	}}
+	pkg := &Package{Types: map[string]bool{"ResultArticle": true}}
+	g := newGenerator(s, pkg, "schema.json")
+
+	files, err := g.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := string(files["types_gen.go"])
+	if !strings.Contains(types, "type ResultGIF struct") || strings.Contains(types, "type Result struct") {
+		t.Errorf("subtypes declared by hand should stay separate types:\n%s", types)
+	}
+
+	if typ := g.goType(Field{Types: []string{"Result"}}, false); typ != "interface{}" {
+		t.Errorf("got %s for a type with separate subtypes", typ)
+	}
+}
+
+func TestTypeChatIDFields(t *testing.T) {
+	s := &Schema{Types: map[string]*Object{
+		"Reply": {Name: "Reply", Fields: []Field{
+			{Name: "message_id", Types: []string{"Integer"}, Required: true},
+			{Name: "chat_id", Types: []string{"Integer", "String"}},
+		}},
+	}}
+	g := newGenerator(s, &Package{}, "schema.json")
+
+	files, err := g.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := string(files["types_gen.go"])
+	for _, want := range []string{
+		"ChatID int64 `json:\"-\"`",
+		"ChannelUsername string `json:\"-\"`",
+		"func (t Reply) MarshalJSON() ([]byte, error)",
+		"func (t *Reply) UnmarshalJSON(data []byte) error",
+		`import "encoding/json"`,
+	} {
+		if !strings.Contains(types, want) {
+			t.Errorf("missing %s in:\n%s", want, types)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// report returns a Markdown report of the methods and types of the schema
+// covered by the package.
+func (g *generator) report() []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# Bot API Compatibility\n\n")
+	fmt.Fprintf(&buf, "<!-- Code generated by tgbotapi-gen from %s; DO NOT EDIT. -->\n\n", g.source)
+	fmt.Fprintf(&buf, "This report compares the library with `%s` (%s).\n", g.source, g.schema.Version)
+	buf.WriteString("It is updated by `go generate`, see [Adding Endpoints](./internals/adding-endpoints.md).\n\n")
+	buf.WriteString("- **hand-written**: a config or type in the library's own files.\n")
+	buf.WriteString("- **generated**: declared by the `*_gen.go` files.\n")
+	buf.WriteString("- **missing**: lists the params or fields the hand-written code doesn't send or decode yet.\n")
+
+	var methodRows, typeRows []string
+	var methodsDone, typesDone int
+
+	for _, name := range g.schema.methodNames() {
+		m := g.schema.Methods[name]
+
+		status, missing := "generated", ""
+		if config := g.pkg.Configs[name]; config != "" {
+			status = "hand-written (`" + config + "`)"
+			missing = missingNames(m.Fields, g.pkg.Params(config))
+		} else if g.pkg.Requests[name] {
+			status = "hand-written"
+		}
+
+		if missing == "" {
+			methodsDone++
+		}
+		methodRows = append(methodRows, fmt.Sprintf("| `%s` | %s | %s |", name, status, missing))
+	}
+
+	for _, name := range g.schema.typeNames() {
+		o := g.schema.Types[name]
+		if g.merged(o) || g.separate(o) {
+			continue
+		}
+
+		status, missing := "generated", ""
+		if alias := aliases[name]; alias != "" {
+			status = "hand-written (`" + alias + "`)"
+		} else if goName := goTypeName(name); g.pkg.Types[goName] {
+			status = "hand-written (`" + goName + "`)"
+			missing = missingNames(g.schema.fields(o), g.pkg.Fields(goName))
+		}
+
+		if missing == "" {
+			typesDone++
+		}
+		typeRows = append(typeRows, fmt.Sprintf("| `%s` | %s | %s |", name, status, missing))
+	}
+
+	fmt.Fprintf(&buf, "\n## Methods\n\n%d of %d methods are fully covered.\n\n", methodsDone, len(methodRows))
+	buf.WriteString("| Method | Status | Missing params |\n| --- | --- | --- |\n")
+	buf.WriteString(strings.Join(methodRows, "\n") + "\n")
+
+	fmt.Fprintf(&buf, "\n## Types\n\n%d of %d types are fully covered.\n\n", typesDone, len(typeRows))
+	buf.WriteString("| Type | Status | Missing fields |\n| --- | --- | --- |\n")
+	buf.WriteString(strings.Join(typeRows, "\n") + "\n")
+
+	return buf.Bytes()
+}
+
+func missingNames(fields []Field, covered map[string]bool) string {
+	var missing []string
+	for _, field := range fields {
+		if !covered[field.Name] {
+			missing = append(missing, "`"+field.Name+"`")
+		}
+	}
+	sort.Strings(missing)
+
+	return strings.Join(missing, ", ")
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Package is what the hand-written files of the tgbotapi package declare.
+type Package struct {
+	// Types are the declared type names.
+	Types map[string]bool
+	// BotMethods are the declared methods of BotAPI.
+	BotMethods map[string]bool
+	// Configs are the config types, by the Bot API method they return.
+	Configs map[string]string
+	// Requests are the Bot API methods requested directly by name, without
+	// a config.
+	Requests map[string]bool
+
+	structs map[string]*ast.StructType
+	params  map[string]map[string]bool
+	calls   map[string][]string
+}
+
+// scanPackage parses the hand-written files of a package. Test files and
+// generated files are ignored.
+func scanPackage(dir string) (*Package, error) {
+	p := &Package{
+		Types:      map[string]bool{},
+		BotMethods: map[string]bool{},
+		Configs:    map[string]string{},
+		Requests:   map[string]bool{},
+		structs:    map[string]*ast.StructType{},
+		params:     map[string]map[string]bool{},
+		calls:      map[string][]string{},
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") ||
+			strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_gen.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		p.scanFile(file)
+	}
+
+	return p, nil
+}
+
+func (p *Package) scanFile(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok {
+					p.Types[spec.Name.Name] = true
+					if st, ok := spec.Type.(*ast.StructType); ok {
+						p.structs[spec.Name.Name] = st
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			p.scanFunc(decl)
+		}
+	}
+}
+
+func (p *Package) scanFunc(fn *ast.FuncDecl) {
+	receiver := ""
+	if fn.Recv != nil && len(fn.Recv.List) == 1 {
+		receiver = typeName(fn.Recv.List[0].Type)
+	}
+
+	if receiver == "BotAPI" {
+		p.BotMethods[fn.Name.Name] = true
+	}
+
+	// Params added by functions, such as addReplyParameters, are attributed
+	// to the configs calling them.
+	owner := receiver
+	if fn.Recv == nil {
+		owner = funcKey(fn.Name.Name)
+	}
+
+	if fn.Body == nil {
+		return
+	}
+
+	if fn.Name.Name == "Method" && receiver != "" {
+		if method, ok := returnedString(fn.Body); ok {
+			p.Configs[method] = receiver
+		}
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if ident, ok := n.Fun.(*ast.Ident); ok && owner != "" {
+				p.calls[owner] = append(p.calls[owner], funcKey(ident.Name))
+			}
+
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || len(n.Args) == 0 {
+				break
+			}
+
+			if sel.Sel.Name == "MakeRequest" {
+				if method, ok := stringLit(n.Args[0]); ok {
+					p.Requests[method] = true
+				}
+			} else if strings.HasPrefix(sel.Sel.Name, "Add") && owner != "" {
+				if key, ok := stringLit(n.Args[0]); ok {
+					p.addParam(owner, key)
+				}
+			}
+		case *ast.IndexExpr:
+			if key, ok := stringLit(n.Index); ok && owner != "" {
+				p.addParam(owner, key)
+			}
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == "Name" && owner != "" {
+					if key, ok := stringLit(kv.Value); ok {
+						p.addParam(owner, key)
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+func (p *Package) addParam(typ, key string) {
+	if p.params[typ] == nil {
+		p.params[typ] = map[string]bool{}
+	}
+	p.params[typ][key] = true
+}
+
+// Params returns the params sent by a config type, including the ones of
+// the types it embeds.
+func (p *Package) Params(typ string) map[string]bool {
+	keys := map[string]bool{}
+	p.collect(typ, keys, map[string]bool{}, p.ownParams)
+
+	return keys
+}
+
+// Fields returns the JSON names of the fields of a struct type, including
+// the ones of the types it embeds.
+func (p *Package) Fields(typ string) map[string]bool {
+	keys := map[string]bool{}
+	p.collect(typ, keys, map[string]bool{}, p.jsonFields)
+
+	return keys
+}
+
+func (p *Package) collect(typ string, keys, seen map[string]bool, own func(string) map[string]bool) {
+	if seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	for key := range own(typ) {
+		keys[key] = true
+	}
+
+	st := p.structs[typ]
+	if st == nil {
+		return
+	}
+
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			p.collect(typeName(field.Type), keys, seen, own)
+		}
+	}
+}
+
+// ownParams returns the params sent by the methods of a type, or by a
+// function, including the ones of the functions they call.
+func (p *Package) ownParams(owner string) map[string]bool {
+	keys := map[string]bool{}
+
+	seen := map[string]bool{}
+	var walk func(owner string)
+	walk = func(owner string) {
+		if seen[owner] {
+			return
+		}
+		seen[owner] = true
+
+		for key := range p.params[owner] {
+			keys[key] = true
+		}
+		for _, fn := range p.calls[owner] {
+			walk(fn)
+		}
+	}
+	walk(owner)
+
+	return keys
+}
+
+func (p *Package) jsonFields(typ string) map[string]bool {
+	st := p.structs[typ]
+	if st == nil {
+		return nil
+	}
+
+	keys := map[string]bool{}
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		name := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+
+	return keys
+}
+
+// returnedString returns the string literal returned by a function body made
+// of a single return statement.
+func returnedString(body *ast.BlockStmt) (string, bool) {
+	if len(body.List) != 1 {
+		return "", false
+	}
+
+	ret, ok := body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return "", false
+	}
+
+	return stringLit(ret.Results[0])
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	s, err := strconv.Unquote(lit.Value)
+
+	return s, err == nil
+}
+
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return typeName(t.X)
+	}
+
+	return ""
+}
+
+func funcKey(name string) string {
+	return "func " + name
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// Schema is a machine-readable description of the Bot API.
+type Schema struct {
+	Version     string             `json:"version"`
+	ReleaseDate string             `json:"release_date"`
+	Methods     map[string]*Method `json:"methods"`
+	Types       map[string]*Object `json:"types"`
+}
+
+// Method is a Bot API method.
+type Method struct {
+	Name        string   `json:"name"`
+	Description []string `json:"description"`
+	Returns     []string `json:"returns"`
+	Fields      []Field  `json:"fields"`
+}
+
+// Object is a Bot API type. Types which are one of several other types
+// list them in Subtypes and have no fields.
+type Object struct {
+	Name        string   `json:"name"`
+	Description []string `json:"description"`
+	Fields      []Field  `json:"fields"`
+	Subtypes    []string `json:"subtypes"`
+	SubtypeOf   []string `json:"subtype_of"`
+}
+
+// Field is a param of a method or a field of a type.
+type Field struct {
+	Name        string   `json:"name"`
+	Types       []string `json:"types"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+}
+
+func (s *Schema) methodNames() []string {
+	names := make([]string, 0, len(s.Methods))
+	for name := range s.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (s *Schema) typeNames() []string {
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// fields returns the fields of a type. The fields of a type with subtypes
+// are merged, as the package represents such types as a single struct. A
+// merged field is only required if all the subtypes require it.
+func (s *Schema) fields(o *Object) []Field {
+	if len(o.Subtypes) == 0 {
+		return o.Fields
+	}
+
+	var fields []Field
+	index := map[string]int{}
+	count := map[string]int{}
+
+	for _, name := range o.Subtypes {
+		subtype := s.Types[name]
+		if subtype == nil {
+			continue
+		}
+
+		for _, field := range s.fields(subtype) {
+			count[field.Name]++
+
+			if i, ok := index[field.Name]; ok {
+				fields[i].Required = fields[i].Required && field.Required
+				if fields[i].Description != field.Description {
+					fields[i].Description = trimAlways(fields[i].Description)
+				}
+				continue
+			}
+
+			index[field.Name] = len(fields)
+			fields = append(fields, field)
+		}
+	}
+
+	for i := range fields {
+		if count[fields[i].Name] < len(o.Subtypes) {
+			fields[i].Required = false
+		}
+	}
+
+	return fields
+}
+
+// isChatID reports if a field is a chat identifier which can also be a
+// channel username.
+func (f Field) isChatID() bool {
+	return strings.HasSuffix(f.Name, "chat_id") && len(f.Types) == 2 &&
+		f.Types[0] == "Integer" && f.Types[1] == "String"
+}
+
+// is64Bit reports if an Integer field may not fit in 32 bits.
+func (f Field) is64Bit() bool {
+	return strings.Contains(f.Description, "32 significant bits") ||
+		strings.HasSuffix(f.Name, "chat_id") || strings.HasSuffix(f.Name, "user_id")
+}
+
+// trimAlways removes the value a subtype always has from the description of
+// a merged field, such as `, always "emoji"`.
+func trimAlways(description string) string {
+	if i := strings.Index(description, ", always "); i >= 0 {
+		return description[:i]
+	}
+
+	return description
+}
@@ -2,10 +2,12 @@ package tgbotapi
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
+	"reflect"
 	"strconv"
 )
 
@@ -33,6 +35,16 @@ const (
 	ChatUploadVideoNote = "upload_video_note"
 )
 
+// Constant values for the allowed colors of forum topic icons
+const (
+	ForumTopicIconBlue   = 0x6FB9F0
+	ForumTopicIconYellow = 0xFFD67E
+	ForumTopicIconViolet = 0xCB86DB
+	ForumTopicIconGreen  = 0x8EEE98
+	ForumTopicIconRose   = 0xFF93B2
+	ForumTopicIconRed    = 0xFB6F5F
+)
+
 // API errors
 const (
 	// ErrAPIForbidden happens when a token is bad
@@ -92,6 +104,57 @@ const (
 	// UpdateTypeChatMember is when the bot must be an administrator in the chat and must explicitly specify
 	// this update in the list of allowed_updates to receive these updates.
 	UpdateTypeChatMember = "chat_member"
+
+	// UpdateTypeMessageReaction is when a reaction to a message was changed by a user. The bot must be an
+	// administrator in the chat and must explicitly specify this update in the list of allowed_updates.
+	UpdateTypeMessageReaction = "message_reaction"
+
+	// UpdateTypeMessageReactionCount is when reactions to a message with anonymous reactions were changed. The bot
+	// must be an administrator in the chat and must explicitly specify this update in the list of allowed_updates.
+	UpdateTypeMessageReactionCount = "message_reaction_count"
+
+	// UpdateTypeBusinessConnection is when the bot was connected to or disconnected from a business account, or a
+	// user edited an existing connection with the bot
+	UpdateTypeBusinessConnection = "business_connection"
+
+	// UpdateTypeBusinessMessage is new message from a connected business account
+	UpdateTypeBusinessMessage = "business_message"
+
+	// UpdateTypeEditedBusinessMessage is new version of a message from a connected business account
+	UpdateTypeEditedBusinessMessage = "edited_business_message"
+
+	// UpdateTypeDeletedBusinessMessages is when messages were deleted from a connected business account
+	UpdateTypeDeletedBusinessMessages = "deleted_business_messages"
+)
+
+// Telegram Stars constants
+const (
+	// CurrencyStars is the currency of payments in Telegram Stars.
+	CurrencyStars = "XTR"
+	// StarsSubscriptionPeriod is the only supported subscription period of
+	// invoice links, 30 days in seconds.
+	StarsSubscriptionPeriod = 2592000
+)
+
+// Constant values for sticker formats
+const (
+	StickerFormatStatic   = "static"
+	StickerFormatAnimated = "animated"
+	StickerFormatVideo    = "video"
+)
+
+// Constant values for sticker types
+const (
+	StickerTypeRegular     = "regular"
+	StickerTypeMask        = "mask"
+	StickerTypeCustomEmoji = "custom_emoji"
+)
+
+// Constant values for the types of reactions
+const (
+	ReactionTypeEmoji       = "emoji"
+	ReactionTypeCustomEmoji = "custom_emoji"
+	ReactionTypePaid        = "paid"
 )
 
 // Library errors
@@ -264,12 +327,21 @@ func (CloseConfig) Params() (Params, error) {
 
 // BaseChat is base type for all chat config types.
 type BaseChat struct {
-	ChatID                   int64 // required
-	ChannelUsername          string
-	ProtectContent           bool
-	ReplyToMessageID         int
-	ReplyMarkup              interface{}
-	DisableNotification      bool
+	ChatID          int64 // required
+	ChannelUsername string
+	// BusinessConnectionID sends the message on behalf of a connected
+	// business account.
+	BusinessConnectionID string
+	MessageThreadID      int
+	ProtectContent       bool
+	// ReplyParameters describes the message to reply to. It replaces
+	// ReplyToMessageID and AllowSendingWithoutReply when set.
+	ReplyParameters *ReplyParameters
+	// Deprecated: use ReplyParameters instead.
+	ReplyToMessageID    int
+	ReplyMarkup         interface{}
+	DisableNotification bool
+	// Deprecated: use ReplyParameters instead.
 	AllowSendingWithoutReply bool
 }
 
@@ -277,16 +349,34 @@ func (chat *BaseChat) Params() (Params, error) {
 	params := make(Params)
 
 	params.AddFirstValid("chat_id", chat.ChatID, chat.ChannelUsername)
-	params.AddNonZero("reply_to_message_id", chat.ReplyToMessageID)
+	params.AddNonEmpty("business_connection_id", chat.BusinessConnectionID)
+	params.AddNonZero("message_thread_id", chat.MessageThreadID)
 	params.AddBool("disable_notification", chat.DisableNotification)
-	params.AddBool("allow_sending_without_reply", chat.AllowSendingWithoutReply)
 	params.AddBool("protect_content", chat.ProtectContent)
 
+	if err := addReplyParameters(params, chat.ReplyParameters, chat.ReplyToMessageID, chat.AllowSendingWithoutReply); err != nil {
+		return params, err
+	}
+
 	err := params.AddInterface("reply_markup", chat.ReplyMarkup)
 
 	return params, err
 }
 
+// addReplyParameters adds the reply_parameters param, or the older
+// reply_to_message_id and allow_sending_without_reply params when there are
+// no ReplyParameters.
+func addReplyParameters(params Params, reply *ReplyParameters, replyToMessageID int, allowSendingWithoutReply bool) error {
+	if reply != nil {
+		return params.AddInterface("reply_parameters", reply)
+	}
+
+	params.AddNonZero("reply_to_message_id", replyToMessageID)
+	params.AddBool("allow_sending_without_reply", allowSendingWithoutReply)
+
+	return nil
+}
+
 // BaseFile is a base type for all file config types.
 type BaseFile struct {
 	BaseChat
@@ -301,14 +391,18 @@ func (file BaseFile) Params() (Params, error) {
 type BaseEdit struct {
 	ChatID          int64
 	ChannelUsername string
-	MessageID       int
-	InlineMessageID string
-	ReplyMarkup     *InlineKeyboardMarkup
+	// BusinessConnectionID edits a message sent on behalf of a connected
+	// business account.
+	BusinessConnectionID string
+	MessageID            int
+	InlineMessageID      string
+	ReplyMarkup          *InlineKeyboardMarkup
 }
 
 func (edit BaseEdit) Params() (Params, error) {
 	params := make(Params)
 
+	params.AddNonEmpty("business_connection_id", edit.BusinessConnectionID)
 	if edit.InlineMessageID != "" {
 		params["inline_message_id"] = edit.InlineMessageID
 	} else {
@@ -324,9 +418,13 @@ func (edit BaseEdit) Params() (Params, error) {
 // MessageConfig contains information about a SendMessage request.
 type MessageConfig struct {
 	BaseChat
-	Text                  string
-	ParseMode             string
-	Entities              []MessageEntity
+	Text      string
+	ParseMode string
+	Entities  []MessageEntity
+	// LinkPreviewOptions controls the link preview. It replaces
+	// DisableWebPagePreview when set.
+	LinkPreviewOptions *LinkPreviewOptions
+	// Deprecated: use LinkPreviewOptions instead.
 	DisableWebPagePreview bool
 }
 
@@ -337,13 +435,27 @@ func (config MessageConfig) Params() (Params, error) {
 	}
 
 	params.AddNonEmpty("text", config.Text)
-	params.AddBool("disable_web_page_preview", config.DisableWebPagePreview)
 	params.AddNonEmpty("parse_mode", config.ParseMode)
+	if err := addLinkPreviewOptions(params, config.LinkPreviewOptions, config.DisableWebPagePreview); err != nil {
+		return params, err
+	}
 	err = params.AddInterface("entities", config.Entities)
 
 	return params, err
 }
 
+// addLinkPreviewOptions adds the link_preview_options param, or the older
+// disable_web_page_preview param when there are no LinkPreviewOptions.
+func addLinkPreviewOptions(params Params, options *LinkPreviewOptions, disableWebPagePreview bool) error {
+	if options != nil {
+		return params.AddInterface("link_preview_options", options)
+	}
+
+	params.AddBool("disable_web_page_preview", disableWebPagePreview)
+
+	return nil
+}
+
 func (config MessageConfig) Method() string {
 	return "sendMessage"
 }
@@ -405,6 +517,8 @@ func (config CopyMessageConfig) Method() string {
 // PhotoConfig contains information about a SendPhoto request.
 type PhotoConfig struct {
 	BaseFile
+	Thumbnail RequestFileData
+	// Deprecated: use Thumbnail instead.
 	Thumb           RequestFileData
 	Caption         string
 	ParseMode       string
@@ -434,10 +548,10 @@ func (config PhotoConfig) files() []RequestFile {
 		Data: config.File,
 	}}
 
-	if config.Thumb != nil {
+	if thumbnail := thumbnailFile(config.Thumbnail, config.Thumb); thumbnail != nil {
 		files = append(files, RequestFile{
-			Name: "thumb",
-			Data: config.Thumb,
+			Name: "thumbnail",
+			Data: thumbnail,
 		})
 	}
 
@@ -447,6 +561,8 @@ func (config PhotoConfig) files() []RequestFile {
 // AudioConfig contains information about a SendAudio request.
 type AudioConfig struct {
 	BaseFile
+	Thumbnail RequestFileData
+	// Deprecated: use Thumbnail instead.
 	Thumb           RequestFileData
 	Caption         string
 	ParseMode       string
@@ -482,10 +598,10 @@ func (config AudioConfig) files() []RequestFile {
 		Data: config.File,
 	}}
 
-	if config.Thumb != nil {
+	if thumbnail := thumbnailFile(config.Thumbnail, config.Thumb); thumbnail != nil {
 		files = append(files, RequestFile{
-			Name: "thumb",
-			Data: config.Thumb,
+			Name: "thumbnail",
+			Data: thumbnail,
 		})
 	}
 
@@ -495,6 +611,8 @@ func (config AudioConfig) files() []RequestFile {
 // DocumentConfig contains information about a SendDocument request.
 type DocumentConfig struct {
 	BaseFile
+	Thumbnail RequestFileData
+	// Deprecated: use Thumbnail instead.
 	Thumb                       RequestFileData
 	Caption                     string
 	ParseMode                   string
@@ -504,10 +622,14 @@ type DocumentConfig struct {
 
 func (config DocumentConfig) Params() (Params, error) {
 	params, err := config.BaseFile.Params()
+	if err != nil {
+		return params, err
+	}
 
 	params.AddNonEmpty("caption", config.Caption)
 	params.AddNonEmpty("parse_mode", config.ParseMode)
 	params.AddBool("disable_content_type_detection", config.DisableContentTypeDetection)
+	err = params.AddInterface("caption_entities", config.CaptionEntities)
 
 	return params, err
 }
@@ -522,10 +644,10 @@ func (config DocumentConfig) files() []RequestFile {
 		Data: config.File,
 	}}
 
-	if config.Thumb != nil {
+	if thumbnail := thumbnailFile(config.Thumbnail, config.Thumb); thumbnail != nil {
 		files = append(files, RequestFile{
-			Name: "thumb",
-			Data: config.Thumb,
+			Name: "thumbnail",
+			Data: thumbnail,
 		})
 	}
 
@@ -555,6 +677,8 @@ func (config StickerConfig) files() []RequestFile {
 // VideoConfig contains information about a SendVideo request.
 type VideoConfig struct {
 	BaseFile
+	Thumbnail RequestFileData
+	// Deprecated: use Thumbnail instead.
 	Thumb             RequestFileData
 	Duration          int
 	Caption           string
@@ -588,10 +712,10 @@ func (config VideoConfig) files() []RequestFile {
 		Data: config.File,
 	}}
 
-	if config.Thumb != nil {
+	if thumbnail := thumbnailFile(config.Thumbnail, config.Thumb); thumbnail != nil {
 		files = append(files, RequestFile{
-			Name: "thumb",
-			Data: config.Thumb,
+			Name: "thumbnail",
+			Data: thumbnail,
 		})
 	}
 
@@ -601,7 +725,9 @@ func (config VideoConfig) files() []RequestFile {
 // AnimationConfig contains information about a SendAnimation request.
 type AnimationConfig struct {
 	BaseFile
-	Duration        int
+	Duration  int
+	Thumbnail RequestFileData
+	// Deprecated: use Thumbnail instead.
 	Thumb           RequestFileData
 	Caption         string
 	ParseMode       string
@@ -632,10 +758,10 @@ func (config AnimationConfig) files() []RequestFile {
 		Data: config.File,
 	}}
 
-	if config.Thumb != nil {
+	if thumbnail := thumbnailFile(config.Thumbnail, config.Thumb); thumbnail != nil {
 		files = append(files, RequestFile{
-			Name: "thumb",
-			Data: config.Thumb,
+			Name: "thumbnail",
+			Data: thumbnail,
 		})
 	}
 
@@ -645,6 +771,8 @@ func (config AnimationConfig) files() []RequestFile {
 // VideoNoteConfig contains information about a SendVideoNote request.
 type VideoNoteConfig struct {
 	BaseFile
+	Thumbnail RequestFileData
+	// Deprecated: use Thumbnail instead.
 	Thumb    RequestFileData
 	Duration int
 	Length   int
@@ -669,10 +797,10 @@ func (config VideoNoteConfig) files() []RequestFile {
 		Data: config.File,
 	}}
 
-	if config.Thumb != nil {
+	if thumbnail := thumbnailFile(config.Thumbnail, config.Thumb); thumbnail != nil {
 		files = append(files, RequestFile{
-			Name: "thumb",
-			Data: config.Thumb,
+			Name: "thumbnail",
+			Data: thumbnail,
 		})
 	}
 
@@ -682,6 +810,8 @@ func (config VideoNoteConfig) files() []RequestFile {
 // VoiceConfig contains information about a SendVoice request.
 type VoiceConfig struct {
 	BaseFile
+	Thumbnail RequestFileData
+	// Deprecated: use Thumbnail instead.
 	Thumb           RequestFileData
 	Caption         string
 	ParseMode       string
@@ -713,16 +843,26 @@ func (config VoiceConfig) files() []RequestFile {
 		Data: config.File,
 	}}
 
-	if config.Thumb != nil {
+	if thumbnail := thumbnailFile(config.Thumbnail, config.Thumb); thumbnail != nil {
 		files = append(files, RequestFile{
-			Name: "thumb",
-			Data: config.Thumb,
+			Name: "thumbnail",
+			Data: thumbnail,
 		})
 	}
 
 	return files
 }
 
+// thumbnailFile returns the thumbnail of a config, preferring Thumbnail over
+// the deprecated Thumb.
+func thumbnailFile(thumbnail, thumb RequestFileData) RequestFileData {
+	if thumbnail != nil {
+		return thumbnail
+	}
+
+	return thumb
+}
+
 // LocationConfig contains information about a SendLocation request.
 type LocationConfig struct {
 	BaseChat
@@ -992,9 +1132,13 @@ func (config ChatActionConfig) Method() string {
 // EditMessageTextConfig allows you to modify the text in a message.
 type EditMessageTextConfig struct {
 	BaseEdit
-	Text                  string
-	ParseMode             string
-	Entities              []MessageEntity
+	Text      string
+	ParseMode string
+	Entities  []MessageEntity
+	// LinkPreviewOptions controls the link preview. It replaces
+	// DisableWebPagePreview when set.
+	LinkPreviewOptions *LinkPreviewOptions
+	// Deprecated: use LinkPreviewOptions instead.
 	DisableWebPagePreview bool
 }
 
@@ -1006,7 +1150,9 @@ func (config EditMessageTextConfig) Params() (Params, error) {
 
 	params["text"] = config.Text
 	params.AddNonEmpty("parse_mode", config.ParseMode)
-	params.AddBool("disable_web_page_preview", config.DisableWebPagePreview)
+	if err := addLinkPreviewOptions(params, config.LinkPreviewOptions, config.DisableWebPagePreview); err != nil {
+		return params, err
+	}
 	err = params.AddInterface("entities", config.Entities)
 
 	return params, err
@@ -1237,11 +1383,47 @@ func (config InlineConfig) Params() (Params, error) {
 	params.AddNonEmpty("next_offset", config.NextOffset)
 	params.AddNonEmpty("switch_pm_text", config.SwitchPMText)
 	params.AddNonEmpty("switch_pm_parameter", config.SwitchPMParameter)
-	err := params.AddInterface("results", config.Results)
+	results := make([]interface{}, len(config.Results))
+	for i, result := range config.Results {
+		results[i] = inlineQueryResultThumbnail(result)
+	}
+	err := params.AddInterface("results", results)
 
 	return params, err
 }
 
+// inlineQueryResultThumbnail returns a copy of an inline query result with
+// the deprecated ThumbURL, ThumbWidth and ThumbHeight moved to the Thumbnail
+// fields replacing them, which are kept if they are set.
+func inlineQueryResultThumbnail(result interface{}) interface{} {
+	v := reflect.ValueOf(result)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return result
+	}
+
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+
+	changed := false
+	for _, name := range []string{"URL", "Width", "Height"} {
+		deprecated := copied.FieldByName("Thumb" + name)
+		field := copied.FieldByName("Thumbnail" + name)
+		if deprecated.IsValid() && field.IsValid() && !deprecated.IsZero() && field.IsZero() {
+			field.Set(deprecated)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return result
+	}
+
+	return copied.Interface()
+}
+
 // AnswerWebAppQueryConfig is used to set the result of an interaction with a
 // Web App and send a corresponding message on behalf of the user to the chat
 // from which the query originated.
@@ -1260,7 +1442,7 @@ func (config AnswerWebAppQueryConfig) Params() (Params, error) {
 	params := make(Params)
 
 	params["web_app_query_id"] = config.WebAppQueryID
-	err := params.AddInterface("result", config.Result)
+	err := params.AddInterface("result", inlineQueryResultThumbnail(config.Result))
 
 	return params, err
 }
@@ -1383,6 +1565,7 @@ type PromoteChatMemberConfig struct {
 	CanRestrictMembers  bool
 	CanPinMessages      bool
 	CanPromoteMembers   bool
+	CanManageTopics     bool
 }
 
 func (config PromoteChatMemberConfig) Method() string {
@@ -1406,6 +1589,7 @@ func (config PromoteChatMemberConfig) Params() (Params, error) {
 	params.AddBool("can_restrict_members", config.CanRestrictMembers)
 	params.AddBool("can_pin_messages", config.CanPinMessages)
 	params.AddBool("can_promote_members", config.CanPromoteMembers)
+	params.AddBool("can_manage_topics", config.CanManageTopics)
 
 	return params, nil
 }
@@ -1726,7 +1910,7 @@ type InvoiceConfig struct {
 	Title                     string         // required
 	Description               string         // required
 	Payload                   string         // required
-	ProviderToken             string         // required
+	ProviderToken             string         // empty for payments in Telegram Stars
 	Currency                  string         // required
 	Prices                    []LabeledPrice // required
 	MaxTipAmount              int
@@ -1747,6 +1931,10 @@ type InvoiceConfig struct {
 }
 
 func (config InvoiceConfig) Params() (Params, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	params, err := config.BaseChat.Params()
 	if err != nil {
 		return params, err
@@ -1755,7 +1943,7 @@ func (config InvoiceConfig) Params() (Params, error) {
 	params["title"] = config.Title
 	params["description"] = config.Description
 	params["payload"] = config.Payload
-	params["provider_token"] = config.ProviderToken
+	params.AddNonEmpty("provider_token", config.ProviderToken)
 	params["currency"] = config.Currency
 	if err = params.AddInterface("prices", config.Prices); err != nil {
 		return params, err
@@ -1784,6 +1972,120 @@ func (config InvoiceConfig) Method() string {
 	return "sendInvoice"
 }
 
+// Validate checks that invoices in Telegram Stars have a single price, no
+// provider token and no tips.
+func (config InvoiceConfig) Validate() error {
+	return validateStarsInvoice(config.Currency, config.ProviderToken, config.Prices, config.MaxTipAmount, config.SuggestedTipAmounts)
+}
+
+// InvoiceLinkConfig contains information for createInvoiceLink request.
+type InvoiceLinkConfig struct {
+	// BusinessConnectionID creates the link on behalf of a business account,
+	// for payments in Telegram Stars only.
+	BusinessConnectionID string
+	Title                string         // required
+	Description          string         // required
+	Payload              string         // required
+	ProviderToken        string         // empty for payments in Telegram Stars
+	Currency             string         // required
+	Prices               []LabeledPrice // required
+	// SubscriptionPeriod makes the link a subscription renewed after this
+	// number of seconds. It must be StarsSubscriptionPeriod, in Telegram
+	// Stars.
+	SubscriptionPeriod        int
+	MaxTipAmount              int
+	SuggestedTipAmounts       []int
+	ProviderData              string
+	PhotoURL                  string
+	PhotoSize                 int
+	PhotoWidth                int
+	PhotoHeight               int
+	NeedName                  bool
+	NeedPhoneNumber           bool
+	NeedEmail                 bool
+	NeedShippingAddress       bool
+	SendPhoneNumberToProvider bool
+	SendEmailToProvider       bool
+	IsFlexible                bool
+}
+
+func (config InvoiceLinkConfig) Params() (Params, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := make(Params)
+
+	params.AddNonEmpty("business_connection_id", config.BusinessConnectionID)
+	params["title"] = config.Title
+	params["description"] = config.Description
+	params["payload"] = config.Payload
+	params.AddNonEmpty("provider_token", config.ProviderToken)
+	params["currency"] = config.Currency
+	if err := params.AddInterface("prices", config.Prices); err != nil {
+		return params, err
+	}
+
+	params.AddNonZero("subscription_period", config.SubscriptionPeriod)
+	params.AddNonZero("max_tip_amount", config.MaxTipAmount)
+	if len(config.SuggestedTipAmounts) > 0 {
+		if err := params.AddInterface("suggested_tip_amounts", config.SuggestedTipAmounts); err != nil {
+			return params, err
+		}
+	}
+	params.AddNonEmpty("provider_data", config.ProviderData)
+	params.AddNonEmpty("photo_url", config.PhotoURL)
+	params.AddNonZero("photo_size", config.PhotoSize)
+	params.AddNonZero("photo_width", config.PhotoWidth)
+	params.AddNonZero("photo_height", config.PhotoHeight)
+	params.AddBool("need_name", config.NeedName)
+	params.AddBool("need_phone_number", config.NeedPhoneNumber)
+	params.AddBool("need_email", config.NeedEmail)
+	params.AddBool("need_shipping_address", config.NeedShippingAddress)
+	params.AddBool("is_flexible", config.IsFlexible)
+	params.AddBool("send_phone_number_to_provider", config.SendPhoneNumberToProvider)
+	params.AddBool("send_email_to_provider", config.SendEmailToProvider)
+
+	return params, nil
+}
+
+func (config InvoiceLinkConfig) Method() string {
+	return "createInvoiceLink"
+}
+
+// Validate checks that invoice links in Telegram Stars have a single price,
+// no provider token and no tips, and that only they are subscriptions.
+func (config InvoiceLinkConfig) Validate() error {
+	if config.SubscriptionPeriod != 0 {
+		if config.Currency != CurrencyStars {
+			return errors.New("subscriptions must be paid in Telegram Stars")
+		}
+		if config.SubscriptionPeriod != StarsSubscriptionPeriod {
+			return fmt.Errorf("subscription period must be %d seconds", StarsSubscriptionPeriod)
+		}
+	}
+
+	return validateStarsInvoice(config.Currency, config.ProviderToken, config.Prices, config.MaxTipAmount, config.SuggestedTipAmounts)
+}
+
+func validateStarsInvoice(currency, providerToken string, prices []LabeledPrice, maxTipAmount int, suggestedTipAmounts []int) error {
+	if currency != CurrencyStars {
+		return nil
+	}
+
+	if providerToken != "" {
+		return errors.New("invoices in Telegram Stars must not have a provider token")
+	}
+	if len(prices) != 1 {
+		return fmt.Errorf("invoices in Telegram Stars must have exactly one price, got %d", len(prices))
+	}
+	if maxTipAmount != 0 || len(suggestedTipAmounts) != 0 {
+		return errors.New("invoices in Telegram Stars don't support tips")
+	}
+
+	return nil
+}
+
 // ShippingConfig contains information for answerShippingQuery request.
 type ShippingConfig struct {
 	ShippingQueryID string // required
@@ -1828,6 +2130,27 @@ func (config PreCheckoutConfig) Params() (Params, error) {
 	return params, nil
 }
 
+// SetPassportDataErrorsConfig informs a user that some of the Telegram
+// Passport elements they provided contain errors. The user will not be able
+// to re-submit their Passport to you until the errors are fixed.
+type SetPassportDataErrorsConfig struct {
+	UserID int64                  // required
+	Errors []PassportElementError // required
+}
+
+func (config SetPassportDataErrorsConfig) Method() string {
+	return "setPassportDataErrors"
+}
+
+func (config SetPassportDataErrorsConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	err := params.AddInterface("errors", config.Errors)
+
+	return params, err
+}
+
 // DeleteMessageConfig contains information of a message in a chat to delete.
 type DeleteMessageConfig struct {
 	ChannelUsername string
@@ -1867,6 +2190,37 @@ func (config DeleteMessagesConfig) Params() (Params, error) {
 	return params, nil
 }
 
+// EditForumTopicConfig allows you to edit the name and icon of a forum topic.
+//
+// Other forum topic requests are generated from the Bot API schema, this one
+// can remove the icon by setting IconCustomEmojiID to an empty string.
+type EditForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	MessageThreadID int // required
+	Name            string
+	// IconCustomEmojiID is the new icon of the topic, or an empty string to
+	// remove it. The current icon is kept if it is nil.
+	IconCustomEmojiID *string
+}
+
+func (config EditForumTopicConfig) Method() string {
+	return "editForumTopic"
+}
+
+func (config EditForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
+	params.AddNonEmpty("name", config.Name)
+	if config.IconCustomEmojiID != nil {
+		params["icon_custom_emoji_id"] = *config.IconCustomEmojiID
+	}
+
+	return params, nil
+}
+
 // PinChatMessageConfig contains information of a message in a chat to pin.
 type PinChatMessageConfig struct {
 	ChatID              int64
@@ -2024,8 +2378,14 @@ func (config GetStickerSetConfig) Params() (Params, error) {
 }
 
 // UploadStickerConfig allows you to upload a sticker for use in a set later.
+//
+// You must set either Sticker and StickerFormat, or PNGSticker.
 type UploadStickerConfig struct {
-	UserID     int64
+	UserID int64
+	// Sticker is a file in the .WEBP, .PNG, .TGS or .WEBM format.
+	Sticker       RequestFileData
+	StickerFormat string
+	// Deprecated: use Sticker and StickerFormat instead.
 	PNGSticker RequestFileData
 }
 
@@ -2037,11 +2397,19 @@ func (config UploadStickerConfig) Params() (Params, error) {
 	params := make(Params)
 
 	params.AddNonZero64("user_id", config.UserID)
+	params.AddNonEmpty("sticker_format", config.StickerFormat)
 
 	return params, nil
 }
 
 func (config UploadStickerConfig) files() []RequestFile {
+	if config.Sticker != nil {
+		return []RequestFile{{
+			Name: "sticker",
+			Data: config.Sticker,
+		}}
+	}
+
 	return []RequestFile{{
 		Name: "png_sticker",
 		Data: config.PNGSticker,
@@ -2050,16 +2418,28 @@ func (config UploadStickerConfig) files() []RequestFile {
 
 // NewStickerSetConfig allows creating a new sticker set.
 //
-// You must set either PNGSticker or TGSSticker.
+// You must set either Stickers, or PNGSticker or TGSSticker.
 type NewStickerSetConfig struct {
-	UserID        int64
-	Name          string
-	Title         string
-	PNGSticker    RequestFileData
-	TGSSticker    RequestFileData
-	Emojis        string
+	UserID   int64
+	Name     string
+	Title    string
+	Stickers []InputSticker
+	// StickerType is one of StickerTypeRegular, StickerTypeMask or
+	// StickerTypeCustomEmoji, regular by default.
+	StickerType string
+	// NeedsRepainting is for custom emoji sets only.
+	NeedsRepainting bool
+
+	// Deprecated: use Stickers instead.
+	PNGSticker RequestFileData
+	// Deprecated: use Stickers instead.
+	TGSSticker RequestFileData
+	// Deprecated: use the EmojiList of Stickers instead.
+	Emojis string
+	// Deprecated: use StickerType instead.
 	ContainsMasks bool
-	MaskPosition  *MaskPosition
+	// Deprecated: use the MaskPosition of Stickers instead.
+	MaskPosition *MaskPosition
 }
 
 func (config NewStickerSetConfig) Method() string {
@@ -2073,6 +2453,15 @@ func (config NewStickerSetConfig) Params() (Params, error) {
 	params["name"] = config.Name
 	params["title"] = config.Title
 
+	if len(config.Stickers) > 0 {
+		params.AddNonEmpty("sticker_type", config.StickerType)
+		params.AddBool("needs_repainting", config.NeedsRepainting)
+
+		err := params.AddInterface("stickers", prepareInputStickersForParams(config.Stickers))
+
+		return params, err
+	}
+
 	params["emojis"] = config.Emojis
 
 	params.AddBool("contains_masks", config.ContainsMasks)
@@ -2083,6 +2472,10 @@ func (config NewStickerSetConfig) Params() (Params, error) {
 }
 
 func (config NewStickerSetConfig) files() []RequestFile {
+	if len(config.Stickers) > 0 {
+		return prepareInputStickersForFiles(config.Stickers)
+	}
+
 	if config.PNGSticker != nil {
 		return []RequestFile{{
 			Name: "png_sticker",
@@ -2097,12 +2490,20 @@ func (config NewStickerSetConfig) files() []RequestFile {
 }
 
 // AddStickerConfig allows you to add a sticker to a set.
+//
+// You must set either Sticker, or PNGSticker or TGSSticker.
 type AddStickerConfig struct {
-	UserID       int64
-	Name         string
-	PNGSticker   RequestFileData
-	TGSSticker   RequestFileData
-	Emojis       string
+	UserID  int64
+	Name    string
+	Sticker *InputSticker
+
+	// Deprecated: use Sticker instead.
+	PNGSticker RequestFileData
+	// Deprecated: use Sticker instead.
+	TGSSticker RequestFileData
+	// Deprecated: use the EmojiList of Sticker instead.
+	Emojis string
+	// Deprecated: use the MaskPosition of Sticker instead.
 	MaskPosition *MaskPosition
 }
 
@@ -2115,6 +2516,13 @@ func (config AddStickerConfig) Params() (Params, error) {
 
 	params.AddNonZero64("user_id", config.UserID)
 	params["name"] = config.Name
+
+	if config.Sticker != nil {
+		err := params.AddInterface("sticker", prepareInputStickerParam(*config.Sticker, 0))
+
+		return params, err
+	}
+
 	params["emojis"] = config.Emojis
 
 	err := params.AddInterface("mask_position", config.MaskPosition)
@@ -2123,6 +2531,10 @@ func (config AddStickerConfig) Params() (Params, error) {
 }
 
 func (config AddStickerConfig) files() []RequestFile {
+	if config.Sticker != nil {
+		return prepareInputStickerFile(*config.Sticker, 0)
+	}
+
 	if config.PNGSticker != nil {
 		return []RequestFile{{
 			Name: "png_sticker",
@@ -2137,6 +2549,35 @@ func (config AddStickerConfig) files() []RequestFile {
 
 }
 
+// ReplaceStickerConfig allows you to replace a sticker in a set, keeping its
+// position.
+type ReplaceStickerConfig struct {
+	UserID     int64
+	Name       string
+	OldSticker string
+	Sticker    InputSticker
+}
+
+func (config ReplaceStickerConfig) Method() string {
+	return "replaceStickerInSet"
+}
+
+func (config ReplaceStickerConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	params["name"] = config.Name
+	params["old_sticker"] = config.OldSticker
+
+	err := params.AddInterface("sticker", prepareInputStickerParam(config.Sticker, 0))
+
+	return params, err
+}
+
+func (config ReplaceStickerConfig) files() []RequestFile {
+	return prepareInputStickerFile(config.Sticker, 0)
+}
+
 // SetStickerPositionConfig allows you to change the position of a sticker in a set.
 type SetStickerPositionConfig struct {
 	Sticker  string
@@ -2173,7 +2614,45 @@ func (config DeleteStickerConfig) Params() (Params, error) {
 	return params, nil
 }
 
+// SetStickerSetThumbnailConfig allows you to set the thumbnail for a sticker
+// set.
+type SetStickerSetThumbnailConfig struct {
+	Name      string
+	UserID    int64
+	Thumbnail RequestFileData
+	// Format is the format of the thumbnail, one of StickerFormatStatic,
+	// StickerFormatAnimated or StickerFormatVideo.
+	Format string
+}
+
+func (config SetStickerSetThumbnailConfig) Method() string {
+	return "setStickerSetThumbnail"
+}
+
+func (config SetStickerSetThumbnailConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params["name"] = config.Name
+	params.AddNonZero64("user_id", config.UserID)
+	params["format"] = config.Format
+
+	return params, nil
+}
+
+func (config SetStickerSetThumbnailConfig) files() []RequestFile {
+	if config.Thumbnail == nil {
+		return nil
+	}
+
+	return []RequestFile{{
+		Name: "thumbnail",
+		Data: config.Thumbnail,
+	}}
+}
+
 // SetStickerSetThumbConfig allows you to set the thumbnail for a sticker set.
+//
+// Deprecated: use SetStickerSetThumbnailConfig instead.
 type SetStickerSetThumbConfig struct {
 	Name   string
 	UserID int64
@@ -2243,12 +2722,18 @@ func (config DeleteChatStickerSetConfig) Params() (Params, error) {
 //
 // Media consist of InputMedia items (InputMediaPhoto, InputMediaVideo).
 type MediaGroupConfig struct {
-	ChatID          int64
-	ChannelUsername string
+	ChatID               int64
+	ChannelUsername      string
+	BusinessConnectionID string
+	MessageThreadID      int
 
 	Media               []interface{}
 	DisableNotification bool
-	ReplyToMessageID    int
+	// ReplyParameters describes the message to reply to. It replaces
+	// ReplyToMessageID when set.
+	ReplyParameters *ReplyParameters
+	// Deprecated: use ReplyParameters instead.
+	ReplyToMessageID int
 }
 
 func (config MediaGroupConfig) Method() string {
@@ -2259,8 +2744,12 @@ func (config MediaGroupConfig) Params() (Params, error) {
 	params := make(Params)
 
 	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonEmpty("business_connection_id", config.BusinessConnectionID)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
 	params.AddBool("disable_notification", config.DisableNotification)
-	params.AddNonZero("reply_to_message_id", config.ReplyToMessageID)
+	if err := addReplyParameters(params, config.ReplyParameters, config.ReplyToMessageID, false); err != nil {
+		return params, err
+	}
 
 	err := params.AddInterface("media", prepareInputMediaForParams(config.Media))
 
@@ -2451,32 +2940,38 @@ func prepareInputMediaParam(inputMedia interface{}, idx int) interface{} {
 
 		return m
 	case InputMediaVideo:
+		m.Thumbnail = thumbnailFile(m.Thumbnail, m.Thumb)
+
 		if m.Media.NeedsUpload() {
 			m.Media = fileAttach(fmt.Sprintf("attach://file-%d", idx))
 		}
 
-		if m.Thumb != nil && m.Thumb.NeedsUpload() {
-			m.Thumb = fileAttach(fmt.Sprintf("attach://file-%d-thumb", idx))
+		if m.Thumbnail != nil && m.Thumbnail.NeedsUpload() {
+			m.Thumbnail = fileAttach(fmt.Sprintf("attach://file-%d-thumb", idx))
 		}
 
 		return m
 	case InputMediaAudio:
+		m.Thumbnail = thumbnailFile(m.Thumbnail, m.Thumb)
+
 		if m.Media.NeedsUpload() {
 			m.Media = fileAttach(fmt.Sprintf("attach://file-%d", idx))
 		}
 
-		if m.Thumb != nil && m.Thumb.NeedsUpload() {
-			m.Thumb = fileAttach(fmt.Sprintf("attach://file-%d-thumb", idx))
+		if m.Thumbnail != nil && m.Thumbnail.NeedsUpload() {
+			m.Thumbnail = fileAttach(fmt.Sprintf("attach://file-%d-thumb", idx))
 		}
 
 		return m
 	case InputMediaDocument:
+		m.Thumbnail = thumbnailFile(m.Thumbnail, m.Thumb)
+
 		if m.Media.NeedsUpload() {
 			m.Media = fileAttach(fmt.Sprintf("attach://file-%d", idx))
 		}
 
-		if m.Thumb != nil && m.Thumb.NeedsUpload() {
-			m.Thumb = fileAttach(fmt.Sprintf("attach://file-%d-thumb", idx))
+		if m.Thumbnail != nil && m.Thumbnail.NeedsUpload() {
+			m.Thumbnail = fileAttach(fmt.Sprintf("attach://file-%d-thumb", idx))
 		}
 
 		return m
@@ -2505,6 +3000,8 @@ func prepareInputMediaFile(inputMedia interface{}, idx int) []RequestFile {
 			})
 		}
 	case InputMediaVideo:
+		m.Thumbnail = thumbnailFile(m.Thumbnail, m.Thumb)
+
 		if m.Media.NeedsUpload() {
 			files = append(files, RequestFile{
 				Name: fmt.Sprintf("file-%d", idx),
@@ -2512,13 +3009,15 @@ func prepareInputMediaFile(inputMedia interface{}, idx int) []RequestFile {
 			})
 		}
 
-		if m.Thumb != nil && m.Thumb.NeedsUpload() {
+		if m.Thumbnail != nil && m.Thumbnail.NeedsUpload() {
 			files = append(files, RequestFile{
-				Name: fmt.Sprintf("file-%d", idx),
-				Data: m.Thumb,
+				Name: fmt.Sprintf("file-%d-thumb", idx),
+				Data: m.Thumbnail,
 			})
 		}
 	case InputMediaDocument:
+		m.Thumbnail = thumbnailFile(m.Thumbnail, m.Thumb)
+
 		if m.Media.NeedsUpload() {
 			files = append(files, RequestFile{
 				Name: fmt.Sprintf("file-%d", idx),
@@ -2526,13 +3025,15 @@ func prepareInputMediaFile(inputMedia interface{}, idx int) []RequestFile {
 			})
 		}
 
-		if m.Thumb != nil && m.Thumb.NeedsUpload() {
+		if m.Thumbnail != nil && m.Thumbnail.NeedsUpload() {
 			files = append(files, RequestFile{
-				Name: fmt.Sprintf("file-%d", idx),
-				Data: m.Thumb,
+				Name: fmt.Sprintf("file-%d-thumb", idx),
+				Data: m.Thumbnail,
 			})
 		}
 	case InputMediaAudio:
+		m.Thumbnail = thumbnailFile(m.Thumbnail, m.Thumb)
+
 		if m.Media.NeedsUpload() {
 			files = append(files, RequestFile{
 				Name: fmt.Sprintf("file-%d", idx),
@@ -2540,10 +3041,10 @@ func prepareInputMediaFile(inputMedia interface{}, idx int) []RequestFile {
 			})
 		}
 
-		if m.Thumb != nil && m.Thumb.NeedsUpload() {
+		if m.Thumbnail != nil && m.Thumbnail.NeedsUpload() {
 			files = append(files, RequestFile{
-				Name: fmt.Sprintf("file-%d", idx),
-				Data: m.Thumb,
+				Name: fmt.Sprintf("file-%d-thumb", idx),
+				Data: m.Thumbnail,
 			})
 		}
 	}
@@ -2551,6 +3052,54 @@ func prepareInputMediaFile(inputMedia interface{}, idx int) []RequestFile {
 	return files
 }
 
+// prepareInputStickerParam returns a copy of an InputSticker with the file
+// to upload replaced by "attach://sticker-%d", the name of the file generated
+// by prepareInputStickerFile.
+func prepareInputStickerParam(sticker InputSticker, idx int) InputSticker {
+	if sticker.Sticker != nil && sticker.Sticker.NeedsUpload() {
+		sticker.Sticker = fileAttach(fmt.Sprintf("attach://sticker-%d", idx))
+	}
+
+	return sticker
+}
+
+// prepareInputStickerFile returns the file of an InputSticker needing to be
+// uploaded, named "sticker-%d".
+func prepareInputStickerFile(sticker InputSticker, idx int) []RequestFile {
+	if sticker.Sticker == nil || !sticker.Sticker.NeedsUpload() {
+		return []RequestFile{}
+	}
+
+	return []RequestFile{{
+		Name: fmt.Sprintf("sticker-%d", idx),
+		Data: sticker.Sticker,
+	}}
+}
+
+// prepareInputStickersForParams calls prepareInputStickerParam for each
+// sticker of a set.
+func prepareInputStickersForParams(stickers []InputSticker) []InputSticker {
+	newStickers := make([]InputSticker, len(stickers))
+
+	for idx, sticker := range stickers {
+		newStickers[idx] = prepareInputStickerParam(sticker, idx)
+	}
+
+	return newStickers
+}
+
+// prepareInputStickersForFiles calls prepareInputStickerFile for each sticker
+// of a set.
+func prepareInputStickersForFiles(stickers []InputSticker) []RequestFile {
+	files := []RequestFile{}
+
+	for idx, sticker := range stickers {
+		files = append(files, prepareInputStickerFile(sticker, idx)...)
+	}
+
+	return files
+}
+
 // prepareInputMediaForParams calls prepareInputMediaParam for each item
 // provided and returns a new array with the correct params for a request.
 //
@@ -0,0 +1,1232 @@
+// Code generated by tgbotapi-gen from schema/api.json; DO NOT EDIT.
+
+package tgbotapi
+
+// CloseForumTopicConfig contains information about a closeForumTopic request.
+//
+// Use this method to close an open topic in a forum supergroup chat. The bot
+// must be an administrator in the chat for this to work and must have the
+// can_manage_topics administrator rights, unless it is the creator of the
+// topic. Returns True on success.
+type CloseForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	MessageThreadID int // required
+}
+
+// Method returns closeForumTopic.
+func (config CloseForumTopicConfig) Method() string {
+	return "closeForumTopic"
+}
+
+// Params returns the params of the request.
+func (config CloseForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
+
+	return params, nil
+}
+
+// CloseGeneralForumTopicConfig contains information about a closeGeneralForumTopic request.
+//
+// Use this method to close an open 'General' topic in a forum supergroup
+// chat. The bot must be an administrator in the chat for this to work and
+// must have the can_manage_topics administrator rights. Returns True on
+// success.
+type CloseGeneralForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+}
+
+// Method returns closeGeneralForumTopic.
+func (config CloseGeneralForumTopicConfig) Method() string {
+	return "closeGeneralForumTopic"
+}
+
+// Params returns the params of the request.
+func (config CloseGeneralForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+
+	return params, nil
+}
+
+// CopyMessagesConfig contains information about a copyMessages request.
+//
+// Use this method to copy messages of any kind. If some of the specified
+// messages can't be found or copied, they are skipped. Service messages,
+// paid media messages, giveaway messages, giveaway winners messages, and
+// invoice messages can't be copied. A quiz poll can be copied only if the
+// value of the field correct_option_id is known to the bot. The method is
+// analogous to the method forwardMessages, but the copied messages don't
+// have a link to the original message. Album grouping is kept for copied
+// messages. On success, an array of MessageID of the sent messages is
+// returned.
+type CopyMessagesConfig struct {
+	ChatID              int64 // required
+	ChannelUsername     string
+	MessageThreadID     int
+	FromChatID          int64 // required
+	FromChannelUsername string
+	MessageIDs          []int // required
+	DisableNotification bool
+	ProtectContent      bool
+	RemoveCaption       bool
+}
+
+// Method returns copyMessages.
+func (config CopyMessagesConfig) Method() string {
+	return "copyMessages"
+}
+
+// Params returns the params of the request.
+func (config CopyMessagesConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
+	params.AddFirstValid("from_chat_id", config.FromChatID, config.FromChannelUsername)
+	if len(config.MessageIDs) > 0 {
+		if err := params.AddInterface("message_ids", config.MessageIDs); err != nil {
+			return params, err
+		}
+	}
+	params.AddBool("disable_notification", config.DisableNotification)
+	params.AddBool("protect_content", config.ProtectContent)
+	params.AddBool("remove_caption", config.RemoveCaption)
+
+	return params, nil
+}
+
+// CreateChatSubscriptionInviteLinkConfig contains information about a createChatSubscriptionInviteLink request.
+//
+// Use this method to create a subscription invite link for a channel chat.
+// The bot must have the can_invite_users administrator rights. The link can
+// be edited using the method editChatSubscriptionInviteLink or revoked using
+// the method revokeChatInviteLink. Returns the new invite link as a
+// ChatInviteLink object.
+type CreateChatSubscriptionInviteLinkConfig struct {
+	ChatID             int64 // required
+	ChannelUsername    string
+	Name               string
+	SubscriptionPeriod int // required
+	SubscriptionPrice  int // required
+}
+
+// Method returns createChatSubscriptionInviteLink.
+func (config CreateChatSubscriptionInviteLinkConfig) Method() string {
+	return "createChatSubscriptionInviteLink"
+}
+
+// Params returns the params of the request.
+func (config CreateChatSubscriptionInviteLinkConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonEmpty("name", config.Name)
+	params.AddNonZero("subscription_period", config.SubscriptionPeriod)
+	params.AddNonZero("subscription_price", config.SubscriptionPrice)
+
+	return params, nil
+}
+
+// CreateForumTopicConfig contains information about a createForumTopic request.
+//
+// Use this method to create a topic in a forum supergroup chat. The bot must
+// be an administrator in the chat for this to work and must have the
+// can_manage_topics administrator rights. Returns information about the
+// created topic as a ForumTopic object.
+type CreateForumTopicConfig struct {
+	ChatID            int64 // required
+	ChannelUsername   string
+	Name              string // required
+	IconColor         int
+	IconCustomEmojiID string
+}
+
+// Method returns createForumTopic.
+func (config CreateForumTopicConfig) Method() string {
+	return "createForumTopic"
+}
+
+// Params returns the params of the request.
+func (config CreateForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonEmpty("name", config.Name)
+	params.AddNonZero("icon_color", config.IconColor)
+	params.AddNonEmpty("icon_custom_emoji_id", config.IconCustomEmojiID)
+
+	return params, nil
+}
+
+// DeleteForumTopicConfig contains information about a deleteForumTopic request.
+//
+// Use this method to delete a forum topic along with all its messages in a
+// forum supergroup chat. The bot must be an administrator in the chat for
+// this to work and must have the can_delete_messages administrator rights.
+// Returns True on success.
+type DeleteForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	MessageThreadID int // required
+}
+
+// Method returns deleteForumTopic.
+func (config DeleteForumTopicConfig) Method() string {
+	return "deleteForumTopic"
+}
+
+// Params returns the params of the request.
+func (config DeleteForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
+
+	return params, nil
+}
+
+// DeleteStickerSetConfig contains information about a deleteStickerSet request.
+//
+// Use this method to delete a sticker set that was created by the bot.
+// Returns True on success.
+type DeleteStickerSetConfig struct {
+	Name string // required
+}
+
+// Method returns deleteStickerSet.
+func (config DeleteStickerSetConfig) Method() string {
+	return "deleteStickerSet"
+}
+
+// Params returns the params of the request.
+func (config DeleteStickerSetConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("name", config.Name)
+
+	return params, nil
+}
+
+// EditChatSubscriptionInviteLinkConfig contains information about a editChatSubscriptionInviteLink request.
+//
+// Use this method to edit a subscription invite link created by the bot. The
+// bot must have the can_invite_users administrator rights. Returns the
+// edited invite link as a ChatInviteLink object.
+type EditChatSubscriptionInviteLinkConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	InviteLink      string // required
+	Name            string
+}
+
+// Method returns editChatSubscriptionInviteLink.
+func (config EditChatSubscriptionInviteLinkConfig) Method() string {
+	return "editChatSubscriptionInviteLink"
+}
+
+// Params returns the params of the request.
+func (config EditChatSubscriptionInviteLinkConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonEmpty("invite_link", config.InviteLink)
+	params.AddNonEmpty("name", config.Name)
+
+	return params, nil
+}
+
+// EditGeneralForumTopicConfig contains information about a editGeneralForumTopic request.
+//
+// Use this method to edit the name of the 'General' topic in a forum
+// supergroup chat. The bot must be an administrator in the chat for this to
+// work and must have the can_manage_topics administrator rights. Returns
+// True on success.
+type EditGeneralForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	Name            string // required
+}
+
+// Method returns editGeneralForumTopic.
+func (config EditGeneralForumTopicConfig) Method() string {
+	return "editGeneralForumTopic"
+}
+
+// Params returns the params of the request.
+func (config EditGeneralForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonEmpty("name", config.Name)
+
+	return params, nil
+}
+
+// EditUserStarSubscriptionConfig contains information about a editUserStarSubscription request.
+//
+// Allows the bot to cancel or re-enable extension of a subscription paid in
+// Telegram Stars. Returns True on success.
+type EditUserStarSubscriptionConfig struct {
+	UserID                  int64  // required
+	TelegramPaymentChargeID string // required
+	IsCanceled              bool   // required
+}
+
+// Method returns editUserStarSubscription.
+func (config EditUserStarSubscriptionConfig) Method() string {
+	return "editUserStarSubscription"
+}
+
+// Params returns the params of the request.
+func (config EditUserStarSubscriptionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	params.AddNonEmpty("telegram_payment_charge_id", config.TelegramPaymentChargeID)
+	params.AddBool("is_canceled", config.IsCanceled)
+
+	return params, nil
+}
+
+// ForwardMessagesConfig contains information about a forwardMessages request.
+//
+// Use this method to forward multiple messages of any kind. If some of the
+// specified messages can't be found or forwarded, they are skipped. Service
+// messages and messages with protected content can't be forwarded. Album
+// grouping is kept for forwarded messages. On success, an array of MessageID
+// of the sent messages is returned.
+type ForwardMessagesConfig struct {
+	ChatID              int64 // required
+	ChannelUsername     string
+	MessageThreadID     int
+	FromChatID          int64 // required
+	FromChannelUsername string
+	MessageIDs          []int // required
+	DisableNotification bool
+	ProtectContent      bool
+}
+
+// Method returns forwardMessages.
+func (config ForwardMessagesConfig) Method() string {
+	return "forwardMessages"
+}
+
+// Params returns the params of the request.
+func (config ForwardMessagesConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
+	params.AddFirstValid("from_chat_id", config.FromChatID, config.FromChannelUsername)
+	if len(config.MessageIDs) > 0 {
+		if err := params.AddInterface("message_ids", config.MessageIDs); err != nil {
+			return params, err
+		}
+	}
+	params.AddBool("disable_notification", config.DisableNotification)
+	params.AddBool("protect_content", config.ProtectContent)
+
+	return params, nil
+}
+
+// GetAvailableGiftsConfig contains information about a getAvailableGifts request.
+//
+// Returns the list of gifts that can be sent by the bot to users and channel
+// chats. Requires no parameters. Returns a Gifts object.
+type GetAvailableGiftsConfig struct{}
+
+// Method returns getAvailableGifts.
+func (config GetAvailableGiftsConfig) Method() string {
+	return "getAvailableGifts"
+}
+
+// Params returns the params of the request.
+func (config GetAvailableGiftsConfig) Params() (Params, error) {
+	params := make(Params)
+
+	return params, nil
+}
+
+// GetBusinessConnectionConfig contains information about a getBusinessConnection request.
+//
+// Use this method to get information about the connection of the bot with a
+// business account. Returns a BusinessConnection object on success.
+type GetBusinessConnectionConfig struct {
+	BusinessConnectionID string // required
+}
+
+// Method returns getBusinessConnection.
+func (config GetBusinessConnectionConfig) Method() string {
+	return "getBusinessConnection"
+}
+
+// Params returns the params of the request.
+func (config GetBusinessConnectionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("business_connection_id", config.BusinessConnectionID)
+
+	return params, nil
+}
+
+// GetChatMemberCountConfig contains information about a getChatMemberCount request.
+//
+// Use this method to get the number of members in a chat. Returns Int on
+// success.
+type GetChatMemberCountConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+}
+
+// Method returns getChatMemberCount.
+func (config GetChatMemberCountConfig) Method() string {
+	return "getChatMemberCount"
+}
+
+// Params returns the params of the request.
+func (config GetChatMemberCountConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+
+	return params, nil
+}
+
+// GetCustomEmojiStickersConfig contains information about a getCustomEmojiStickers request.
+//
+// Use this method to get information about custom emoji stickers by their
+// identifiers. Returns an Array of Sticker objects.
+type GetCustomEmojiStickersConfig struct {
+	CustomEmojiIDs []string // required
+}
+
+// Method returns getCustomEmojiStickers.
+func (config GetCustomEmojiStickersConfig) Method() string {
+	return "getCustomEmojiStickers"
+}
+
+// Params returns the params of the request.
+func (config GetCustomEmojiStickersConfig) Params() (Params, error) {
+	params := make(Params)
+
+	if len(config.CustomEmojiIDs) > 0 {
+		if err := params.AddInterface("custom_emoji_ids", config.CustomEmojiIDs); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+// GetForumTopicIconStickersConfig contains information about a getForumTopicIconStickers request.
+//
+// Use this method to get custom emoji stickers, which can be used as a forum
+// topic icon by any user. Requires no parameters. Returns an Array of
+// Sticker objects.
+type GetForumTopicIconStickersConfig struct{}
+
+// Method returns getForumTopicIconStickers.
+func (config GetForumTopicIconStickersConfig) Method() string {
+	return "getForumTopicIconStickers"
+}
+
+// Params returns the params of the request.
+func (config GetForumTopicIconStickersConfig) Params() (Params, error) {
+	params := make(Params)
+
+	return params, nil
+}
+
+// GetMyDescriptionConfig contains information about a getMyDescription request.
+//
+// Use this method to get the current bot description for the given user
+// language. Returns BotDescription on success.
+type GetMyDescriptionConfig struct {
+	LanguageCode string
+}
+
+// Method returns getMyDescription.
+func (config GetMyDescriptionConfig) Method() string {
+	return "getMyDescription"
+}
+
+// Params returns the params of the request.
+func (config GetMyDescriptionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("language_code", config.LanguageCode)
+
+	return params, nil
+}
+
+// GetMyNameConfig contains information about a getMyName request.
+//
+// Use this method to get the current bot name for the given user language.
+// Returns BotName on success.
+type GetMyNameConfig struct {
+	LanguageCode string
+}
+
+// Method returns getMyName.
+func (config GetMyNameConfig) Method() string {
+	return "getMyName"
+}
+
+// Params returns the params of the request.
+func (config GetMyNameConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("language_code", config.LanguageCode)
+
+	return params, nil
+}
+
+// GetMyShortDescriptionConfig contains information about a getMyShortDescription request.
+//
+// Use this method to get the current bot short description for the given
+// user language. Returns BotShortDescription on success.
+type GetMyShortDescriptionConfig struct {
+	LanguageCode string
+}
+
+// Method returns getMyShortDescription.
+func (config GetMyShortDescriptionConfig) Method() string {
+	return "getMyShortDescription"
+}
+
+// Params returns the params of the request.
+func (config GetMyShortDescriptionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("language_code", config.LanguageCode)
+
+	return params, nil
+}
+
+// GetStarTransactionsConfig contains information about a getStarTransactions request.
+//
+// Returns the bot's Telegram Star transactions in chronological order. On
+// success, returns a StarTransactions object.
+type GetStarTransactionsConfig struct {
+	Offset int
+	Limit  int
+}
+
+// Method returns getStarTransactions.
+func (config GetStarTransactionsConfig) Method() string {
+	return "getStarTransactions"
+}
+
+// Params returns the params of the request.
+func (config GetStarTransactionsConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero("offset", config.Offset)
+	params.AddNonZero("limit", config.Limit)
+
+	return params, nil
+}
+
+// GetUserChatBoostsConfig contains information about a getUserChatBoosts request.
+//
+// Use this method to get the list of boosts added to a chat by a user.
+// Requires administrator rights in the chat. Returns a UserChatBoosts
+// object.
+type GetUserChatBoostsConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	UserID          int64 // required
+}
+
+// Method returns getUserChatBoosts.
+func (config GetUserChatBoostsConfig) Method() string {
+	return "getUserChatBoosts"
+}
+
+// Params returns the params of the request.
+func (config GetUserChatBoostsConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero64("user_id", config.UserID)
+
+	return params, nil
+}
+
+// HideGeneralForumTopicConfig contains information about a hideGeneralForumTopic request.
+//
+// Use this method to hide the 'General' topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must
+// have the can_manage_topics administrator rights. The topic will be
+// automatically closed if it was open. Returns True on success.
+type HideGeneralForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+}
+
+// Method returns hideGeneralForumTopic.
+func (config HideGeneralForumTopicConfig) Method() string {
+	return "hideGeneralForumTopic"
+}
+
+// Params returns the params of the request.
+func (config HideGeneralForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+
+	return params, nil
+}
+
+// RefundStarPaymentConfig contains information about a refundStarPayment request.
+//
+// Refunds a successful payment in Telegram Stars. Returns True on success.
+type RefundStarPaymentConfig struct {
+	UserID                  int64  // required
+	TelegramPaymentChargeID string // required
+}
+
+// Method returns refundStarPayment.
+func (config RefundStarPaymentConfig) Method() string {
+	return "refundStarPayment"
+}
+
+// Params returns the params of the request.
+func (config RefundStarPaymentConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	params.AddNonEmpty("telegram_payment_charge_id", config.TelegramPaymentChargeID)
+
+	return params, nil
+}
+
+// RemoveChatVerificationConfig contains information about a removeChatVerification request.
+//
+// Removes verification from a chat that is currently verified on behalf of
+// the organization represented by the bot. Returns True on success.
+type RemoveChatVerificationConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+}
+
+// Method returns removeChatVerification.
+func (config RemoveChatVerificationConfig) Method() string {
+	return "removeChatVerification"
+}
+
+// Params returns the params of the request.
+func (config RemoveChatVerificationConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+
+	return params, nil
+}
+
+// RemoveUserVerificationConfig contains information about a removeUserVerification request.
+//
+// Removes verification from a user who is currently verified on behalf of
+// the organization represented by the bot. Returns True on success.
+type RemoveUserVerificationConfig struct {
+	UserID int64 // required
+}
+
+// Method returns removeUserVerification.
+func (config RemoveUserVerificationConfig) Method() string {
+	return "removeUserVerification"
+}
+
+// Params returns the params of the request.
+func (config RemoveUserVerificationConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+
+	return params, nil
+}
+
+// ReopenForumTopicConfig contains information about a reopenForumTopic request.
+//
+// Use this method to reopen a closed topic in a forum supergroup chat. The
+// bot must be an administrator in the chat for this to work and must have
+// the can_manage_topics administrator rights, unless it is the creator of
+// the topic. Returns True on success.
+type ReopenForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	MessageThreadID int // required
+}
+
+// Method returns reopenForumTopic.
+func (config ReopenForumTopicConfig) Method() string {
+	return "reopenForumTopic"
+}
+
+// Params returns the params of the request.
+func (config ReopenForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
+
+	return params, nil
+}
+
+// ReopenGeneralForumTopicConfig contains information about a reopenGeneralForumTopic request.
+//
+// Use this method to reopen a closed 'General' topic in a forum supergroup
+// chat. The bot must be an administrator in the chat for this to work and
+// must have the can_manage_topics administrator rights. The topic will be
+// automatically unhidden if it was hidden. Returns True on success.
+type ReopenGeneralForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+}
+
+// Method returns reopenGeneralForumTopic.
+func (config ReopenGeneralForumTopicConfig) Method() string {
+	return "reopenGeneralForumTopic"
+}
+
+// Params returns the params of the request.
+func (config ReopenGeneralForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+
+	return params, nil
+}
+
+// SavePreparedInlineMessageConfig contains information about a savePreparedInlineMessage request.
+//
+// Stores a message that can be sent by a user of a Mini App. Returns a
+// PreparedInlineMessage object.
+type SavePreparedInlineMessageConfig struct {
+	UserID            int64       // required
+	Result            interface{} // required
+	AllowUserChats    bool
+	AllowBotChats     bool
+	AllowGroupChats   bool
+	AllowChannelChats bool
+}
+
+// Method returns savePreparedInlineMessage.
+func (config SavePreparedInlineMessageConfig) Method() string {
+	return "savePreparedInlineMessage"
+}
+
+// Params returns the params of the request.
+func (config SavePreparedInlineMessageConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	if err := params.AddInterface("result", config.Result); err != nil {
+		return params, err
+	}
+	params.AddBool("allow_user_chats", config.AllowUserChats)
+	params.AddBool("allow_bot_chats", config.AllowBotChats)
+	params.AddBool("allow_group_chats", config.AllowGroupChats)
+	params.AddBool("allow_channel_chats", config.AllowChannelChats)
+
+	return params, nil
+}
+
+// SendGiftConfig contains information about a sendGift request.
+//
+// Sends a gift to the given user or channel chat. The gift can't be
+// converted to Telegram Stars by the receiver. Returns True on success.
+type SendGiftConfig struct {
+	UserID          int64
+	ChatID          int64
+	ChannelUsername string
+	GiftID          string // required
+	PayForUpgrade   bool
+	Text            string
+	TextParseMode   string
+	TextEntities    []MessageEntity
+}
+
+// Method returns sendGift.
+func (config SendGiftConfig) Method() string {
+	return "sendGift"
+}
+
+// Params returns the params of the request.
+func (config SendGiftConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonEmpty("gift_id", config.GiftID)
+	params.AddBool("pay_for_upgrade", config.PayForUpgrade)
+	params.AddNonEmpty("text", config.Text)
+	params.AddNonEmpty("text_parse_mode", config.TextParseMode)
+	if len(config.TextEntities) > 0 {
+		if err := params.AddInterface("text_entities", config.TextEntities); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+// SendPaidMediaConfig contains information about a sendPaidMedia request.
+//
+// Use this method to send paid media. On success, the sent Message is
+// returned.
+type SendPaidMediaConfig struct {
+	BusinessConnectionID  string
+	ChatID                int64 // required
+	ChannelUsername       string
+	StarCount             int              // required
+	Media                 []InputPaidMedia // required
+	Payload               string
+	Caption               string
+	ParseMode             string
+	CaptionEntities       []MessageEntity
+	ShowCaptionAboveMedia bool
+	DisableNotification   bool
+	ProtectContent        bool
+	AllowPaidBroadcast    bool
+	ReplyParameters       ReplyParameters
+	ReplyMarkup           interface{}
+}
+
+// Method returns sendPaidMedia.
+func (config SendPaidMediaConfig) Method() string {
+	return "sendPaidMedia"
+}
+
+// Params returns the params of the request.
+func (config SendPaidMediaConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("business_connection_id", config.BusinessConnectionID)
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("star_count", config.StarCount)
+	if len(config.Media) > 0 {
+		if err := params.AddInterface("media", config.Media); err != nil {
+			return params, err
+		}
+	}
+	params.AddNonEmpty("payload", config.Payload)
+	params.AddNonEmpty("caption", config.Caption)
+	params.AddNonEmpty("parse_mode", config.ParseMode)
+	if len(config.CaptionEntities) > 0 {
+		if err := params.AddInterface("caption_entities", config.CaptionEntities); err != nil {
+			return params, err
+		}
+	}
+	params.AddBool("show_caption_above_media", config.ShowCaptionAboveMedia)
+	params.AddBool("disable_notification", config.DisableNotification)
+	params.AddBool("protect_content", config.ProtectContent)
+	params.AddBool("allow_paid_broadcast", config.AllowPaidBroadcast)
+	if err := params.AddInterface("reply_parameters", config.ReplyParameters); err != nil {
+		return params, err
+	}
+	if err := params.AddInterface("reply_markup", config.ReplyMarkup); err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
+// SetCustomEmojiStickerSetThumbnailConfig contains information about a setCustomEmojiStickerSetThumbnail request.
+//
+// Use this method to set the thumbnail of a custom emoji sticker set.
+// Returns True on success.
+type SetCustomEmojiStickerSetThumbnailConfig struct {
+	Name          string // required
+	CustomEmojiID string
+}
+
+// Method returns setCustomEmojiStickerSetThumbnail.
+func (config SetCustomEmojiStickerSetThumbnailConfig) Method() string {
+	return "setCustomEmojiStickerSetThumbnail"
+}
+
+// Params returns the params of the request.
+func (config SetCustomEmojiStickerSetThumbnailConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("name", config.Name)
+	params.AddNonEmpty("custom_emoji_id", config.CustomEmojiID)
+
+	return params, nil
+}
+
+// SetMessageReactionConfig contains information about a setMessageReaction request.
+//
+// Use this method to change the chosen reactions on a message. Service
+// messages of some types can't be reacted to. Automatically forwarded
+// messages from a channel to its discussion group have the same available
+// reactions as messages in the channel. Bots can't use paid reactions.
+// Returns True on success.
+type SetMessageReactionConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	MessageID       int // required
+	Reaction        []ReactionType
+	IsBig           bool
+}
+
+// Method returns setMessageReaction.
+func (config SetMessageReactionConfig) Method() string {
+	return "setMessageReaction"
+}
+
+// Params returns the params of the request.
+func (config SetMessageReactionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_id", config.MessageID)
+	if len(config.Reaction) > 0 {
+		if err := params.AddInterface("reaction", config.Reaction); err != nil {
+			return params, err
+		}
+	}
+	params.AddBool("is_big", config.IsBig)
+
+	return params, nil
+}
+
+// SetMyDescriptionConfig contains information about a setMyDescription request.
+//
+// Use this method to change the bot's description, which is shown in the
+// chat with the bot if the chat is empty. Returns True on success.
+type SetMyDescriptionConfig struct {
+	Description  string
+	LanguageCode string
+}
+
+// Method returns setMyDescription.
+func (config SetMyDescriptionConfig) Method() string {
+	return "setMyDescription"
+}
+
+// Params returns the params of the request.
+func (config SetMyDescriptionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("description", config.Description)
+	params.AddNonEmpty("language_code", config.LanguageCode)
+
+	return params, nil
+}
+
+// SetMyNameConfig contains information about a setMyName request.
+//
+// Use this method to change the bot's name. Returns True on success.
+type SetMyNameConfig struct {
+	Name         string
+	LanguageCode string
+}
+
+// Method returns setMyName.
+func (config SetMyNameConfig) Method() string {
+	return "setMyName"
+}
+
+// Params returns the params of the request.
+func (config SetMyNameConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("name", config.Name)
+	params.AddNonEmpty("language_code", config.LanguageCode)
+
+	return params, nil
+}
+
+// SetMyShortDescriptionConfig contains information about a setMyShortDescription request.
+//
+// Use this method to change the bot's short description, which is shown on
+// the bot's profile page and is sent together with the link when users share
+// the bot. Returns True on success.
+type SetMyShortDescriptionConfig struct {
+	ShortDescription string
+	LanguageCode     string
+}
+
+// Method returns setMyShortDescription.
+func (config SetMyShortDescriptionConfig) Method() string {
+	return "setMyShortDescription"
+}
+
+// Params returns the params of the request.
+func (config SetMyShortDescriptionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("short_description", config.ShortDescription)
+	params.AddNonEmpty("language_code", config.LanguageCode)
+
+	return params, nil
+}
+
+// SetStickerEmojiListConfig contains information about a setStickerEmojiList request.
+//
+// Use this method to change the list of emoji assigned to a regular or
+// custom emoji sticker. The sticker must belong to a sticker set created by
+// the bot. Returns True on success.
+type SetStickerEmojiListConfig struct {
+	Sticker   string   // required
+	EmojiList []string // required
+}
+
+// Method returns setStickerEmojiList.
+func (config SetStickerEmojiListConfig) Method() string {
+	return "setStickerEmojiList"
+}
+
+// Params returns the params of the request.
+func (config SetStickerEmojiListConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("sticker", config.Sticker)
+	if len(config.EmojiList) > 0 {
+		if err := params.AddInterface("emoji_list", config.EmojiList); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+// SetStickerKeywordsConfig contains information about a setStickerKeywords request.
+//
+// Use this method to change search keywords assigned to a regular or custom
+// emoji sticker. The sticker must belong to a sticker set created by the
+// bot. Returns True on success.
+type SetStickerKeywordsConfig struct {
+	Sticker  string // required
+	Keywords []string
+}
+
+// Method returns setStickerKeywords.
+func (config SetStickerKeywordsConfig) Method() string {
+	return "setStickerKeywords"
+}
+
+// Params returns the params of the request.
+func (config SetStickerKeywordsConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("sticker", config.Sticker)
+	if len(config.Keywords) > 0 {
+		if err := params.AddInterface("keywords", config.Keywords); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+// SetStickerMaskPositionConfig contains information about a setStickerMaskPosition request.
+//
+// Use this method to change the mask position of a mask sticker. The sticker
+// must belong to a sticker set that was created by the bot. Returns True on
+// success.
+type SetStickerMaskPositionConfig struct {
+	Sticker      string // required
+	MaskPosition MaskPosition
+}
+
+// Method returns setStickerMaskPosition.
+func (config SetStickerMaskPositionConfig) Method() string {
+	return "setStickerMaskPosition"
+}
+
+// Params returns the params of the request.
+func (config SetStickerMaskPositionConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("sticker", config.Sticker)
+	if err := params.AddInterface("mask_position", config.MaskPosition); err != nil {
+		return params, err
+	}
+
+	return params, nil
+}
+
+// SetStickerSetTitleConfig contains information about a setStickerSetTitle request.
+//
+// Use this method to set the title of a created sticker set. Returns True on
+// success.
+type SetStickerSetTitleConfig struct {
+	Name  string // required
+	Title string // required
+}
+
+// Method returns setStickerSetTitle.
+func (config SetStickerSetTitleConfig) Method() string {
+	return "setStickerSetTitle"
+}
+
+// Params returns the params of the request.
+func (config SetStickerSetTitleConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonEmpty("name", config.Name)
+	params.AddNonEmpty("title", config.Title)
+
+	return params, nil
+}
+
+// SetUserEmojiStatusConfig contains information about a setUserEmojiStatus request.
+//
+// Changes the emoji status for a given user that previously allowed the bot
+// to manage their emoji status via the Mini App method
+// requestEmojiStatusAccess. Returns True on success.
+type SetUserEmojiStatusConfig struct {
+	UserID                    int64 // required
+	EmojiStatusCustomEmojiID  string
+	EmojiStatusExpirationDate int
+}
+
+// Method returns setUserEmojiStatus.
+func (config SetUserEmojiStatusConfig) Method() string {
+	return "setUserEmojiStatus"
+}
+
+// Params returns the params of the request.
+func (config SetUserEmojiStatusConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	params.AddNonEmpty("emoji_status_custom_emoji_id", config.EmojiStatusCustomEmojiID)
+	params.AddNonZero("emoji_status_expiration_date", config.EmojiStatusExpirationDate)
+
+	return params, nil
+}
+
+// UnhideGeneralForumTopicConfig contains information about a unhideGeneralForumTopic request.
+//
+// Use this method to unhide the 'General' topic in a forum supergroup chat.
+// The bot must be an administrator in the chat for this to work and must
+// have the can_manage_topics administrator rights. Returns True on success.
+type UnhideGeneralForumTopicConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+}
+
+// Method returns unhideGeneralForumTopic.
+func (config UnhideGeneralForumTopicConfig) Method() string {
+	return "unhideGeneralForumTopic"
+}
+
+// Params returns the params of the request.
+func (config UnhideGeneralForumTopicConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+
+	return params, nil
+}
+
+// UnpinAllForumTopicMessagesConfig contains information about a unpinAllForumTopicMessages request.
+//
+// Use this method to clear the list of pinned messages in a forum topic. The
+// bot must be an administrator in the chat for this to work and must have
+// the can_pin_messages administrator right in the supergroup. Returns True
+// on success.
+type UnpinAllForumTopicMessagesConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+	MessageThreadID int // required
+}
+
+// Method returns unpinAllForumTopicMessages.
+func (config UnpinAllForumTopicMessagesConfig) Method() string {
+	return "unpinAllForumTopicMessages"
+}
+
+// Params returns the params of the request.
+func (config UnpinAllForumTopicMessagesConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonZero("message_thread_id", config.MessageThreadID)
+
+	return params, nil
+}
+
+// UnpinAllGeneralForumTopicMessagesConfig contains information about a unpinAllGeneralForumTopicMessages request.
+//
+// Use this method to clear the list of pinned messages in a General forum
+// topic. The bot must be an administrator in the chat for this to work and
+// must have the can_pin_messages administrator right in the supergroup.
+// Returns True on success.
+type UnpinAllGeneralForumTopicMessagesConfig struct {
+	ChatID          int64 // required
+	ChannelUsername string
+}
+
+// Method returns unpinAllGeneralForumTopicMessages.
+func (config UnpinAllGeneralForumTopicMessagesConfig) Method() string {
+	return "unpinAllGeneralForumTopicMessages"
+}
+
+// Params returns the params of the request.
+func (config UnpinAllGeneralForumTopicMessagesConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+
+	return params, nil
+}
+
+// VerifyChatConfig contains information about a verifyChat request.
+//
+// Verifies a chat on behalf of the organization which is represented by the
+// bot. Returns True on success.
+type VerifyChatConfig struct {
+	ChatID            int64 // required
+	ChannelUsername   string
+	CustomDescription string
+}
+
+// Method returns verifyChat.
+func (config VerifyChatConfig) Method() string {
+	return "verifyChat"
+}
+
+// Params returns the params of the request.
+func (config VerifyChatConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddFirstValid("chat_id", config.ChatID, config.ChannelUsername)
+	params.AddNonEmpty("custom_description", config.CustomDescription)
+
+	return params, nil
+}
+
+// VerifyUserConfig contains information about a verifyUser request.
+//
+// Verifies a user on behalf of the organization which is represented by the
+// bot. Returns True on success.
+type VerifyUserConfig struct {
+	UserID            int64 // required
+	CustomDescription string
+}
+
+// Method returns verifyUser.
+func (config VerifyUserConfig) Method() string {
+	return "verifyUser"
+}
+
+// Params returns the params of the request.
+func (config VerifyUserConfig) Params() (Params, error) {
+	params := make(Params)
+
+	params.AddNonZero64("user_id", config.UserID)
+	params.AddNonEmpty("custom_description", config.CustomDescription)
+
+	return params, nil
+}
@@ -0,0 +1,124 @@
+package tgbotapi
+
+// UpdateHandlers calls the handler for the kind of an update. Kinds without
+// a handler are passed to Default, if it is set.
+//
+// A zero UpdateHandlers handles nothing, so only the kinds a bot needs have
+// to be set:
+//
+//	handlers := tgbotapi.UpdateHandlers{
+//		Message: func(bot tgbotapi.Sender, message *tgbotapi.Message) error {
+//			_, err := bot.Send(tgbotapi.NewMessageReply(message, message.Text))
+//			return err
+//		},
+//	}
+//
+//	for update := range updates {
+//		if _, err := handlers.Handle(bot, &update); err != nil {
+//			log.Println(err)
+//		}
+//	}
+type UpdateHandlers struct {
+	// Message handles new incoming messages.
+	//
+	// optional
+	Message func(bot Sender, message *Message) error
+	// EditedMessage handles new versions of known messages.
+	//
+	// optional
+	EditedMessage func(bot Sender, message *Message) error
+	// ChannelPost handles new incoming channel posts.
+	//
+	// optional
+	ChannelPost func(bot Sender, message *Message) error
+	// EditedChannelPost handles new versions of known channel posts.
+	//
+	// optional
+	EditedChannelPost func(bot Sender, message *Message) error
+	// InlineQuery handles new incoming inline queries.
+	//
+	// optional
+	InlineQuery func(bot Sender, query *InlineQuery) error
+	// CallbackQuery handles new incoming callback queries.
+	//
+	// optional
+	CallbackQuery func(bot Sender, query *CallbackQuery) error
+	// MessageReaction handles reactions to a message changed by a user. They
+	// must be requested with UpdateTypeMessageReaction in AllowedUpdates.
+	//
+	// optional
+	MessageReaction func(bot Sender, reaction *MessageReactionUpdated) error
+	// MessageReactionCount handles changed anonymous reactions to a message.
+	// They must be requested with UpdateTypeMessageReactionCount in
+	// AllowedUpdates.
+	//
+	// optional
+	MessageReactionCount func(bot Sender, count *MessageReactionCountUpdated) error
+	// BusinessConnection handles the bot being connected to or disconnected
+	// from a business account, or the connection being edited.
+	//
+	// optional
+	BusinessConnection func(bot Sender, connection *BusinessConnection) error
+	// BusinessMessage handles new messages from a connected business
+	// account. Replies made with ReplyTo are sent on behalf of the account.
+	//
+	// optional
+	BusinessMessage func(bot Sender, message *Message) error
+	// EditedBusinessMessage handles new versions of messages from a
+	// connected business account.
+	//
+	// optional
+	EditedBusinessMessage func(bot Sender, message *Message) error
+	// DeletedBusinessMessages handles messages deleted from a connected
+	// business account.
+	//
+	// optional
+	DeletedBusinessMessages func(bot Sender, deleted *BusinessMessagesDeleted) error
+	// Default handles updates of every other kind.
+	//
+	// optional
+	Default func(bot Sender, update *Update) error
+}
+
+// Handle calls the handler for the kind of an update and returns its error.
+//
+// It returns false if there is no handler for the update.
+func (h *UpdateHandlers) Handle(bot Sender, update *Update) (bool, error) {
+	switch {
+	case update.Message != nil && h.Message != nil:
+		return true, h.Message(bot, update.Message)
+	case update.EditedMessage != nil && h.EditedMessage != nil:
+		return true, h.EditedMessage(bot, update.EditedMessage)
+	case update.ChannelPost != nil && h.ChannelPost != nil:
+		return true, h.ChannelPost(bot, update.ChannelPost)
+	case update.EditedChannelPost != nil && h.EditedChannelPost != nil:
+		return true, h.EditedChannelPost(bot, update.EditedChannelPost)
+	case update.InlineQuery != nil && h.InlineQuery != nil:
+		return true, h.InlineQuery(bot, update.InlineQuery)
+	case update.CallbackQuery != nil && h.CallbackQuery != nil:
+		return true, h.CallbackQuery(bot, update.CallbackQuery)
+	case update.MessageReaction != nil && h.MessageReaction != nil:
+		return true, h.MessageReaction(bot, update.MessageReaction)
+	case update.MessageReactionCount != nil && h.MessageReactionCount != nil:
+		return true, h.MessageReactionCount(bot, update.MessageReactionCount)
+	case update.BusinessConnection != nil && h.BusinessConnection != nil:
+		return true, h.BusinessConnection(bot, update.BusinessConnection)
+	case update.BusinessMessage != nil && h.BusinessMessage != nil:
+		return true, h.BusinessMessage(bot, update.BusinessMessage)
+	case update.EditedBusinessMessage != nil && h.EditedBusinessMessage != nil:
+		return true, h.EditedBusinessMessage(bot, update.EditedBusinessMessage)
+	case update.DeletedBusinessMessages != nil && h.DeletedBusinessMessages != nil:
+		return true, h.DeletedBusinessMessages(bot, update.DeletedBusinessMessages)
+	case h.Default != nil:
+		return true, h.Default(bot, update)
+	default:
+		return false, nil
+	}
+}
+
+// ReplyTo sends a text message replying to message, in the same forum topic
+// if it was sent to one. Replies to business messages are sent on behalf of
+// the connected business account.
+func ReplyTo(bot Sender, message *Message, text string) (Message, error) {
+	return bot.Send(NewMessageReply(message, text))
+}
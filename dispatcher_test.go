@@ -0,0 +1,87 @@
+package tgbotapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateHandlers(t *testing.T) {
+	var handled []string
+	failed := errors.New("failed")
+
+	handlers := UpdateHandlers{
+		Message: func(bot Sender, message *Message) error {
+			handled = append(handled, "message")
+			return nil
+		},
+		MessageReaction: func(bot Sender, reaction *MessageReactionUpdated) error {
+			handled = append(handled, "message_reaction")
+			return nil
+		},
+		MessageReactionCount: func(bot Sender, count *MessageReactionCountUpdated) error {
+			handled = append(handled, "message_reaction_count")
+			return failed
+		},
+	}
+
+	bot, _ := newRecordingBot()
+
+	updates := []struct {
+		update  Update
+		handled bool
+		err     error
+	}{
+		{Update{Message: &Message{MessageID: 1}}, true, nil},
+		{Update{MessageReaction: &MessageReactionUpdated{MessageID: 1}}, true, nil},
+		{Update{MessageReactionCount: &MessageReactionCountUpdated{MessageID: 1}}, true, failed},
+		{Update{EditedMessage: &Message{MessageID: 1}}, false, nil},
+	}
+
+	for _, test := range updates {
+		ok, err := handlers.Handle(bot, &test.update)
+		if ok != test.handled || err != test.err {
+			t.Errorf("%+v: got %t, %v", test.update, ok, err)
+		}
+	}
+
+	if len(handled) != 3 || handled[1] != "message_reaction" || handled[2] != "message_reaction_count" {
+		t.Errorf("unexpected handlers called %v", handled)
+	}
+
+	handlers.Default = func(bot Sender, update *Update) error {
+		handled = append(handled, "default")
+		return nil
+	}
+	if ok, _ := handlers.Handle(bot, &Update{EditedMessage: &Message{}}); !ok || handled[3] != "default" {
+		t.Errorf("updates without a handler should be passed to Default")
+	}
+}
+
+func TestUpdateHandlersBusinessMessage(t *testing.T) {
+	handlers := UpdateHandlers{
+		BusinessMessage: func(bot Sender, message *Message) error {
+			_, err := ReplyTo(bot, message, "Thanks!")
+			return err
+		},
+	}
+
+	bot, client := newRecordingBot()
+
+	update := &Update{BusinessMessage: &Message{
+		MessageID:            5,
+		Chat:                 &Chat{ID: 20},
+		BusinessConnectionID: "connection",
+	}}
+
+	// The recording client answers true instead of a message, so only the
+	// request is checked.
+	if ok, _ := handlers.Handle(bot, update); !ok || len(client.requests) != 1 {
+		t.Fatalf("business message not handled")
+	}
+
+	values := client.requests[0]
+	if client.methods[0] != "sendMessage" || values.Get("business_connection_id") != "connection" ||
+		values.Get("chat_id") != "20" || values.Get("text") != "Thanks!" {
+		t.Errorf("unexpected reply %s %v", client.methods[0], values)
+	}
+}
@@ -0,0 +1,448 @@
+package tgbotapi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// utf16Offsets maps each UTF-16 offset of s to the matching byte offset. The
+// result has UTF16Len(s)+1 items, the last one being len(s). An offset in the
+// middle of a surrogate pair maps to the start of its rune.
+func utf16Offsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+
+	for i, r := range s {
+		offsets = append(offsets, i)
+		if r >= 0x10000 {
+			offsets = append(offsets, i)
+		}
+	}
+
+	return append(offsets, len(s))
+}
+
+// UTF16Substring returns the part of text starting at offset with the given
+// length, both measured in UTF-16 code units. Out of range values are
+// clamped to the text.
+func UTF16Substring(text string, offset, length int) string {
+	offsets := utf16Offsets(text)
+	size := len(offsets) - 1
+
+	start := clampInt(offset, 0, size)
+	end := clampInt(offset+length, start, size)
+
+	return text[offsets[start]:offsets[end]]
+}
+
+// EntityText returns the part of text covered by an entity.
+func EntityText(text string, entity MessageEntity) string {
+	return UTF16Substring(text, entity.Offset, entity.Length)
+}
+
+// FilterEntities returns the entities having one of the given types.
+func FilterEntities(entities []MessageEntity, types ...string) []MessageEntity {
+	var filtered []MessageEntity
+
+	for _, entity := range entities {
+		for _, t := range types {
+			if entity.Type == t {
+				filtered = append(filtered, entity)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// TextWithEntities returns the text of the message with its entities, or the
+// caption with the caption entities for media messages.
+func (m *Message) TextWithEntities() (string, []MessageEntity) {
+	if m.Text == "" && m.Caption != "" {
+		return m.Caption, m.CaptionEntities
+	}
+
+	return m.Text, m.Entities
+}
+
+// EntityText returns the part of the message text, or caption, covered by an
+// entity.
+func (m *Message) EntityText(entity MessageEntity) string {
+	text, _ := m.TextWithEntities()
+
+	return EntityText(text, entity)
+}
+
+// EntityValues returns the text of every entity of the given type in the
+// message text, or caption, such as all the hashtags or URLs.
+func (m *Message) EntityValues(entityType string) []string {
+	text, entities := m.TextWithEntities()
+
+	var values []string
+	for _, entity := range FilterEntities(entities, entityType) {
+		values = append(values, EntityText(text, entity))
+	}
+
+	return values
+}
+
+// HTML renders the message text, or caption, and its entities using the HTML
+// parse mode.
+func (m *Message) HTML() string {
+	return RenderHTML(m.TextWithEntities())
+}
+
+// MarkdownV2 renders the message text, or caption, and its entities using the
+// MarkdownV2 parse mode.
+func (m *Message) MarkdownV2() string {
+	return RenderMarkdownV2(m.TextWithEntities())
+}
+
+// RenderHTML renders text with entities as markup for the ModeHTML parse
+// mode. Entities detected by Telegram on its own, such as mentions or URLs,
+// are left as plain text.
+func RenderHTML(text string, entities []MessageEntity) string {
+	return renderEntities(text, entities, htmlRenderer{})
+}
+
+// RenderMarkdownV2 renders text with entities as markup for the
+// ModeMarkdownV2 parse mode. Entities detected by Telegram on its own, such
+// as mentions or URLs, are left as plain text.
+func RenderMarkdownV2(text string, entities []MessageEntity) string {
+	return renderEntities(text, entities, &markdownV2Renderer{})
+}
+
+// entityRenderer writes the markup of a parse mode.
+type entityRenderer interface {
+	// open writes the start of an entity.
+	open(b *strings.Builder, entity MessageEntity)
+	// close writes the end of an entity.
+	close(b *strings.Builder, entity MessageEntity)
+	// text writes escaped text, open contains the enclosing entities.
+	text(b *strings.Builder, text string, open []MessageEntity)
+}
+
+// isFormattingEntity reports if an entity is set by the sender rather than
+// detected by Telegram.
+func isFormattingEntity(entity MessageEntity) bool {
+	switch entity.Type {
+	case EntityBold, EntityItalic, EntityUnderline, EntityStrikethrough,
+		EntitySpoiler, EntityCode, EntityPre, EntityTextLink,
+		EntityTextMention, EntityBlockquote, EntityCustomEmoji:
+		return true
+	}
+
+	return false
+}
+
+// renderEntities walks the text, opening and closing entities at their
+// boundaries. Entities which overlap without being nested are closed and
+// reopened so the output is always properly nested.
+func renderEntities(text string, entities []MessageEntity, r entityRenderer) string {
+	offsets := utf16Offsets(text)
+	size := len(offsets) - 1
+
+	sorted := make([]MessageEntity, 0, len(entities))
+	for _, entity := range entities {
+		if !isFormattingEntity(entity) {
+			continue
+		}
+
+		start := clampInt(entity.Offset, 0, size)
+		end := clampInt(entity.Offset+entity.Length, start, size)
+		if start == end {
+			continue
+		}
+
+		entity.Offset, entity.Length = start, end-start
+		sorted = append(sorted, entity)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Offset != sorted[j].Offset {
+			return sorted[i].Offset < sorted[j].Offset
+		}
+		return sorted[i].Length > sorted[j].Length
+	})
+
+	b := &strings.Builder{}
+	stack := []MessageEntity{}
+	next := 0
+	last := 0
+
+	for pos := 0; pos <= size; pos++ {
+		ends := false
+		for _, entity := range stack {
+			if entity.Offset+entity.Length == pos {
+				ends = true
+				break
+			}
+		}
+
+		starts := next < len(sorted) && sorted[next].Offset == pos
+		if !ends && !starts {
+			continue
+		}
+
+		r.text(b, text[offsets[last]:offsets[pos]], stack)
+		last = pos
+
+		if ends {
+			// Close everything down to the outermost ending entity, then
+			// reopen the ones continuing past this position.
+			outermost := len(stack)
+			for i, entity := range stack {
+				if entity.Offset+entity.Length == pos {
+					outermost = i
+					break
+				}
+			}
+
+			var reopen []MessageEntity
+			for i := len(stack) - 1; i >= outermost; i-- {
+				r.close(b, stack[i])
+				if stack[i].Offset+stack[i].Length != pos {
+					reopen = append([]MessageEntity{stack[i]}, reopen...)
+				}
+			}
+
+			stack = stack[:outermost]
+			for _, entity := range reopen {
+				r.open(b, entity)
+				stack = append(stack, entity)
+			}
+		}
+
+		for next < len(sorted) && sorted[next].Offset == pos {
+			r.open(b, sorted[next])
+			stack = append(stack, sorted[next])
+			next++
+		}
+	}
+
+	r.text(b, text[offsets[last]:], stack)
+
+	return b.String()
+}
+
+type htmlRenderer struct{}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func (htmlRenderer) open(b *strings.Builder, entity MessageEntity) {
+	switch entity.Type {
+	case EntityBold:
+		b.WriteString("<b>")
+	case EntityItalic:
+		b.WriteString("<i>")
+	case EntityUnderline:
+		b.WriteString("<u>")
+	case EntityStrikethrough:
+		b.WriteString("<s>")
+	case EntitySpoiler:
+		b.WriteString("<tg-spoiler>")
+	case EntityCode:
+		b.WriteString("<code>")
+	case EntityPre:
+		if entity.Language != "" {
+			b.WriteString(`<pre><code class="language-` + htmlEscaper.Replace(entity.Language) + `">`)
+		} else {
+			b.WriteString("<pre>")
+		}
+	case EntityTextLink:
+		b.WriteString(`<a href="` + htmlEscaper.Replace(entity.URL) + `">`)
+	case EntityTextMention:
+		b.WriteString(`<a href="tg://user?id=` + strconv.FormatInt(mentionedUserID(entity), 10) + `">`)
+	case EntityBlockquote:
+		b.WriteString("<blockquote>")
+	case EntityCustomEmoji:
+		b.WriteString(`<tg-emoji emoji-id="` + htmlEscaper.Replace(entity.CustomEmojiID) + `">`)
+	}
+}
+
+func (htmlRenderer) close(b *strings.Builder, entity MessageEntity) {
+	switch entity.Type {
+	case EntityBold:
+		b.WriteString("</b>")
+	case EntityItalic:
+		b.WriteString("</i>")
+	case EntityUnderline:
+		b.WriteString("</u>")
+	case EntityStrikethrough:
+		b.WriteString("</s>")
+	case EntitySpoiler:
+		b.WriteString("</tg-spoiler>")
+	case EntityCode:
+		b.WriteString("</code>")
+	case EntityPre:
+		if entity.Language != "" {
+			b.WriteString("</code></pre>")
+		} else {
+			b.WriteString("</pre>")
+		}
+	case EntityTextLink, EntityTextMention:
+		b.WriteString("</a>")
+	case EntityBlockquote:
+		b.WriteString("</blockquote>")
+	case EntityCustomEmoji:
+		b.WriteString("</tg-emoji>")
+	}
+}
+
+func (htmlRenderer) text(b *strings.Builder, text string, open []MessageEntity) {
+	b.WriteString(htmlEscaper.Replace(text))
+}
+
+type markdownV2Renderer struct {
+	// underscore is set when the output ends with an underscore marker, so
+	// a following underscore marker must be separated from it.
+	underscore bool
+	// quoteEnded is set when a block quotation ended, so following text on
+	// the same line must be moved to the next line to stay unquoted.
+	quoteEnded bool
+}
+
+// lineBreak starts a new line, unless the output is empty or already ends
+// with a line break, as block quotations only start at a line start.
+func (r *markdownV2Renderer) lineBreak(b *strings.Builder) {
+	if s := b.String(); s != "" && !strings.HasSuffix(s, "\n") {
+		b.WriteString("\n")
+		r.underscore = false
+	}
+}
+
+// endQuote moves the output following a block quotation to the next line,
+// unless it already starts with one.
+func (r *markdownV2Renderer) endQuote(b *strings.Builder, next string) {
+	if r.quoteEnded && !strings.HasPrefix(next, "\n") {
+		r.lineBreak(b)
+	}
+
+	r.quoteEnded = false
+}
+
+var (
+	markdownV2Escaper = strings.NewReplacer(
+		`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`,
+		")", `\)`, "~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`,
+		"-", `\-`, "=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`,
+		"!", `\!`,
+	)
+	markdownV2CodeEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+	markdownV2LinkEscaper = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+)
+
+func (r *markdownV2Renderer) marker(b *strings.Builder, marker string) {
+	// Telegram reads "___" greedily as underline, so italic and underline
+	// markers next to each other are separated by a carriage return.
+	if r.underscore && strings.HasPrefix(marker, "_") {
+		b.WriteString("\r")
+	}
+
+	b.WriteString(marker)
+	r.underscore = strings.HasSuffix(marker, "_")
+}
+
+func (r *markdownV2Renderer) open(b *strings.Builder, entity MessageEntity) {
+	r.endQuote(b, "")
+
+	switch entity.Type {
+	case EntityBold:
+		r.marker(b, "*")
+	case EntityItalic:
+		r.marker(b, "_")
+	case EntityUnderline:
+		r.marker(b, "__")
+	case EntityStrikethrough:
+		r.marker(b, "~")
+	case EntitySpoiler:
+		r.marker(b, "||")
+	case EntityCode:
+		r.marker(b, "`")
+	case EntityPre:
+		r.marker(b, "```"+entity.Language+"\n")
+	case EntityTextLink, EntityTextMention:
+		r.marker(b, "[")
+	case EntityBlockquote:
+		r.lineBreak(b)
+		r.marker(b, ">")
+	case EntityCustomEmoji:
+		r.marker(b, "![")
+	}
+}
+
+func (r *markdownV2Renderer) close(b *strings.Builder, entity MessageEntity) {
+	switch entity.Type {
+	case EntityBold:
+		r.marker(b, "*")
+	case EntityItalic:
+		r.marker(b, "_")
+	case EntityUnderline:
+		r.marker(b, "__")
+	case EntityStrikethrough:
+		r.marker(b, "~")
+	case EntitySpoiler:
+		r.marker(b, "||")
+	case EntityCode:
+		r.marker(b, "`")
+	case EntityPre:
+		r.marker(b, "```")
+	case EntityTextLink:
+		r.marker(b, "]("+markdownV2LinkEscaper.Replace(entity.URL)+")")
+	case EntityTextMention:
+		r.marker(b, "](tg://user?id="+strconv.FormatInt(mentionedUserID(entity), 10)+")")
+	case EntityBlockquote:
+		// Block quotations end with the line.
+		r.underscore = false
+		r.quoteEnded = true
+	case EntityCustomEmoji:
+		r.marker(b, "](tg://emoji?id="+markdownV2LinkEscaper.Replace(entity.CustomEmojiID)+")")
+	}
+}
+
+func (r *markdownV2Renderer) text(b *strings.Builder, text string, open []MessageEntity) {
+	if text == "" {
+		return
+	}
+
+	r.endQuote(b, text)
+
+	escaper := markdownV2Escaper
+	quoted := false
+	for _, entity := range open {
+		switch entity.Type {
+		case EntityCode, EntityPre:
+			escaper = markdownV2CodeEscaper
+		case EntityBlockquote:
+			quoted = true
+		}
+	}
+
+	text = escaper.Replace(text)
+	if quoted {
+		text = strings.ReplaceAll(text, "\n", "\n>")
+	}
+
+	b.WriteString(text)
+	r.underscore = false
+}
+
+func mentionedUserID(entity MessageEntity) int64 {
+	if entity.User == nil {
+		return 0
+	}
+
+	return entity.User.ID
+}
+
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+
+	return value
+}
@@ -0,0 +1,97 @@
+package tgbotapi
+
+import (
+	"testing"
+)
+
+func TestUTF16Substring(t *testing.T) {
+	text := "👍 héllo 🌍!"
+
+	if s := UTF16Substring(text, 3, 5); s != "héllo" {
+		t.Errorf("unexpected substring %q", s)
+	}
+	if s := UTF16Substring(text, 9, 2); s != "🌍" {
+		t.Errorf("unexpected substring %q", s)
+	}
+	if s := UTF16Substring(text, 9, 100); s != "🌍!" {
+		t.Errorf("expected clamped substring, got %q", s)
+	}
+}
+
+func TestMessageEntityValues(t *testing.T) {
+	message := Message{
+		Caption: "😀 #one and #two",
+		CaptionEntities: []MessageEntity{
+			{Type: EntityHashtag, Offset: 3, Length: 4},
+			{Type: EntityBold, Offset: 8, Length: 3},
+			{Type: EntityHashtag, Offset: 12, Length: 4},
+		},
+	}
+
+	values := message.EntityValues(EntityHashtag)
+	if len(values) != 2 || values[0] != "#one" || values[1] != "#two" {
+		t.Fatalf("unexpected hashtags %v", values)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	text := "bold <b> link 👍 mention"
+	entities := []MessageEntity{
+		{Type: EntityBold, Offset: 0, Length: 13},
+		{Type: EntityItalic, Offset: 9, Length: 7},
+		{Type: EntityTextLink, Offset: 9, Length: 4, URL: `https://example.com/?a=1&b="2"`},
+		{Type: EntityTextMention, Offset: 17, Length: 7, User: &User{ID: 42}},
+		{Type: EntityURL, Offset: 0, Length: 4},
+	}
+
+	expected := `<b>bold &lt;b&gt; <i><a href="https://example.com/?a=1&amp;b=&quot;2&quot;">link</a></i></b><i> 👍</i> <a href="tg://user?id=42">mention</a>`
+	if actual := RenderHTML(text, entities); actual != expected {
+		t.Fatalf("unexpected HTML\n%s\nexpected\n%s", actual, expected)
+	}
+}
+
+func TestRenderMarkdownV2(t *testing.T) {
+	text := "italic underline 1.5 code\nquote\nnext"
+	entities := []MessageEntity{
+		{Type: EntityItalic, Offset: 0, Length: 16},
+		{Type: EntityUnderline, Offset: 7, Length: 9},
+		{Type: EntityCode, Offset: 21, Length: 4},
+		{Type: EntityBlockquote, Offset: 26, Length: 10},
+		{Type: EntityTextLink, Offset: 17, Length: 3, URL: "https://example.com/(x)"},
+	}
+
+	expected := "_italic __underline__\r_ [1\\.5](https://example.com/(x\\)) `code`\n>quote\n>next"
+	if actual := RenderMarkdownV2(text, entities); actual != expected {
+		t.Fatalf("unexpected MarkdownV2\n%q\nexpected\n%q", actual, expected)
+	}
+}
+
+func TestRenderMarkdownV2QuoteMidLine(t *testing.T) {
+	text := "see quote and more"
+	entities := []MessageEntity{
+		{Type: EntityBlockquote, Offset: 4, Length: 5},
+	}
+
+	expected := "see \n>quote\n and more"
+	actual := RenderMarkdownV2(text, entities)
+	if actual != expected {
+		t.Fatalf("unexpected MarkdownV2\n%q\nexpected\n%q", actual, expected)
+	}
+
+	_, parsed, err := ParseMarkup(ModeMarkdownV2, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed) != 1 || parsed[0].Type != EntityBlockquote {
+		t.Fatalf("unexpected entities %+v", parsed)
+	}
+}
+
+func TestRenderEntitiesTrailingText(t *testing.T) {
+	entities := []MessageEntity{{Type: EntityBold, Offset: 0, Length: 4}}
+
+	if actual := RenderHTML("bold text", entities); actual != "<b>bold</b> text" {
+		t.Fatalf("unexpected HTML %q", actual)
+	}
+}
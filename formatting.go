@@ -0,0 +1,198 @@
+package tgbotapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Constant values for MessageEntity types
+const (
+	EntityMention       = "mention"
+	EntityHashtag       = "hashtag"
+	EntityCashtag       = "cashtag"
+	EntityBotCommand    = "bot_command"
+	EntityURL           = "url"
+	EntityEmail         = "email"
+	EntityPhoneNumber   = "phone_number"
+	EntityBold          = "bold"
+	EntityItalic        = "italic"
+	EntityUnderline     = "underline"
+	EntityStrikethrough = "strikethrough"
+	EntitySpoiler       = "spoiler"
+	EntityBlockquote    = "blockquote"
+	EntityCode          = "code"
+	EntityPre           = "pre"
+	EntityTextLink      = "text_link"
+	EntityTextMention   = "text_mention"
+	EntityCustomEmoji   = "custom_emoji"
+)
+
+// UTF16Len returns the length of a string in UTF-16 code units, which is how
+// Telegram measures entity offsets and lengths.
+func UTF16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+
+	return n
+}
+
+// TextBuilder composes formatted text as plain text and a list of entities,
+// so it can be sent without a parse mode and without escaping.
+//
+// Offsets of the entities are tracked in UTF-16 code units.
+type TextBuilder struct {
+	text     strings.Builder
+	length   int
+	entities []MessageEntity
+}
+
+// NewTextBuilder creates an empty TextBuilder.
+func NewTextBuilder() *TextBuilder {
+	return &TextBuilder{}
+}
+
+// Text appends unformatted text.
+func (b *TextBuilder) Text(text string) *TextBuilder {
+	b.text.WriteString(text)
+	b.length += UTF16Len(text)
+
+	return b
+}
+
+// Textf appends unformatted text formatted with fmt.Sprintf.
+func (b *TextBuilder) Textf(format string, a ...interface{}) *TextBuilder {
+	return b.Text(fmt.Sprintf(format, a...))
+}
+
+// Newline appends a line break.
+func (b *TextBuilder) Newline() *TextBuilder {
+	return b.Text("\n")
+}
+
+// Bold appends bold text.
+func (b *TextBuilder) Bold(text string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityBold}, text)
+}
+
+// Italic appends italic text.
+func (b *TextBuilder) Italic(text string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityItalic}, text)
+}
+
+// Underline appends underlined text.
+func (b *TextBuilder) Underline(text string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityUnderline}, text)
+}
+
+// Strikethrough appends strikethrough text.
+func (b *TextBuilder) Strikethrough(text string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityStrikethrough}, text)
+}
+
+// Spoiler appends text hidden as a spoiler.
+func (b *TextBuilder) Spoiler(text string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntitySpoiler}, text)
+}
+
+// Code appends inline monowidth text.
+func (b *TextBuilder) Code(text string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityCode}, text)
+}
+
+// Pre appends a monowidth block. The language is optional.
+func (b *TextBuilder) Pre(text, language string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityPre, Language: language}, text)
+}
+
+// TextLink appends text linking to a URL.
+func (b *TextBuilder) TextLink(text, url string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityTextLink, URL: url}, text)
+}
+
+// TextMention appends text mentioning a user, for users without usernames.
+func (b *TextBuilder) TextMention(text string, user *User) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityTextMention, User: user}, text)
+}
+
+// Blockquote appends a block quotation.
+func (b *TextBuilder) Blockquote(text string) *TextBuilder {
+	return b.styled(MessageEntity{Type: EntityBlockquote}, text)
+}
+
+// Entity applies an entity to everything appended by fn, allowing entities
+// to be nested. The offset and length of the entity are filled in.
+//
+// For example, a bold link may be created with:
+//
+//	b.Entity(MessageEntity{Type: EntityBold}, func(b *TextBuilder) {
+//		b.TextLink("example", "https://example.com")
+//	})
+func (b *TextBuilder) Entity(entity MessageEntity, fn func(b *TextBuilder)) *TextBuilder {
+	start := b.length
+	fn(b)
+
+	entity.Offset = start
+	entity.Length = b.length - start
+	if entity.Length > 0 {
+		b.entities = append(b.entities, entity)
+	}
+
+	return b
+}
+
+func (b *TextBuilder) styled(entity MessageEntity, text string) *TextBuilder {
+	return b.Entity(entity, func(b *TextBuilder) {
+		b.Text(text)
+	})
+}
+
+// Len returns the length of the text in UTF-16 code units.
+func (b *TextBuilder) Len() int {
+	return b.length
+}
+
+// String returns the plain text.
+func (b *TextBuilder) String() string {
+	return b.text.String()
+}
+
+// Entities returns the entities of the text, ordered by offset. Enclosing
+// entities come before the entities nested in them.
+func (b *TextBuilder) Entities() []MessageEntity {
+	if len(b.entities) == 0 {
+		return nil
+	}
+
+	entities := make([]MessageEntity, len(b.entities))
+	copy(entities, b.entities)
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		if entities[i].Offset != entities[j].Offset {
+			return entities[i].Offset < entities[j].Offset
+		}
+		return entities[i].Length > entities[j].Length
+	})
+
+	return entities
+}
+
+// Build returns the plain text and its entities, for use as a message text
+// or a caption.
+func (b *TextBuilder) Build() (string, []MessageEntity) {
+	return b.String(), b.Entities()
+}
+
+// Message creates a MessageConfig with the built text and entities.
+func (b *TextBuilder) Message(chatID int64) MessageConfig {
+	msg := NewMessage(chatID, b.String())
+	msg.Entities = b.Entities()
+
+	return msg
+}
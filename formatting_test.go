@@ -0,0 +1,62 @@
+package tgbotapi
+
+import (
+	"testing"
+)
+
+func TestUTF16Len(t *testing.T) {
+	tests := map[string]int{
+		"":       0,
+		"hello":  5,
+		"привет": 6,
+		"👍":      2,
+		"a👍b":    4,
+	}
+
+	for input, expected := range tests {
+		if actual := UTF16Len(input); actual != expected {
+			t.Errorf("UTF16Len(%q) = %d, expected %d", input, actual, expected)
+		}
+	}
+}
+
+func TestTextBuilder(t *testing.T) {
+	b := NewTextBuilder().
+		Text("👍 ").
+		Bold("bold").
+		Text(" ").
+		Entity(MessageEntity{Type: EntityItalic}, func(b *TextBuilder) {
+			b.Text("it ").TextLink("link", "https://example.com")
+		}).
+		Newline().
+		Pre("fmt.Println()", "go").
+		Code("")
+
+	text, entities := b.Build()
+
+	if text != "👍 bold it link\nfmt.Println()" {
+		t.Fatalf("unexpected text %q", text)
+	}
+
+	expected := []MessageEntity{
+		{Type: EntityBold, Offset: 3, Length: 4},
+		{Type: EntityItalic, Offset: 8, Length: 7},
+		{Type: EntityTextLink, Offset: 11, Length: 4, URL: "https://example.com"},
+		{Type: EntityPre, Offset: 16, Length: 13, Language: "go"},
+	}
+
+	if len(entities) != len(expected) {
+		t.Fatalf("expected %d entities, got %v", len(expected), entities)
+	}
+
+	for i := range expected {
+		if entities[i] != expected[i] {
+			t.Errorf("entity %d: expected %+v, got %+v", i, expected[i], entities[i])
+		}
+	}
+
+	msg := b.Message(ChatID)
+	if msg.ParseMode != "" || len(msg.Entities) != len(expected) {
+		t.Fatal("expected a message with entities and no parse mode")
+	}
+}
@@ -0,0 +1,3 @@
+package tgbotapi
+
+//go:generate go run ./cmd/tgbotapi-gen -schema schema/api.json -dir . -report docs/compatibility.md
@@ -1,13 +1,12 @@
 package tgbotapi
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
-	"errors"
+	"bytes"
 	"fmt"
+	"io"
 	"net/url"
-	"sort"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -47,6 +46,54 @@ func NewMessageToChannel(username string, text string) MessageConfig {
 	}
 }
 
+// NewMessageToTopic creates a new Message sent to a forum topic.
+//
+// chatID is the forum supergroup and messageThreadID is the topic.
+func NewMessageToTopic(chatID int64, messageThreadID int, text string) MessageConfig {
+	return MessageConfig{
+		BaseChat: BaseChat{
+			ChatID:          chatID,
+			MessageThreadID: messageThreadID,
+		},
+		Text: text,
+	}
+}
+
+// NewMessageReply creates a new Message replying to message, in the same
+// forum topic if it was sent to one. Replies to business messages are sent
+// on behalf of the business account.
+func NewMessageReply(message *Message, text string) MessageConfig {
+	return MessageConfig{
+		BaseChat: BaseChat{
+			ChatID:               message.Chat.ID,
+			BusinessConnectionID: message.BusinessConnectionID,
+			MessageThreadID:      message.TopicID(),
+			ReplyParameters:      &ReplyParameters{MessageID: message.MessageID},
+		},
+		Text: text,
+	}
+}
+
+// NewQuoteReply creates a new Message replying to message and quoting part
+// of its text or caption. The quote must be an exact substring of the text,
+// and is located by its first occurrence. If it is not found, the message
+// replies without a quote.
+func NewQuoteReply(message *Message, quote, text string) MessageConfig {
+	config := NewMessageReply(message, text)
+
+	quoted := message.Text
+	if quoted == "" {
+		quoted = message.Caption
+	}
+
+	if i := strings.Index(quoted, quote); quote != "" && i >= 0 {
+		config.ReplyParameters.Quote = quote
+		config.ReplyParameters.QuotePosition = UTF16Len(quoted[:i])
+	}
+
+	return config
+}
+
 // NewForward creates a new forward.
 //
 // chatID is where to send it, fromChatID is the source chat,
@@ -435,10 +482,10 @@ func NewInlineQueryResultPhoto(id, url string) InlineQueryResultPhoto {
 // NewInlineQueryResultPhotoWithThumb creates a new inline query photo.
 func NewInlineQueryResultPhotoWithThumb(id, url, thumb string) InlineQueryResultPhoto {
 	return InlineQueryResultPhoto{
-		Type:     "photo",
-		ID:       id,
-		URL:      url,
-		ThumbURL: thumb,
+		Type:         "photo",
+		ID:           id,
+		URL:          url,
+		ThumbnailURL: thumb,
 	}
 }
 
@@ -470,6 +517,80 @@ func NewInlineQueryResultCachedVideo(id, videoID, title string) InlineQueryResul
 	}
 }
 
+// NewInputSticker creates a sticker to add to a set, in one of the sticker
+// formats and associated with 1-20 emoji.
+func NewInputSticker(file RequestFileData, format string, emojis ...string) InputSticker {
+	return InputSticker{
+		Sticker:   file,
+		Format:    format,
+		EmojiList: emojis,
+	}
+}
+
+// NewStickerSetFromFiles creates a new regular sticker set from local files,
+// detecting their format with DetectStickerFormat. All the stickers are
+// associated with the same emoji.
+func NewStickerSetFromFiles(userID int64, name, title string, emojis []string, paths ...string) (NewStickerSetConfig, error) {
+	config := NewStickerSetConfig{
+		UserID:      userID,
+		Name:        name,
+		Title:       title,
+		StickerType: StickerTypeRegular,
+	}
+
+	for _, path := range paths {
+		format, err := DetectStickerFormat(path)
+		if err != nil {
+			return config, err
+		}
+
+		config.Stickers = append(config.Stickers, NewInputSticker(FilePath(path), format, emojis...))
+	}
+
+	return config, nil
+}
+
+// DetectStickerFormat returns the format of a sticker file, from its content
+// or else from its extension: StickerFormatStatic for .PNG and .WEBP images,
+// StickerFormatAnimated for .TGS animations and StickerFormatVideo for .WEBM
+// videos.
+func DetectStickerFormat(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	header := make([]byte, 12)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("\x89PNG")),
+		bytes.HasPrefix(header, []byte("RIFF")) && bytes.HasSuffix(header, []byte("WEBP")):
+		return StickerFormatStatic, nil
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		// TGS animations are gzipped Lottie files.
+		return StickerFormatAnimated, nil
+	case bytes.HasPrefix(header, []byte{0x1a, 0x45, 0xdf, 0xa3}):
+		return StickerFormatVideo, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".webp":
+		return StickerFormatStatic, nil
+	case ".tgs":
+		return StickerFormatAnimated, nil
+	case ".webm":
+		return StickerFormatVideo, nil
+	}
+
+	return "", fmt.Errorf("unknown sticker format of %s", path)
+}
+
 // NewInlineQueryResultCachedSticker create a new inline query with cached sticker.
 func NewInlineQueryResultCachedSticker(id, stickerID, title string) InlineQueryResultCachedSticker {
 	return InlineQueryResultCachedSticker{
@@ -777,6 +898,49 @@ func NewInvoice(chatID int64, title, description, payload, providerToken, startP
 		Prices:         prices}
 }
 
+// NewStarsInvoice creates a new invoice paid in Telegram Stars, for a single
+// price of amount Stars.
+func NewStarsInvoice(chatID int64, title, description, payload string, amount int) InvoiceConfig {
+	return InvoiceConfig{
+		BaseChat:    BaseChat{ChatID: chatID},
+		Title:       title,
+		Description: description,
+		Payload:     payload,
+		Currency:    CurrencyStars,
+		Prices:      []LabeledPrice{{Label: title, Amount: amount}},
+	}
+}
+
+// NewStarsInvoiceLink creates a link for an invoice paid in Telegram Stars,
+// for a single price of amount Stars.
+func NewStarsInvoiceLink(title, description, payload string, amount int) InvoiceLinkConfig {
+	return InvoiceLinkConfig{
+		Title:       title,
+		Description: description,
+		Payload:     payload,
+		Currency:    CurrencyStars,
+		Prices:      []LabeledPrice{{Label: title, Amount: amount}},
+	}
+}
+
+// NewStarsSubscriptionLink creates a link for a subscription paid in Telegram
+// Stars, charging amount Stars every 30 days.
+func NewStarsSubscriptionLink(title, description, payload string, amount int) InvoiceLinkConfig {
+	config := NewStarsInvoiceLink(title, description, payload, amount)
+	config.SubscriptionPeriod = StarsSubscriptionPeriod
+
+	return config
+}
+
+// NewRefundStarPayment refunds a successful payment in Telegram Stars, given
+// the TelegramPaymentChargeID of its SuccessfulPayment.
+func NewRefundStarPayment(userID int64, telegramPaymentChargeID string) RefundStarPaymentConfig {
+	return RefundStarPaymentConfig{
+		UserID:                  userID,
+		TelegramPaymentChargeID: telegramPaymentChargeID,
+	}
+}
+
 // NewChatTitle allows you to update the title of a chat.
 func NewChatTitle(chatID int64, title string) SetChatTitleConfig {
 	return SetChatTitleConfig{
@@ -953,35 +1117,305 @@ func NewDeleteMyCommandsWithScopeAndLanguage(scope BotCommandScope, languageCode
 
 // ValidateWebAppData validate data received via the Web App
 // https://core.telegram.org/bots/webapps#validating-data-received-via-the-web-app
+//
+// Use ValidateWebAppInitData to get the parsed data and reject stale data.
 func ValidateWebAppData(token, telegramInitData string) (bool, error) {
 	initData, err := url.ParseQuery(telegramInitData)
 	if err != nil {
 		return false, fmt.Errorf("error parsing data %w", err)
 	}
 
-	dataCheckString := make([]string, 0, len(initData))
-	for k, v := range initData {
-		if k == "hash" {
-			continue
-		}
-		if len(v) > 0 {
-			dataCheckString = append(dataCheckString, fmt.Sprintf("%s=%s", k, v[0]))
-		}
+	if err := checkWebAppHash(token, initData); err != nil {
+		return false, err
 	}
 
-	sort.Strings(dataCheckString)
+	return true, nil
+}
+
+// NewCreateForumTopic creates a request to create a forum topic.
+func NewCreateForumTopic(chatID int64, name string) CreateForumTopicConfig {
+	return CreateForumTopicConfig{
+		ChatID: chatID,
+		Name:   name,
+	}
+}
 
-	secret := hmac.New(sha256.New, []byte("WebAppData"))
-	secret.Write([]byte(token))
+// NewEditForumTopic creates a request to rename a forum topic.
+func NewEditForumTopic(chatID int64, messageThreadID int, name string) EditForumTopicConfig {
+	return EditForumTopicConfig{
+		ChatID:          chatID,
+		MessageThreadID: messageThreadID,
+		Name:            name,
+	}
+}
 
-	hHash := hmac.New(sha256.New, secret.Sum(nil))
-	hHash.Write([]byte(strings.Join(dataCheckString, "\n")))
+// NewCloseForumTopic creates a request to close a forum topic.
+func NewCloseForumTopic(chatID int64, messageThreadID int) CloseForumTopicConfig {
+	return CloseForumTopicConfig{
+		ChatID:          chatID,
+		MessageThreadID: messageThreadID,
+	}
+}
 
-	hash := hex.EncodeToString(hHash.Sum(nil))
+// NewReopenForumTopic creates a request to reopen a closed forum topic.
+func NewReopenForumTopic(chatID int64, messageThreadID int) ReopenForumTopicConfig {
+	return ReopenForumTopicConfig{
+		ChatID:          chatID,
+		MessageThreadID: messageThreadID,
+	}
+}
 
-	if initData.Get("hash") != hash {
-		return false, errors.New("hash not equal")
+// NewDeleteForumTopic creates a request to delete a forum topic with all
+// its messages.
+func NewDeleteForumTopic(chatID int64, messageThreadID int) DeleteForumTopicConfig {
+	return DeleteForumTopicConfig{
+		ChatID:          chatID,
+		MessageThreadID: messageThreadID,
 	}
+}
 
-	return true, nil
+// NewUnpinAllForumTopicMessages creates a request to unpin all messages of
+// a forum topic.
+func NewUnpinAllForumTopicMessages(chatID int64, messageThreadID int) UnpinAllForumTopicMessagesConfig {
+	return UnpinAllForumTopicMessagesConfig{
+		ChatID:          chatID,
+		MessageThreadID: messageThreadID,
+	}
+}
+
+// NewEditGeneralForumTopic creates a request to rename the 'General' topic
+// of a forum.
+func NewEditGeneralForumTopic(chatID int64, name string) EditGeneralForumTopicConfig {
+	return EditGeneralForumTopicConfig{
+		ChatID: chatID,
+		Name:   name,
+	}
+}
+
+// NewCloseGeneralForumTopic creates a request to close the 'General' topic
+// of a forum.
+func NewCloseGeneralForumTopic(chatID int64) CloseGeneralForumTopicConfig {
+	return CloseGeneralForumTopicConfig{ChatID: chatID}
+}
+
+// NewReopenGeneralForumTopic creates a request to reopen the 'General'
+// topic of a forum.
+func NewReopenGeneralForumTopic(chatID int64) ReopenGeneralForumTopicConfig {
+	return ReopenGeneralForumTopicConfig{ChatID: chatID}
+}
+
+// NewHideGeneralForumTopic creates a request to hide the 'General' topic
+// of a forum.
+func NewHideGeneralForumTopic(chatID int64) HideGeneralForumTopicConfig {
+	return HideGeneralForumTopicConfig{ChatID: chatID}
+}
+
+// NewUnhideGeneralForumTopic creates a request to unhide the 'General'
+// topic of a forum.
+func NewUnhideGeneralForumTopic(chatID int64) UnhideGeneralForumTopicConfig {
+	return UnhideGeneralForumTopicConfig{ChatID: chatID}
+}
+
+// NewUnpinAllGeneralForumTopicMessages creates a request to unpin all
+// messages of the 'General' topic of a forum.
+func NewUnpinAllGeneralForumTopicMessages(chatID int64) UnpinAllGeneralForumTopicMessagesConfig {
+	return UnpinAllGeneralForumTopicMessagesConfig{ChatID: chatID}
+}
+
+// NewReactionEmoji creates an emoji reaction, such as "👍".
+func NewReactionEmoji(emoji string) ReactionType {
+	return ReactionType{
+		Type:  ReactionTypeEmoji,
+		Emoji: emoji,
+	}
+}
+
+// NewReactionCustomEmoji creates a custom emoji reaction.
+func NewReactionCustomEmoji(customEmojiID string) ReactionType {
+	return ReactionType{
+		Type:          ReactionTypeCustomEmoji,
+		CustomEmojiID: customEmojiID,
+	}
+}
+
+// NewReactionPaid creates a paid reaction. Bots can't set paid reactions,
+// but receive them in updates and chat info.
+func NewReactionPaid() ReactionType {
+	return ReactionType{Type: ReactionTypePaid}
+}
+
+// NewSetMessageReaction creates a request to set the reactions of the bot
+// on a message. Without reactions, the reactions of the bot are removed.
+func NewSetMessageReaction(chatID int64, messageID int, reactions ...ReactionType) SetMessageReactionConfig {
+	return SetMessageReactionConfig{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Reaction:  reactions,
+	}
+}
+
+// NewPassportScope creates the scope of a passport authorization request.
+func NewPassportScope(elements ...PassportScopeElement) *PassportScope {
+	return &PassportScope{V: 1, Data: elements}
+}
+
+// NewSetPassportDataErrors reports errors in the passport data of a user.
+func NewSetPassportDataErrors(userID int64, errors ...PassportElementError) SetPassportDataErrorsConfig {
+	return SetPassportDataErrorsConfig{
+		UserID: userID,
+		Errors: errors,
+	}
+}
+
+// NewPassportElementErrorDataField creates an error in a data field of an
+// element, with the data hash of the element from the decrypted credentials.
+func NewPassportElementErrorDataField(credentials *Credentials, elementType, fieldName, message string) (PassportElementErrorDataField, error) {
+	value := credentials.Data[elementType]
+	if value == nil || value.Data == nil {
+		return PassportElementErrorDataField{}, fmt.Errorf("no data credentials for %s", elementType)
+	}
+
+	return PassportElementErrorDataField{
+		Source:    "data",
+		Type:      elementType,
+		FieldName: fieldName,
+		DataHash:  value.Data.DataHash,
+		Message:   message,
+	}, nil
+}
+
+// NewPassportElementErrorFrontSide creates an error in the front side of the
+// document of an element, with its file hash from the decrypted credentials.
+func NewPassportElementErrorFrontSide(credentials *Credentials, elementType, message string) (PassportElementErrorFrontSide, error) {
+	value := credentials.Data[elementType]
+	if value == nil || value.FrontSide == nil {
+		return PassportElementErrorFrontSide{}, fmt.Errorf("no front side credentials for %s", elementType)
+	}
+
+	return PassportElementErrorFrontSide{
+		Source:   "front_side",
+		Type:     elementType,
+		FileHash: value.FrontSide.FileHash,
+		Message:  message,
+	}, nil
+}
+
+// NewPassportElementErrorReverseSide creates an error in the reverse side of
+// the document of an element, with its file hash from the decrypted
+// credentials.
+func NewPassportElementErrorReverseSide(credentials *Credentials, elementType, message string) (PassportElementErrorReverseSide, error) {
+	value := credentials.Data[elementType]
+	if value == nil || value.ReverseSide == nil {
+		return PassportElementErrorReverseSide{}, fmt.Errorf("no reverse side credentials for %s", elementType)
+	}
+
+	return PassportElementErrorReverseSide{
+		Source:   "reverse_side",
+		Type:     elementType,
+		FileHash: value.ReverseSide.FileHash,
+		Message:  message,
+	}, nil
+}
+
+// NewPassportElementErrorSelfie creates an error in the selfie of an
+// element, with its file hash from the decrypted credentials.
+func NewPassportElementErrorSelfie(credentials *Credentials, elementType, message string) (PassportElementErrorSelfie, error) {
+	value := credentials.Data[elementType]
+	if value == nil || value.Selfie == nil {
+		return PassportElementErrorSelfie{}, fmt.Errorf("no selfie credentials for %s", elementType)
+	}
+
+	return PassportElementErrorSelfie{
+		Source:   "selfie",
+		Type:     elementType,
+		FileHash: value.Selfie.FileHash,
+		Message:  message,
+	}, nil
+}
+
+// NewPassportElementErrorFile creates an error in one of the files of an
+// element, given by its index, with its file hash from the decrypted
+// credentials.
+func NewPassportElementErrorFile(credentials *Credentials, elementType string, index int, message string) (PassportElementErrorFile, error) {
+	value := credentials.Data[elementType]
+	if value == nil || index < 0 || index >= len(value.Files) {
+		return PassportElementErrorFile{}, fmt.Errorf("no credentials for file %d of %s", index, elementType)
+	}
+
+	return PassportElementErrorFile{
+		Source:   "file",
+		Type:     elementType,
+		FileHash: value.Files[index].FileHash,
+		Message:  message,
+	}, nil
+}
+
+// NewPassportElementErrorFiles creates an error in all the files of an
+// element, with their file hashes from the decrypted credentials.
+func NewPassportElementErrorFiles(credentials *Credentials, elementType, message string) (PassportElementErrorFiles, error) {
+	value := credentials.Data[elementType]
+	if value == nil || len(value.Files) == 0 {
+		return PassportElementErrorFiles{}, fmt.Errorf("no files credentials for %s", elementType)
+	}
+
+	return PassportElementErrorFiles{
+		Source:     "files",
+		Type:       elementType,
+		FileHashes: fileHashes(value.Files),
+		Message:    message,
+	}, nil
+}
+
+// NewPassportElementErrorTranslationFile creates an error in one of the
+// translation files of an element, given by its index, with its file hash
+// from the decrypted credentials.
+func NewPassportElementErrorTranslationFile(credentials *Credentials, elementType string, index int, message string) (PassportElementErrorTranslationFile, error) {
+	value := credentials.Data[elementType]
+	if value == nil || index < 0 || index >= len(value.Translation) {
+		return PassportElementErrorTranslationFile{}, fmt.Errorf("no credentials for translation file %d of %s", index, elementType)
+	}
+
+	return PassportElementErrorTranslationFile{
+		Source:   "translation_file",
+		Type:     elementType,
+		FileHash: value.Translation[index].FileHash,
+		Message:  message,
+	}, nil
+}
+
+// NewPassportElementErrorTranslationFiles creates an error in all the
+// translation files of an element, with their file hashes from the decrypted
+// credentials.
+func NewPassportElementErrorTranslationFiles(credentials *Credentials, elementType, message string) (PassportElementErrorTranslationFiles, error) {
+	value := credentials.Data[elementType]
+	if value == nil || len(value.Translation) == 0 {
+		return PassportElementErrorTranslationFiles{}, fmt.Errorf("no translation credentials for %s", elementType)
+	}
+
+	return PassportElementErrorTranslationFiles{
+		Source:     "translation_files",
+		Type:       elementType,
+		FileHashes: fileHashes(value.Translation),
+		Message:    message,
+	}, nil
+}
+
+// NewPassportElementErrorUnspecified creates an error in an unspecified
+// place of an element.
+func NewPassportElementErrorUnspecified(element EncryptedPassportElement, message string) PassportElementErrorUnspecified {
+	return PassportElementErrorUnspecified{
+		Source:      "unspecified",
+		Type:        element.Type,
+		ElementHash: element.Hash,
+		Message:     message,
+	}
+}
+
+func fileHashes(files []*FileCredentials) []string {
+	hashes := make([]string, len(files))
+	for i, file := range files {
+		hashes[i] = file.FileHash
+	}
+
+	return hashes
 }
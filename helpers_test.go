@@ -1,6 +1,9 @@
 package tgbotapi
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -100,7 +103,7 @@ func TestNewInlineQueryResultPhotoWithThumb(t *testing.T) {
 	if result.Type != "photo" ||
 		result.ID != "id" ||
 		result.URL != "google.com" ||
-		result.ThumbURL != "thumb.com" {
+		result.ThumbnailURL != "thumb.com" {
 		t.Fail()
 	}
 }
@@ -260,3 +263,260 @@ func TestValidateWebAppData(t *testing.T) {
 		}
 	})
 }
+
+func TestNewMessageReply(t *testing.T) {
+	topicMessage := &Message{
+		MessageID:       10,
+		MessageThreadID: 4,
+		IsTopicMessage:  true,
+		Chat:            &Chat{ID: -100, IsForum: true},
+	}
+
+	config := NewMessageReply(topicMessage, "reply")
+	params, err := config.Params()
+	if err != nil ||
+		params["chat_id"] != "-100" ||
+		params["message_thread_id"] != "4" ||
+		params["reply_parameters"] != `{"message_id":10}` {
+		t.Errorf("unexpected topic reply params %v (%v)", params, err)
+	}
+
+	// Replies in non-forum groups have a thread too, which must not be sent.
+	threadMessage := &Message{MessageID: 11, MessageThreadID: 5, Chat: &Chat{ID: -200}}
+
+	params, err = NewMessageReply(threadMessage, "reply").Params()
+	if _, ok := params["message_thread_id"]; err != nil || ok {
+		t.Errorf("unexpected reply params %v (%v)", params, err)
+	}
+}
+
+func TestNewEditForumTopic(t *testing.T) {
+	config := NewEditForumTopic(-100, 4, "")
+
+	params, err := config.Params()
+	if _, ok := params["icon_custom_emoji_id"]; err != nil || ok {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+
+	noIcon := ""
+	config.IconCustomEmojiID = &noIcon
+
+	params, err = config.Params()
+	if icon, ok := params["icon_custom_emoji_id"]; err != nil || !ok || icon != "" {
+		t.Errorf("icon removal not sent: %v (%v)", params, err)
+	}
+}
+
+func TestNewSetMessageReaction(t *testing.T) {
+	params, err := NewSetMessageReaction(-100, 3, NewReactionEmoji("👍")).Params()
+	if err != nil || params["reaction"] != `[{"type":"emoji","emoji":"👍"}]` {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+
+	params, err = NewSetMessageReaction(-100, 3).Params()
+	if _, ok := params["reaction"]; err != nil || ok {
+		t.Errorf("unexpected params without reactions %v (%v)", params, err)
+	}
+}
+
+func TestReplyParametersCompatibility(t *testing.T) {
+	config := NewMessage(-100, "text")
+	config.ReplyToMessageID = 3
+	config.DisableWebPagePreview = true
+
+	params, err := config.Params()
+	if err != nil || params["reply_to_message_id"] != "3" || params["disable_web_page_preview"] != "true" {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+
+	config.ReplyParameters = &ReplyParameters{MessageID: 4, Quote: "te"}
+	config.LinkPreviewOptions = &LinkPreviewOptions{URL: "https://example.com", ShowAboveText: true}
+
+	params, err = config.Params()
+	if err != nil ||
+		params["reply_parameters"] != `{"message_id":4,"quote":"te"}` ||
+		params["link_preview_options"] != `{"url":"https://example.com","show_above_text":true}` {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+	if _, ok := params["reply_to_message_id"]; ok {
+		t.Error("reply_to_message_id sent with reply_parameters")
+	}
+	if _, ok := params["disable_web_page_preview"]; ok {
+		t.Error("disable_web_page_preview sent with link_preview_options")
+	}
+}
+
+func TestNewQuoteReply(t *testing.T) {
+	message := &Message{MessageID: 7, Text: "👍 quoted text", Chat: &Chat{ID: 1}}
+
+	config := NewQuoteReply(message, "quoted", "reply")
+	if config.ReplyParameters.MessageID != 7 || config.ReplyParameters.QuotePosition != 3 {
+		t.Errorf("unexpected reply parameters %+v", config.ReplyParameters)
+	}
+
+	config = NewQuoteReply(message, "👍", "reply")
+	if config.ReplyParameters.Quote != "👍" || config.ReplyParameters.QuotePosition != 0 {
+		t.Errorf("quote at the start not found %+v", config.ReplyParameters)
+	}
+
+	config = NewQuoteReply(&Message{MessageID: 8, Caption: "a photo caption", Chat: &Chat{ID: 1}}, "caption", "reply")
+	if config.ReplyParameters.Quote != "caption" || config.ReplyParameters.QuotePosition != 8 {
+		t.Errorf("quote in caption not found %+v", config.ReplyParameters)
+	}
+
+	config = NewQuoteReply(message, "missing", "reply")
+	if config.ReplyParameters.MessageID != 7 || config.ReplyParameters.Quote != "" {
+		t.Errorf("missing quote should be dropped %+v", config.ReplyParameters)
+	}
+}
+
+func TestNewStarsInvoice(t *testing.T) {
+	config := NewStarsInvoice(-100, "title", "description", "payload", 50)
+
+	params, err := config.Params()
+	if err != nil || params["currency"] != "XTR" || params["prices"] != `[{"label":"title","amount":50}]` {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+	if _, ok := params["provider_token"]; ok {
+		t.Error("provider_token sent for Stars invoice")
+	}
+
+	config.ProviderToken = "token"
+	if _, err := config.Params(); err == nil {
+		t.Error("Stars invoice with a provider token accepted")
+	}
+
+	config.ProviderToken = ""
+	config.Prices = append(config.Prices, LabeledPrice{Label: "tax", Amount: 5})
+	if _, err := config.Params(); err == nil {
+		t.Error("Stars invoice with several prices accepted")
+	}
+}
+
+func TestNewStarsSubscriptionLink(t *testing.T) {
+	config := NewStarsSubscriptionLink("title", "description", "payload", 50)
+
+	params, err := config.Params()
+	if err != nil || params["subscription_period"] != "2592000" {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+
+	config.SubscriptionPeriod = 3600
+	if err := config.Validate(); err == nil {
+		t.Error("unsupported subscription period accepted")
+	}
+
+	config = NewStarsSubscriptionLink("title", "description", "payload", 50)
+	config.Currency = "USD"
+	config.ProviderToken = "token"
+	if err := config.Validate(); err == nil {
+		t.Error("subscription not in Stars accepted")
+	}
+}
+
+func TestNewStickerSetFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"static.png":      []byte("\x89PNG\r\n\x1a\n"),
+		"animated":        {0x1f, 0x8b, 0x08},
+		"video.webm":      {0x1a, 0x45, 0xdf, 0xa3},
+		"renamed.sticker": []byte("RIFF\x00\x00\x00\x00WEBP"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config, err := NewStickerSetFromFiles(1, "set_by_bot", "Set", []string{"👍"},
+		filepath.Join(dir, "static.png"), filepath.Join(dir, "animated"),
+		filepath.Join(dir, "video.webm"), filepath.Join(dir, "renamed.sticker"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formats := []string{StickerFormatStatic, StickerFormatAnimated, StickerFormatVideo, StickerFormatStatic}
+	for i, sticker := range config.Stickers {
+		if sticker.Format != formats[i] {
+			t.Errorf("sticker %d has format %s, expected %s", i, sticker.Format, formats[i])
+		}
+	}
+
+	params, err := config.Params()
+	if err != nil || !strings.HasPrefix(params["stickers"], `[{"sticker":"attach://sticker-0","format":"static","emoji_list":["👍"]}`) {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+	if files := config.files(); len(files) != 4 || files[3].Name != "sticker-3" {
+		t.Errorf("unexpected files %v", files)
+	}
+
+	if _, err := DetectStickerFormat(filepath.Join(dir, "missing.png")); err == nil {
+		t.Error("missing file accepted")
+	}
+}
+
+func TestAddStickerConfig(t *testing.T) {
+	sticker := NewInputSticker(FileID("file-id"), StickerFormatStatic, "👍")
+	config := AddStickerConfig{UserID: 1, Name: "set_by_bot", Sticker: &sticker}
+
+	params, err := config.Params()
+	if err != nil || params["sticker"] != `{"sticker":"file-id","format":"static","emoji_list":["👍"]}` {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+	if _, ok := params["emojis"]; ok {
+		t.Error("legacy emojis sent with sticker")
+	}
+	if files := config.files(); len(files) != 0 {
+		t.Errorf("unexpected files %v", files)
+	}
+}
+
+func TestNewSetPassportDataErrors(t *testing.T) {
+	credentials := &Credentials{Data: SecureData{
+		"passport": {
+			Data:      &DataCredentials{DataHash: "data-hash"},
+			FrontSide: &FileCredentials{FileHash: "front-hash"},
+		},
+		"utility_bill": {
+			Files:       []*FileCredentials{{FileHash: "file-1"}, {FileHash: "file-2"}},
+			Translation: []*FileCredentials{{FileHash: "translation-1"}},
+		},
+	}}
+
+	field, err := NewPassportElementErrorDataField(credentials, "passport", "document_no", "Invalid number")
+	if err != nil || field.DataHash != "data-hash" || field.Source != "data" {
+		t.Errorf("unexpected error %+v (%v)", field, err)
+	}
+
+	frontSide, err := NewPassportElementErrorFrontSide(credentials, "passport", "Blurry")
+	if err != nil || frontSide.FileHash != "front-hash" {
+		t.Errorf("unexpected error %+v (%v)", frontSide, err)
+	}
+
+	files, err := NewPassportElementErrorFiles(credentials, "utility_bill", "Outdated")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewPassportElementErrorSelfie(credentials, "passport", "Missing"); err == nil {
+		t.Error("selfie error without credentials created")
+	}
+	if _, err := NewPassportElementErrorFile(credentials, "utility_bill", 2, "Missing"); err == nil {
+		t.Error("file error out of range created")
+	}
+
+	translation, err := NewPassportElementErrorTranslationFile(credentials, "utility_bill", 0, "Unreadable")
+	if err != nil || translation.FileHash != "translation-1" || translation.Source != "translation_file" {
+		t.Errorf("unexpected error %+v (%v)", translation, err)
+	}
+	if _, err := NewPassportElementErrorTranslationFile(credentials, "passport", 0, "Missing"); err == nil {
+		t.Error("translation file error without credentials created")
+	}
+
+	params, err := NewSetPassportDataErrors(1, field, files).Params()
+	expected := `[{"source":"data","type":"passport","field_name":"document_no","data_hash":"data-hash","message":"Invalid number"},` +
+		`{"source":"files","type":"utility_bill","file_hashes":["file-1","file-2"],"message":"Outdated"}]`
+	if err != nil || params["errors"] != expected || params["user_id"] != "1" {
+		t.Errorf("unexpected params %v (%v)", params, err)
+	}
+}
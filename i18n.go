@@ -0,0 +1,885 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Plural categories, as defined by Unicode CLDR.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralRule returns the plural category used for a count. Like the rules
+// of Unicode CLDR, the rules of the package use the absolute value of n.
+type PluralRule func(n int) string
+
+// PluralRules maps base language codes to their plural rules. Languages
+// without a rule use PluralRuleOneOther.
+var PluralRules = map[string]PluralRule{
+	"ar": PluralRuleArabic,
+	"be": PluralRuleEastSlavic,
+	"cs": PluralRuleCzech,
+	"fa": PluralRuleZeroOne,
+	"fr": PluralRuleZeroOne,
+	"hi": PluralRuleZeroOne,
+	"id": PluralRuleNone,
+	"ja": PluralRuleNone,
+	"ko": PluralRuleNone,
+	"ms": PluralRuleNone,
+	"pl": PluralRulePolish,
+	"pt": PluralRuleZeroOne,
+	"ru": PluralRuleEastSlavic,
+	"sk": PluralRuleCzech,
+	"th": PluralRuleNone,
+	"uk": PluralRuleEastSlavic,
+	"vi": PluralRuleNone,
+	"zh": PluralRuleNone,
+}
+
+// PluralRuleNone is the rule for languages without plural forms.
+func PluralRuleNone(n int) string {
+	return PluralOther
+}
+
+// PluralRuleOneOther is the rule for languages such as English or German.
+func PluralRuleOneOther(n int) string {
+	if absInt(n) == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// PluralRuleZeroOne is the rule for languages such as French, where zero
+// uses the singular form.
+func PluralRuleZeroOne(n int) string {
+	if n = absInt(n); n == 0 || n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// PluralRuleEastSlavic is the rule for Russian, Ukrainian and Belarusian.
+func PluralRuleEastSlavic(n int) string {
+	n = absInt(n)
+
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return PluralOne
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// PluralRulePolish is the rule for Polish.
+func PluralRulePolish(n int) string {
+	n = absInt(n)
+
+	switch {
+	case n == 1:
+		return PluralOne
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// PluralRuleCzech is the rule for Czech and Slovak.
+func PluralRuleCzech(n int) string {
+	n = absInt(n)
+
+	switch {
+	case n == 1:
+		return PluralOne
+	case n >= 2 && n <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// PluralRuleArabic is the rule for Arabic.
+func PluralRuleArabic(n int) string {
+	n = absInt(n)
+
+	switch {
+	case n == 0:
+		return PluralZero
+	case n == 1:
+		return PluralOne
+	case n == 2:
+		return PluralTwo
+	case n%100 >= 3 && n%100 <= 10:
+		return PluralFew
+	case n%100 >= 11:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// PluralRuleFor returns the plural rule for a language code, using the
+// rule of its base language.
+func PluralRuleFor(languageCode string) PluralRule {
+	tag := NormalizeLocale(languageCode)
+	if i := strings.IndexByte(tag, '-'); i != -1 {
+		tag = tag[:i]
+	}
+
+	if rule, ok := PluralRules[tag]; ok {
+		return rule
+	}
+
+	return PluralRuleOneOther
+}
+
+// NormalizeLocale lowercases a language code and uses '-' to separate its
+// parts, so "pt_BR" becomes "pt-br".
+func NormalizeLocale(languageCode string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(languageCode)), "_", "-")
+}
+
+// LocaleFallbacks returns the locales to try for a language code, from the
+// most to the least specific, ending with the default locale. For example,
+// "pt-BR" with the default "en" gives "pt-br", "pt" and "en".
+func LocaleFallbacks(languageCode, defaultLocale string) []string {
+	var locales []string
+
+	tag := NormalizeLocale(languageCode)
+	for tag != "" {
+		locales = append(locales, tag)
+
+		i := strings.LastIndexByte(tag, '-')
+		if i == -1 {
+			break
+		}
+		tag = tag[:i]
+	}
+
+	if defaultLocale = NormalizeLocale(defaultLocale); defaultLocale != "" {
+		for _, locale := range locales {
+			if locale == defaultLocale {
+				return locales
+			}
+		}
+		locales = append(locales, defaultLocale)
+	}
+
+	return locales
+}
+
+// Catalog holds translated messages for several locales.
+//
+// A message is either a single text, or a text per plural category (see
+// PluralOne, PluralOther and so on). Texts may contain fmt verbs, which are
+// filled in by Localizer.
+type Catalog struct {
+	// DefaultLocale is the last locale tried when looking up messages.
+	DefaultLocale string
+
+	mu       sync.RWMutex
+	messages map[string]map[string]map[string]string
+}
+
+// NewCatalog creates an empty catalog.
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		DefaultLocale: NormalizeLocale(defaultLocale),
+		messages:      map[string]map[string]map[string]string{},
+	}
+}
+
+// Add adds a message to a locale.
+func (c *Catalog) Add(locale, key, text string) {
+	c.AddPlural(locale, key, map[string]string{PluralOther: text})
+}
+
+// AddPlural adds a message with a text per plural category to a locale.
+func (c *Catalog) AddPlural(locale, key string, forms map[string]string) {
+	locale = NormalizeLocale(locale)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages[locale] == nil {
+		c.messages[locale] = map[string]map[string]string{}
+	}
+
+	c.messages[locale][key] = forms
+}
+
+// Locales returns the locales with messages, sorted.
+func (c *Catalog) Locales() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	return locales
+}
+
+func (c *Catalog) lookup(locale, key string) (map[string]string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	forms, ok := c.messages[locale][key]
+
+	return forms, ok
+}
+
+// LoadJSON adds the messages of a JSON object to a locale.
+//
+// Values are either strings or objects. Objects only containing plural
+// categories are plural messages, other objects are nested and their keys
+// are joined with dots.
+//
+//	{"greeting": "Hello!", "menu": {"back": "Back"},
+//	 "apples": {"one": "%d apple", "other": "%d apples"}}
+func (c *Catalog) LoadJSON(locale string, data []byte) error {
+	var messages map[string]interface{}
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return err
+	}
+
+	return c.addMessages(locale, "", messages)
+}
+
+// LoadTOML adds the messages of a TOML document to a locale, which are
+// interpreted like LoadJSON does. Only strings, tables and inline tables
+// are supported.
+//
+//	greeting = "Hello!"
+//	apples = { one = "%d apple", other = "%d apples" }
+//
+//	[menu]
+//	back = "Back"
+func (c *Catalog) LoadTOML(locale string, data []byte) error {
+	messages, err := parseTOML(string(data))
+	if err != nil {
+		return err
+	}
+
+	return c.addMessages(locale, "", messages)
+}
+
+// LoadFile loads a .json or .toml file named after its locale, such as
+// "pt-br.json".
+func (c *Catalog) LoadFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return c.load(filepath.Base(filename), data)
+}
+
+// LoadFS loads all .json and .toml files in a directory of a file system,
+// each named after its locale.
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		if err := c.load(entry.Name(), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Catalog) load(name string, data []byte) error {
+	ext := path.Ext(name)
+	locale := strings.TrimSuffix(name, ext)
+
+	var err error
+	switch ext {
+	case ".json":
+		err = c.LoadJSON(locale, data)
+	case ".toml":
+		err = c.LoadTOML(locale, data)
+	default:
+		return fmt.Errorf("unsupported catalog file %s", name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (c *Catalog) addMessages(locale, prefix string, messages map[string]interface{}) error {
+	for key, value := range messages {
+		key = prefix + key
+
+		switch value := value.(type) {
+		case string:
+			c.Add(locale, key, value)
+		case map[string]interface{}:
+			if forms, ok := pluralForms(value); ok {
+				c.AddPlural(locale, key, forms)
+				continue
+			}
+
+			if err := c.addMessages(locale, key+".", value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %s must be a string or an object", key)
+		}
+	}
+
+	return nil
+}
+
+// pluralForms converts an object to plural forms if all its keys are plural
+// categories with string values.
+func pluralForms(value map[string]interface{}) (map[string]string, bool) {
+	if len(value) == 0 {
+		return nil, false
+	}
+
+	forms := make(map[string]string, len(value))
+	for category, text := range value {
+		switch category {
+		case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		default:
+			return nil, false
+		}
+
+		s, ok := text.(string)
+		if !ok {
+			return nil, false
+		}
+		forms[category] = s
+	}
+
+	return forms, true
+}
+
+// Localizer returns a Localizer for a language code.
+func (c *Catalog) Localizer(languageCode string) *Localizer {
+	return &Localizer{
+		Catalog: c,
+		Locale:  NormalizeLocale(languageCode),
+		locales: LocaleFallbacks(languageCode, c.DefaultLocale),
+	}
+}
+
+// ForUser returns a Localizer for the language of a user, which may be nil.
+func (c *Catalog) ForUser(user *User) *Localizer {
+	if user == nil {
+		return c.Localizer("")
+	}
+
+	return c.Localizer(user.LanguageCode)
+}
+
+// ForUpdate returns a Localizer for the language of the user who sent an
+// update.
+func (c *Catalog) ForUpdate(update *Update) *Localizer {
+	return c.ForUser(update.SentFrom())
+}
+
+// SetMyCommands creates configs setting the commands for every language of
+// the catalog, using Localizer.Commands. The default locale is used for
+// users without a dedicated language. Regional locales are skipped, as
+// Telegram only accepts two-letter language codes.
+func (c *Catalog) SetMyCommands(scope *BotCommandScope, commands ...BotCommand) []SetMyCommandsConfig {
+	configs := []SetMyCommandsConfig{{
+		Commands: c.Localizer(c.DefaultLocale).Commands(commands...),
+		Scope:    scope,
+	}}
+
+	for _, locale := range c.Locales() {
+		if len(locale) != 2 || locale == c.DefaultLocale {
+			continue
+		}
+
+		configs = append(configs, SetMyCommandsConfig{
+			Commands:     c.Localizer(locale).Commands(commands...),
+			Scope:        scope,
+			LanguageCode: locale,
+		})
+	}
+
+	return configs
+}
+
+// Localizer translates messages of a Catalog for a locale.
+type Localizer struct {
+	Catalog *Catalog
+	// Locale is the normalized locale that was requested.
+	Locale string
+
+	locales []string
+}
+
+// lookup finds a message in the first locale having it.
+func (l *Localizer) lookup(key string) (map[string]string, string, bool) {
+	for _, locale := range l.locales {
+		if forms, ok := l.Catalog.lookup(locale, key); ok {
+			return forms, locale, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// Has reports if a message exists for the locale or one of its fallbacks.
+func (l *Localizer) Has(key string) bool {
+	_, _, ok := l.lookup(key)
+
+	return ok
+}
+
+// T translates a message, formatting it with args if there are any.
+// Missing messages, and plural messages without an other form, are returned
+// as their key.
+func (l *Localizer) T(key string, args ...interface{}) string {
+	forms, _, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+
+	text, ok := forms[PluralOther]
+	if !ok {
+		return key
+	}
+
+	return formatMessage(text, args)
+}
+
+// N translates a message in the plural form for n, or its other form if it
+// has no form for n. The message is formatted with args if there are any,
+// or with n otherwise. Missing messages are returned as their key.
+func (l *Localizer) N(key string, n int, args ...interface{}) string {
+	forms, locale, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+
+	text, ok := forms[PluralRuleFor(locale)(n)]
+	if !ok {
+		text, ok = forms[PluralOther]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		args = []interface{}{n}
+	}
+
+	return formatMessage(text, args)
+}
+
+func formatMessage(text string, args []interface{}) string {
+	if len(args) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf(text, args...)
+}
+
+// Commands returns the commands with their descriptions translated, using
+// each description as a message key.
+func (l *Localizer) Commands(commands ...BotCommand) []BotCommand {
+	translated := make([]BotCommand, len(commands))
+	for i, command := range commands {
+		translated[i] = BotCommand{Command: command.Command, Description: l.T(command.Description)}
+	}
+
+	return translated
+}
+
+// InlineKeyboard returns a copy of a keyboard with its button texts
+// translated, using each text as a message key.
+func (l *Localizer) InlineKeyboard(markup InlineKeyboardMarkup) InlineKeyboardMarkup {
+	rows := make([][]InlineKeyboardButton, len(markup.InlineKeyboard))
+	for i, row := range markup.InlineKeyboard {
+		rows[i] = make([]InlineKeyboardButton, len(row))
+		for j, button := range row {
+			button.Text = l.T(button.Text)
+			rows[i][j] = button
+		}
+	}
+
+	markup.InlineKeyboard = rows
+
+	return markup
+}
+
+// ReplyKeyboard returns a copy of a keyboard with its button texts and
+// input field placeholder translated, using each text as a message key.
+func (l *Localizer) ReplyKeyboard(markup ReplyKeyboardMarkup) ReplyKeyboardMarkup {
+	rows := make([][]KeyboardButton, len(markup.Keyboard))
+	for i, row := range markup.Keyboard {
+		rows[i] = make([]KeyboardButton, len(row))
+		for j, button := range row {
+			button.Text = l.T(button.Text)
+			rows[i][j] = button
+		}
+	}
+
+	markup.Keyboard = rows
+	if markup.InputFieldPlaceholder != "" {
+		markup.InputFieldPlaceholder = l.T(markup.InputFieldPlaceholder)
+	}
+
+	return markup
+}
+
+// tomlParser parses the subset of TOML used by catalogs.
+type tomlParser struct {
+	data string
+	pos  int
+	line int
+}
+
+func parseTOML(data string) (map[string]interface{}, error) {
+	p := &tomlParser{data: data, line: 1}
+	root := map[string]interface{}{}
+	table := root
+
+	for {
+		p.skipSpace(true)
+		if p.pos >= len(p.data) {
+			return root, nil
+		}
+
+		if p.data[p.pos] == '[' {
+			p.pos++
+			keys, err := p.parseKey()
+			if err != nil {
+				return nil, err
+			}
+
+			p.skipSpace(false)
+			if !p.consume("]") {
+				return nil, p.errorf("expected ']'")
+			}
+
+			if table, err = p.table(root, keys); err != nil {
+				return nil, err
+			}
+		} else if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+
+		p.skipSpace(false)
+		if p.pos < len(p.data) && p.data[p.pos] != '\n' {
+			return nil, p.errorf("expected a new line")
+		}
+	}
+}
+
+func (p *tomlParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("toml: line %d: %s", p.line, fmt.Sprintf(format, a...))
+}
+
+func (p *tomlParser) consume(s string) bool {
+	if strings.HasPrefix(p.data[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+
+	return false
+}
+
+// skipSpace skips whitespace and comments, and new lines if newlines is
+// set.
+func (p *tomlParser) skipSpace(newlines bool) {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case c == '\n' && newlines:
+			p.pos++
+			p.line++
+		case c == '#':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// table returns the table for a dotted key, creating it if needed.
+func (p *tomlParser) table(root map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	table := root
+	for _, key := range keys {
+		switch value := table[key].(type) {
+		case nil:
+			child := map[string]interface{}{}
+			table[key] = child
+			table = child
+		case map[string]interface{}:
+			table = value
+		default:
+			return nil, p.errorf("key %s is already defined", key)
+		}
+	}
+
+	return table, nil
+}
+
+func (p *tomlParser) parseKeyValue(table map[string]interface{}) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace(false)
+	if !p.consume("=") {
+		return p.errorf("expected '=' after key")
+	}
+	p.skipSpace(false)
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	if table, err = p.table(table, keys[:len(keys)-1]); err != nil {
+		return err
+	}
+
+	last := keys[len(keys)-1]
+	if _, ok := table[last]; ok {
+		return p.errorf("key %s is already defined", last)
+	}
+	table[last] = value
+
+	return nil
+}
+
+// parseKey parses a possibly dotted key.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+
+	for {
+		p.skipSpace(false)
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("expected a key")
+		}
+
+		var (
+			key string
+			err error
+		)
+
+		switch p.data[p.pos] {
+		case '"', '\'':
+			key, err = p.parseString()
+		default:
+			start := p.pos
+			for p.pos < len(p.data) && isTOMLBareKeyChar(p.data[p.pos]) {
+				p.pos++
+			}
+			key = p.data[start:p.pos]
+			if key == "" {
+				err = p.errorf("expected a key")
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		p.skipSpace(false)
+		if !p.consume(".") {
+			return keys, nil
+		}
+	}
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return isASCIILetter(c) || isASCIIDigit(c) || c == '_' || c == '-'
+}
+
+func (p *tomlParser) parseValue() (interface{}, error) {
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("expected a value")
+	}
+
+	switch p.data[p.pos] {
+	case '"', '\'':
+		return p.parseString()
+	case '{':
+		p.pos++
+		table := map[string]interface{}{}
+
+		p.skipSpace(false)
+		if p.consume("}") {
+			return table, nil
+		}
+
+		for {
+			if err := p.parseKeyValue(table); err != nil {
+				return nil, err
+			}
+
+			p.skipSpace(false)
+			if p.consume("}") {
+				return table, nil
+			}
+			if !p.consume(",") {
+				return nil, p.errorf("expected ',' or '}' in inline table")
+			}
+		}
+	}
+
+	return nil, p.errorf("unsupported value, only strings and tables are allowed")
+}
+
+func (p *tomlParser) parseString() (string, error) {
+	switch {
+	case p.consume(`"""`):
+		return p.parseStringBody(`"""`, true, true)
+	case p.consume("'''"):
+		return p.parseStringBody("'''", false, true)
+	case p.consume(`"`):
+		return p.parseStringBody(`"`, true, false)
+	case p.consume("'"):
+		return p.parseStringBody("'", false, false)
+	}
+
+	return "", p.errorf("expected a string")
+}
+
+func (p *tomlParser) parseStringBody(delim string, escapes, multiline bool) (string, error) {
+	var b strings.Builder
+
+	// A new line right after the opening delimiter is trimmed.
+	if multiline {
+		p.consume("\r")
+		if p.consume("\n") {
+			p.line++
+		}
+	}
+
+	for p.pos < len(p.data) {
+		if p.consume(delim) {
+			return b.String(), nil
+		}
+
+		c := p.data[p.pos]
+		switch {
+		case c == '\n':
+			if !multiline {
+				return "", p.errorf("unterminated string")
+			}
+			p.line++
+			b.WriteByte(c)
+			p.pos++
+		case c == '\\' && escapes:
+			p.pos++
+			if err := p.parseEscape(&b, multiline); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+func (p *tomlParser) parseEscape(b *strings.Builder, multiline bool) error {
+	if p.pos >= len(p.data) {
+		return p.errorf("unterminated string")
+	}
+
+	c := p.data[p.pos]
+	p.pos++
+
+	switch c {
+	case 'n':
+		b.WriteByte('\n')
+	case 't':
+		b.WriteByte('\t')
+	case 'r':
+		b.WriteByte('\r')
+	case 'b':
+		b.WriteByte('\b')
+	case 'f':
+		b.WriteByte('\f')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.data) {
+			return p.errorf("invalid unicode escape")
+		}
+
+		var code rune
+		if _, err := fmt.Sscanf(p.data[p.pos:p.pos+size], "%x", &code); err != nil {
+			return p.errorf("invalid unicode escape")
+		}
+		b.WriteRune(code)
+		p.pos += size
+	case '\n', ' ', '\t', '\r':
+		// A line ending backslash trims the following whitespace.
+		if !multiline {
+			return p.errorf("invalid escape sequence")
+		}
+		p.pos--
+		for p.pos < len(p.data) && strings.IndexByte(" \t\r\n", p.data[p.pos]) != -1 {
+			if p.data[p.pos] == '\n' {
+				p.line++
+			}
+			p.pos++
+		}
+	default:
+		return p.errorf("invalid escape sequence \\%c", c)
+	}
+
+	return nil
+}
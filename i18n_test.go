@@ -0,0 +1,141 @@
+package tgbotapi
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocaleFallbacks(t *testing.T) {
+	locales := LocaleFallbacks("pt_BR", "en")
+	if len(locales) != 3 || locales[0] != "pt-br" || locales[1] != "pt" || locales[2] != "en" {
+		t.Fatalf("unexpected fallbacks %v", locales)
+	}
+
+	if locales := LocaleFallbacks("en-US", "en"); len(locales) != 2 {
+		t.Fatalf("default locale should not be repeated, got %v", locales)
+	}
+}
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		languageCode string
+		n            int
+		expected     string
+	}{
+		{"en", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"en", -1, PluralOne},
+		{"fr", 0, PluralOne},
+		{"fr", -1, PluralOne},
+		{"ru", 21, PluralOne},
+		{"ru", 22, PluralFew},
+		{"ru", 12, PluralMany},
+		{"uk-UA", 5, PluralMany},
+		{"pl", 22, PluralFew},
+		{"cs", 3, PluralFew},
+		{"cs", -3, PluralFew},
+		{"ru", -22, PluralFew},
+		{"ja", 1, PluralOther},
+		{"ar", 2, PluralTwo},
+	}
+
+	for _, test := range tests {
+		if actual := PluralRuleFor(test.languageCode)(test.n); actual != test.expected {
+			t.Errorf("%s %d: expected %s, got %s", test.languageCode, test.n, test.expected, actual)
+		}
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	c := NewCatalog("en")
+
+	if err := c.LoadJSON("en", []byte(`{
+		"hello": "Hello, %s!",
+		"menu": {"back": "Back"},
+		"apples": {"one": "%d apple", "other": "%d apples"},
+		"cmd": {"start": "Start the bot"}
+	}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{
+		"locales/ru.toml": &fstest.MapFile{Data: []byte(`
+# Russian
+hello = "Привет, %s!"
+apples = { one = "%d яблоко", few = "%d яблока", many = "%d яблок" }
+pears = { one = "%d груша" }
+
+[menu]
+back = 'Назад'
+
+[cmd]
+start = """
+Запустить \
+  бота"""
+`)},
+		"locales/pt-br.json": &fstest.MapFile{Data: []byte(`{"hello": "Olá, %s!"}`)},
+		"locales/README.md":  &fstest.MapFile{Data: []byte("ignored")},
+	}
+	if err := c.LoadFS(fsys, "locales"); err != nil {
+		t.Fatal(err)
+	}
+
+	update := &Update{Message: &Message{From: &User{LanguageCode: "ru"}}}
+	ru := c.ForUpdate(update)
+
+	if s := ru.T("hello", "Ivan"); s != "Привет, Ivan!" {
+		t.Errorf("unexpected translation %q", s)
+	}
+	if s := ru.N("apples", 3); s != "3 яблока" {
+		t.Errorf("unexpected plural %q", s)
+	}
+	if s := ru.T("cmd.start"); s != "Запустить бота" {
+		t.Errorf("unexpected multi-line string %q", s)
+	}
+	if s := ru.N("pears", 5); s != "pears" {
+		t.Errorf("plural messages without the form or other should return the key, got %q", s)
+	}
+	if s := ru.T("pears"); s != "pears" {
+		t.Errorf("plural messages without other should return the key, got %q", s)
+	}
+
+	pt := c.ForUser(&User{LanguageCode: "pt-BR"})
+	if s := pt.T("hello", "Ana"); s != "Olá, Ana!" {
+		t.Errorf("unexpected translation %q", s)
+	}
+	if s := pt.N("apples", 1); s != "1 apple" {
+		t.Errorf("expected fallback to English, got %q", s)
+	}
+	if s := pt.T("missing"); s != "missing" {
+		t.Errorf("missing messages should return the key, got %q", s)
+	}
+
+	keyboard := ru.InlineKeyboard(NewInlineKeyboardMarkup(NewInlineKeyboardRow(NewInlineKeyboardButtonData("menu.back", "back"))))
+	if keyboard.InlineKeyboard[0][0].Text != "Назад" || keyboard.InlineKeyboard[0][0].CallbackData == nil {
+		t.Errorf("unexpected keyboard %+v", keyboard)
+	}
+
+	configs := c.SetMyCommands(nil, BotCommand{Command: "start", Description: "cmd.start"})
+	if len(configs) != 2 {
+		t.Fatalf("expected commands for en and ru, got %d", len(configs))
+	}
+	if configs[0].LanguageCode != "" || configs[0].Commands[0].Description != "Start the bot" {
+		t.Errorf("unexpected default commands %+v", configs[0])
+	}
+	if configs[1].LanguageCode != "ru" || configs[1].Commands[0].Description != "Запустить бота" {
+		t.Errorf("unexpected ru commands %+v", configs[1])
+	}
+}
+
+func TestCatalogTOMLErrors(t *testing.T) {
+	for _, data := range []string{
+		`a = 1`,
+		`a = "unterminated`,
+		"a = \"x\"\na = \"y\"",
+		`[table`,
+	} {
+		if err := NewCatalog("en").LoadTOML("en", []byte(data)); err == nil {
+			t.Errorf("%q: expected an error", data)
+		}
+	}
+}
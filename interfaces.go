@@ -0,0 +1,59 @@
+package tgbotapi
+
+// Requester makes requests to the Bot API. It is implemented by BotAPI,
+// and may be mocked in tests.
+type Requester interface {
+	Request(c Chattable) (*APIResponse, error)
+}
+
+// Sender sends messages in addition to making requests.
+type Sender interface {
+	Requester
+	Send(c Chattable) (Message, error)
+	SendMediaGroup(config MediaGroupConfig) ([]Message, error)
+}
+
+// BotClient covers the methods of BotAPI which call the Bot API, so code
+// may depend on it instead of the concrete type.
+type BotClient interface {
+	Sender
+	GetMe() (User, error)
+	GetFile(config FileConfig) (File, error)
+	GetFileDirectURL(fileID string) (string, error)
+	GetUserProfilePhotos(config UserProfilePhotosConfig) (UserProfilePhotos, error)
+	GetUpdates(config UpdateConfig) ([]Update, error)
+	GetWebhookInfo() (WebhookInfo, error)
+	GetChat(config ChatInfoConfig) (Chat, error)
+	GetChatAdministrators(config ChatAdministratorsConfig) ([]ChatMember, error)
+	GetChatMembersCount(config ChatMemberCountConfig) (int, error)
+	GetChatMember(config GetChatMemberConfig) (ChatMember, error)
+	GetGameHighScores(config GetGameHighScoresConfig) ([]GameHighScore, error)
+	GetInviteLink(config ChatInviteLinkConfig) (string, error)
+	GetStickerSet(config GetStickerSetConfig) (StickerSet, error)
+	StopPoll(config StopPollConfig) (Poll, error)
+	GetMyCommands() ([]BotCommand, error)
+	GetMyCommandsWithConfig(config GetMyCommandsConfig) ([]BotCommand, error)
+	CopyMessage(config CopyMessageConfig) (MessageID, error)
+	AnswerWebAppQuery(config AnswerWebAppQueryConfig) (SentWebAppMessage, error)
+	GetMyDefaultAdministratorRights(config GetMyDefaultAdministratorRightsConfig) (ChatAdministratorRights, error)
+	CopyMessages(config CopyMessagesConfig) ([]MessageID, error)
+	ForwardMessages(config ForwardMessagesConfig) ([]MessageID, error)
+	GetUserChatBoosts(config GetUserChatBoostsConfig) (UserChatBoosts, error)
+	GetChatMemberCount(config GetChatMemberCountConfig) (int, error)
+	CreateChatSubscriptionInviteLink(config CreateChatSubscriptionInviteLinkConfig) (ChatInviteLink, error)
+	EditChatSubscriptionInviteLink(config EditChatSubscriptionInviteLinkConfig) (ChatInviteLink, error)
+	GetAvailableGifts(config GetAvailableGiftsConfig) (Gifts, error)
+	SavePreparedInlineMessage(config SavePreparedInlineMessageConfig) (PreparedInlineMessage, error)
+	GetMyName(config GetMyNameConfig) (BotName, error)
+	GetMyDescription(config GetMyDescriptionConfig) (BotDescription, error)
+	GetMyShortDescription(config GetMyShortDescriptionConfig) (BotShortDescription, error)
+	GetForumTopicIconStickers(config GetForumTopicIconStickersConfig) ([]Sticker, error)
+	CreateForumTopic(config CreateForumTopicConfig) (ForumTopic, error)
+	GetBusinessConnection(config GetBusinessConnectionConfig) (BusinessConnection, error)
+	GetStarTransactions(config GetStarTransactionsConfig) (StarTransactions, error)
+	GetAllStarTransactions() ([]StarTransaction, error)
+	CreateInvoiceLink(config InvoiceLinkConfig) (string, error)
+	GetCustomEmojiStickers(config GetCustomEmojiStickersConfig) ([]Sticker, error)
+}
+
+var _ BotClient = (*BotAPI)(nil)
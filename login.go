@@ -0,0 +1,163 @@
+package tgbotapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Login Widget errors
+var (
+	ErrLoginDataHash    = errors.New("login data hash is not valid")
+	ErrLoginDataExpired = errors.New("login data is expired")
+)
+
+// LoginWidgetUser is the user authorized with the Telegram Login Widget.
+//
+// https://core.telegram.org/widgets/login#receiving-authorization-data
+type LoginWidgetUser struct {
+	// ID is a unique identifier of the user.
+	ID int64
+	// FirstName of the user.
+	FirstName string
+	// LastName of the user.
+	//
+	// optional
+	LastName string
+	// UserName of the user.
+	//
+	// optional
+	UserName string
+	// PhotoURL is a link to the profile photo of the user.
+	//
+	// optional
+	PhotoURL string
+	// AuthDate is the unix time of the authorization.
+	AuthDate int
+	// Hash is the signature of all the other fields, made with the bot
+	// token.
+	Hash string
+}
+
+// User returns the LoginWidgetUser as a User.
+func (u LoginWidgetUser) User() User {
+	return User{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		UserName:  u.UserName,
+	}
+}
+
+// AuthTime returns AuthDate as a time.
+func (u LoginWidgetUser) AuthTime() time.Time {
+	return time.Unix(int64(u.AuthDate), 0)
+}
+
+// loginWidgetFields are the fields of the authorization data signed by
+// Telegram.
+var loginWidgetFields = []string{"id", "first_name", "last_name", "username", "photo_url", "auth_date"}
+
+// loginWidgetCheckString joins the sorted fields of authorization data
+// signed by Telegram with newlines. Other fields, such as params the
+// data-auth-url already had, are ignored.
+func loginWidgetCheckString(values url.Values) string {
+	signed := make(url.Values, len(loginWidgetFields))
+	for _, field := range loginWidgetFields {
+		if value, ok := values[field]; ok {
+			signed[field] = value
+		}
+	}
+
+	return webAppDataCheckString(signed)
+}
+
+// ValidateLoginWidgetData validates the authorization data of the Login
+// Widget, such as the query of its redirect, with the bot token and parses
+// it. Only the fields of LoginWidgetUser are checked, so the query may have
+// other params. Data older than maxAge is rejected with ErrLoginDataExpired,
+// unless maxAge is 0.
+//
+// https://core.telegram.org/widgets/login#checking-authorization
+func ValidateLoginWidgetData(token string, values url.Values, maxAge time.Duration) (LoginWidgetUser, error) {
+	expected, err := hex.DecodeString(values.Get("hash"))
+	if err != nil || len(expected) == 0 {
+		return LoginWidgetUser{}, ErrLoginDataHash
+	}
+
+	secret := sha256.Sum256([]byte(token))
+
+	hash := hmac.New(sha256.New, secret[:])
+	hash.Write([]byte(loginWidgetCheckString(values)))
+
+	if !hmac.Equal(hash.Sum(nil), expected) {
+		return LoginWidgetUser{}, ErrLoginDataHash
+	}
+
+	user := LoginWidgetUser{
+		FirstName: values.Get("first_name"),
+		LastName:  values.Get("last_name"),
+		UserName:  values.Get("username"),
+		PhotoURL:  values.Get("photo_url"),
+		Hash:      values.Get("hash"),
+	}
+
+	if user.ID, err = strconv.ParseInt(values.Get("id"), 10, 64); err != nil {
+		return user, fmt.Errorf("error parsing id %w", err)
+	}
+	if user.AuthDate, err = strconv.Atoi(values.Get("auth_date")); err != nil {
+		return user, fmt.Errorf("error parsing auth_date %w", err)
+	}
+
+	if maxAge > 0 && time.Since(user.AuthTime()) > maxAge {
+		return user, ErrLoginDataExpired
+	}
+
+	return user, nil
+}
+
+// ValidateLoginWidgetJSON is like ValidateLoginWidgetData, for the object
+// passed to the data-onauth callback of the Login Widget, decoded from JSON.
+func ValidateLoginWidgetJSON(token string, data map[string]interface{}, maxAge time.Duration) (LoginWidgetUser, error) {
+	values := make(url.Values, len(data))
+
+	for key, value := range data {
+		switch value := value.(type) {
+		case string:
+			values.Set(key, value)
+		case float64:
+			values.Set(key, strconv.FormatFloat(value, 'f', -1, 64))
+		case json.Number:
+			values.Set(key, value.String())
+		case bool:
+			values.Set(key, strconv.FormatBool(value))
+		default:
+			return LoginWidgetUser{}, fmt.Errorf("login data %s must be a string, a number or a boolean", key)
+		}
+	}
+
+	return ValidateLoginWidgetData(token, values, maxAge)
+}
+
+// LoginWidgetHandler handles the redirect of the Login Widget to its
+// data-auth-url. It validates the query with ValidateLoginWidgetData and
+// calls onLogin with the user, which typically starts a session and
+// redirects. Requests without valid data are rejected with 401 Unauthorized.
+func LoginWidgetHandler(token string, maxAge time.Duration, onLogin func(w http.ResponseWriter, r *http.Request, user LoginWidgetUser)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := ValidateLoginWidgetData(token, r.URL.Query(), maxAge)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		onLogin(w, r, user)
+	})
+}
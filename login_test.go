@@ -0,0 +1,118 @@
+package tgbotapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const loginToken = "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
+
+// signLoginWidgetData adds the hash made with the bot token to login data.
+func signLoginWidgetData(token string, values url.Values) url.Values {
+	secret := sha256.Sum256([]byte(token))
+
+	hash := hmac.New(sha256.New, secret[:])
+	hash.Write([]byte(loginWidgetCheckString(values)))
+	values.Set("hash", hex.EncodeToString(hash.Sum(nil)))
+
+	return values
+}
+
+func loginWidgetValues(authDate time.Time) url.Values {
+	return signLoginWidgetData(loginToken, url.Values{
+		"id":         {"160657077"},
+		"first_name": {"Ada"},
+		"username":   {"ada"},
+		"photo_url":  {"https://t.me/i/userpic/320/ada.jpg"},
+		"auth_date":  {strconv.FormatInt(authDate.Unix(), 10)},
+	})
+}
+
+func TestValidateLoginWidgetData(t *testing.T) {
+	// The hash was computed independently with Python's hashlib and hmac.
+	known := url.Values{
+		"id":         {"160657077"},
+		"first_name": {"Ada"},
+		"last_name":  {"Lovelace"},
+		"username":   {"ada"},
+		"photo_url":  {"https://t.me/i/userpic/320/ada.jpg"},
+		"auth_date":  {"1700000000"},
+		"hash":       {"56714f28128ed3b50af5945bcae1195114dd39a868390609382cd85e08d0af7a"},
+	}
+	user, err := ValidateLoginWidgetData(loginToken, known, 0)
+	if err != nil || user.ID != 160657077 || user.LastName != "Lovelace" || user.AuthDate != 1700000000 {
+		t.Errorf("unexpected user %+v (%v)", user, err)
+	}
+
+	known.Set("next", "/dashboard")
+	if _, err := ValidateLoginWidgetData(loginToken, known, 0); err != nil {
+		t.Errorf("params not signed by Telegram should be ignored, got %v", err)
+	}
+
+	user, err = ValidateLoginWidgetData(loginToken, loginWidgetValues(time.Now()), time.Hour)
+	if err != nil || user.ID != 160657077 || user.User().UserName != "ada" || user.PhotoURL == "" {
+		t.Errorf("unexpected user %+v (%v)", user, err)
+	}
+
+	if _, err := ValidateLoginWidgetData(loginToken, loginWidgetValues(time.Now().Add(-2*time.Hour)), time.Hour); err != ErrLoginDataExpired {
+		t.Errorf("expected expired data, got %v", err)
+	}
+
+	values := loginWidgetValues(time.Now())
+	values.Set("first_name", "Eve")
+	if _, err := ValidateLoginWidgetData(loginToken, values, 0); err != ErrLoginDataHash {
+		t.Errorf("expected invalid hash, got %v", err)
+	}
+}
+
+func TestValidateLoginWidgetJSON(t *testing.T) {
+	values := loginWidgetValues(time.Now())
+
+	object := map[string]interface{}{}
+	for key := range values {
+		object[key] = values.Get(key)
+	}
+	object["id"], _ = strconv.Atoi(values.Get("id"))
+	object["auth_date"], _ = strconv.Atoi(values.Get("auth_date"))
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := ValidateLoginWidgetJSON(loginToken, decoded, time.Hour)
+	if err != nil || user.ID != 160657077 {
+		t.Errorf("unexpected user %+v (%v)", user, err)
+	}
+}
+
+func TestLoginWidgetHandler(t *testing.T) {
+	handler := LoginWidgetHandler(loginToken, time.Hour, func(w http.ResponseWriter, r *http.Request, user LoginWidgetUser) {
+		http.Redirect(w, r, "/dashboard", http.StatusFound)
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/login?"+loginWidgetValues(time.Now()).Encode(), nil))
+	if w.Code != http.StatusFound {
+		t.Errorf("got status %d for valid data", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/login?id=1", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("got status %d for invalid data", w.Code)
+	}
+}
@@ -0,0 +1,806 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MarkupError describes formatted text which Telegram would refuse to parse.
+type MarkupError struct {
+	// Offset is the byte offset in the text where the problem was found.
+	Offset int
+	// Message describes the problem.
+	Message string
+}
+
+// Error message string.
+func (e *MarkupError) Error() string {
+	return fmt.Sprintf("can't parse entities: %s at byte offset %d", e.Message, e.Offset)
+}
+
+func newMarkupError(offset int, format string, a ...interface{}) *MarkupError {
+	return &MarkupError{Offset: offset, Message: fmt.Sprintf(format, a...)}
+}
+
+// ParseMarkup parses text formatted for a parse mode (ModeHTML, ModeMarkdown
+// or ModeMarkdownV2) the same way Telegram does. It returns the plain text
+// and its entities, which may be sent without a parse mode.
+//
+// Invalid markup results in a *MarkupError. An empty parse mode returns the
+// text unchanged.
+func ParseMarkup(parseMode, text string) (string, []MessageEntity, error) {
+	var (
+		plain    string
+		entities []MessageEntity
+		err      error
+	)
+
+	switch parseMode {
+	case "":
+		return text, nil, nil
+	case ModeHTML:
+		plain, entities, err = parseHTML(text)
+	case ModeMarkdown:
+		plain, entities, err = parseMarkdown(text)
+	case ModeMarkdownV2:
+		plain, entities, err = parseMarkdownV2(text)
+	default:
+		return "", nil, fmt.Errorf("unsupported parse mode %q", parseMode)
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		if entities[i].Offset != entities[j].Offset {
+			return entities[i].Offset < entities[j].Offset
+		}
+		return entities[i].Length > entities[j].Length
+	})
+
+	return plain, entities, nil
+}
+
+// ValidateMarkup checks if text formatted for a parse mode can be parsed by
+// Telegram, returning a *MarkupError describing the first problem found.
+func ValidateMarkup(parseMode, text string) error {
+	_, _, err := ParseMarkup(parseMode, text)
+
+	return err
+}
+
+// markupParamPairs are the parse mode params and the params they apply to.
+var markupParamPairs = [][2]string{
+	{"parse_mode", "text"},
+	{"parse_mode", "caption"},
+	{"question_parse_mode", "question"},
+	{"explanation_parse_mode", "explanation"},
+}
+
+// validateParamsMarkup validates the formatted texts of request params,
+// including the captions of the media in the media param, which holds a
+// single InputMedia or the items of a media group.
+func validateParamsMarkup(params Params) error {
+	for _, pair := range markupParamPairs {
+		parseMode, ok := params[pair[0]]
+		if !ok {
+			continue
+		}
+
+		if text, ok := params[pair[1]]; ok {
+			if err := ValidateMarkup(parseMode, text); err != nil {
+				return err
+			}
+		}
+	}
+
+	media, ok := params["media"]
+	if !ok {
+		return nil
+	}
+
+	var items []struct {
+		Caption   string `json:"caption"`
+		ParseMode string `json:"parse_mode"`
+	}
+	if strings.HasPrefix(media, "{") {
+		media = "[" + media + "]"
+	}
+	if err := json.Unmarshal([]byte(media), &items); err != nil {
+		// Media that isn't JSON is left for Telegram to reject.
+		return nil
+	}
+
+	for _, item := range items {
+		if err := ValidateMarkup(item.ParseMode, item.Caption); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// markupWriter collects the plain text and entities produced by a parser.
+type markupWriter struct {
+	text     strings.Builder
+	length   int
+	entities []MessageEntity
+}
+
+func (w *markupWriter) writeString(s string) {
+	w.text.WriteString(s)
+	w.length += UTF16Len(s)
+}
+
+func (w *markupWriter) writeRune(r rune) {
+	w.text.WriteRune(r)
+	if r >= 0x10000 {
+		w.length += 2
+	} else {
+		w.length++
+	}
+}
+
+// addEntity adds an entity starting at start and ending at the current
+// position, unless it would be empty.
+func (w *markupWriter) addEntity(entity MessageEntity, start int) {
+	entity.Offset = start
+	entity.Length = w.length - start
+
+	if entity.Length > 0 {
+		w.entities = append(w.entities, entity)
+	}
+}
+
+// linkEntity creates the entity for a link, which is a text mention for
+// tg://user links. It returns false if there is no usable URL.
+func linkEntity(url string) (MessageEntity, bool) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return MessageEntity{}, false
+	}
+
+	const userPrefix = "tg://user?id="
+	if strings.HasPrefix(url, userPrefix) {
+		if id, err := strconv.ParseInt(url[len(userPrefix):], 10, 64); err == nil {
+			return MessageEntity{Type: EntityTextMention, User: &User{ID: id}}, true
+		}
+	}
+
+	return MessageEntity{Type: EntityTextLink, URL: url}, true
+}
+
+// htmlTagEntities maps supported HTML tags to entity types.
+var htmlTagEntities = map[string]string{
+	"b":          EntityBold,
+	"strong":     EntityBold,
+	"i":          EntityItalic,
+	"em":         EntityItalic,
+	"u":          EntityUnderline,
+	"ins":        EntityUnderline,
+	"s":          EntityStrikethrough,
+	"strike":     EntityStrikethrough,
+	"del":        EntityStrikethrough,
+	"tg-spoiler": EntitySpoiler,
+	"span":       EntitySpoiler,
+	"a":          EntityTextLink,
+	"code":       EntityCode,
+	"pre":        EntityPre,
+	"blockquote": EntityBlockquote,
+	"tg-emoji":   EntityCustomEmoji,
+}
+
+type htmlTag struct {
+	name   string
+	offset int
+	start  int
+	entity MessageEntity
+	// valid is false for tags which do not produce an entity, such as
+	// links without a URL.
+	valid bool
+	// language is the class of a code tag.
+	language string
+}
+
+func parseHTML(text string) (string, []MessageEntity, error) {
+	w := &markupWriter{}
+	stack := []*htmlTag{}
+
+	for i := 0; i < len(text); {
+		switch text[i] {
+		case '&':
+			decoded, size := decodeHTMLEntity(text[i:])
+			w.writeString(decoded)
+			i += size
+		case '<':
+			if i+1 < len(text) && text[i+1] == '/' {
+				end := strings.IndexByte(text[i:], '>')
+				if end == -1 {
+					return "", nil, newMarkupError(i, "Unclosed end tag")
+				}
+
+				name := strings.ToLower(strings.TrimSpace(text[i+2 : i+end]))
+				if len(stack) == 0 {
+					return "", nil, newMarkupError(i, "Unexpected end tag")
+				}
+
+				tag := stack[len(stack)-1]
+				if tag.name != name {
+					return "", nil, newMarkupError(i, "Unmatched end tag, expected \"</%s>\", found \"</%s>\"", tag.name, name)
+				}
+				stack = stack[:len(stack)-1]
+
+				closeHTMLTag(w, tag, stack)
+				i += end + 1
+				continue
+			}
+
+			tag, size, err := parseHTMLStartTag(text, i)
+			if err != nil {
+				return "", nil, err
+			}
+
+			tag.start = w.length
+			stack = append(stack, tag)
+			i += size
+		default:
+			r, size := utf8.DecodeRuneInString(text[i:])
+			w.writeRune(r)
+			i += size
+		}
+	}
+
+	if len(stack) > 0 {
+		tag := stack[len(stack)-1]
+		return "", nil, newMarkupError(tag.offset, "Can't find end tag corresponding to start tag \"%s\"", tag.name)
+	}
+
+	return w.text.String(), w.entities, nil
+}
+
+func closeHTMLTag(w *markupWriter, tag *htmlTag, stack []*htmlTag) {
+	if !tag.valid {
+		return
+	}
+
+	// <pre><code class="language-x"> sets the language of the pre block
+	// instead of creating a code entity.
+	if tag.name == "code" && len(stack) > 0 {
+		if parent := stack[len(stack)-1]; parent.name == "pre" && parent.start == tag.start {
+			if strings.HasPrefix(tag.language, "language-") {
+				parent.entity.Language = strings.TrimPrefix(tag.language, "language-")
+			}
+			return
+		}
+	}
+
+	w.addEntity(tag.entity, tag.start)
+}
+
+// parseHTMLStartTag parses a start tag at offset, returning the tag and the
+// number of bytes it takes.
+func parseHTMLStartTag(text string, offset int) (*htmlTag, int, error) {
+	i := offset + 1
+
+	nameStart := i
+	for i < len(text) && (isASCIILetter(text[i]) || isASCIIDigit(text[i]) || text[i] == '-') {
+		i++
+	}
+	name := strings.ToLower(text[nameStart:i])
+
+	entityType, ok := htmlTagEntities[name]
+	if !ok {
+		return nil, 0, newMarkupError(offset, "Unsupported start tag \"%s\"", name)
+	}
+
+	attributes := map[string]string{}
+	for {
+		for i < len(text) && isHTMLSpace(text[i]) {
+			i++
+		}
+
+		if i >= len(text) {
+			return nil, 0, newMarkupError(offset, "Unclosed start tag")
+		}
+
+		if text[i] == '>' {
+			i++
+			break
+		}
+
+		attrStart := i
+		for i < len(text) && text[i] != '=' && text[i] != '>' && !isHTMLSpace(text[i]) {
+			i++
+		}
+		attr := strings.ToLower(text[attrStart:i])
+		if attr == "" {
+			return nil, 0, newMarkupError(i, "Empty attribute name in the tag \"%s\"", name)
+		}
+
+		for i < len(text) && isHTMLSpace(text[i]) {
+			i++
+		}
+		if i >= len(text) || text[i] != '=' {
+			attributes[attr] = ""
+			continue
+		}
+		i++
+
+		for i < len(text) && isHTMLSpace(text[i]) {
+			i++
+		}
+		if i >= len(text) {
+			return nil, 0, newMarkupError(offset, "Unclosed start tag")
+		}
+
+		var raw string
+		if quote := text[i]; quote == '"' || quote == '\'' {
+			end := strings.IndexByte(text[i+1:], quote)
+			if end == -1 {
+				return nil, 0, newMarkupError(i, "Unclosed end of attribute value")
+			}
+			raw = text[i+1 : i+1+end]
+			i += end + 2
+		} else {
+			valueStart := i
+			for i < len(text) && text[i] != '>' && !isHTMLSpace(text[i]) {
+				i++
+			}
+			raw = text[valueStart:i]
+		}
+
+		attributes[attr] = decodeHTMLEntities(raw)
+	}
+
+	tag := &htmlTag{
+		name:   name,
+		offset: offset,
+		entity: MessageEntity{Type: entityType},
+		valid:  true,
+	}
+
+	switch name {
+	case "span":
+		if attributes["class"] != "tg-spoiler" {
+			return nil, 0, newMarkupError(offset, "Tag \"span\" must have class \"tg-spoiler\"")
+		}
+	case "a":
+		tag.entity, tag.valid = linkEntity(attributes["href"])
+	case "code":
+		tag.language = attributes["class"]
+	case "tg-emoji":
+		id := attributes["emoji-id"]
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, 0, newMarkupError(offset, "Invalid custom emoji identifier specified")
+		}
+		tag.entity.CustomEmojiID = id
+	}
+
+	return tag, i - offset, nil
+}
+
+// decodeHTMLEntity decodes the HTML entity at the start of text, returning
+// the decoded text and the number of bytes used. Unknown entities are kept
+// as written.
+func decodeHTMLEntity(text string) (string, int) {
+	end := strings.IndexByte(text, ';')
+	if end == -1 || end > 10 {
+		return "&", 1
+	}
+
+	name := text[1:end]
+	switch name {
+	case "lt":
+		return "<", end + 1
+	case "gt":
+		return ">", end + 1
+	case "amp":
+		return "&", end + 1
+	case "quot":
+		return `"`, end + 1
+	}
+
+	if strings.HasPrefix(name, "#") {
+		var (
+			code int64
+			err  error
+		)
+
+		if strings.HasPrefix(name, "#x") || strings.HasPrefix(name, "#X") {
+			code, err = strconv.ParseInt(name[2:], 16, 32)
+		} else {
+			code, err = strconv.ParseInt(name[1:], 10, 32)
+		}
+
+		if err == nil && code > 0 && code <= utf8.MaxRune && utf8.ValidRune(rune(code)) {
+			return string(rune(code)), end + 1
+		}
+	}
+
+	return "&", 1
+}
+
+func decodeHTMLEntities(text string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		if text[i] == '&' {
+			decoded, size := decodeHTMLEntity(text[i:])
+			b.WriteString(decoded)
+			i += size
+			continue
+		}
+
+		b.WriteByte(text[i])
+		i++
+	}
+
+	return b.String()
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parseMarkdown parses the legacy Markdown parse mode, which has no nested
+// entities and only allows escaping outside of entities.
+func parseMarkdown(text string) (string, []MessageEntity, error) {
+	w := &markupWriter{}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		if c == '\\' && i+1 < len(text) && strings.IndexByte("_*`[", text[i+1]) != -1 {
+			w.writeString(text[i+1 : i+2])
+			i += 2
+			continue
+		}
+
+		if strings.IndexByte("_*`[", c) == -1 {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			w.writeRune(r)
+			i += size
+			continue
+		}
+
+		begin := i
+		entity := MessageEntity{}
+		end := string(c)
+
+		switch c {
+		case '_':
+			entity.Type = EntityItalic
+		case '*':
+			entity.Type = EntityBold
+		case '[':
+			entity.Type = EntityTextLink
+			end = "]"
+		case '`':
+			entity.Type = EntityCode
+			if strings.HasPrefix(text[i:], "```") {
+				entity.Type = EntityPre
+				end = "```"
+			}
+		}
+		i += len(end)
+
+		if entity.Type == EntityPre {
+			language, size := markdownPreLanguage(text[i:])
+			entity.Language = language
+			i += size
+		}
+
+		endIndex := strings.Index(text[i:], end)
+		if endIndex == -1 {
+			return "", nil, newMarkupError(begin, "Can't find end of the entity starting")
+		}
+
+		start := w.length
+		w.writeString(text[i : i+endIndex])
+		i += endIndex + len(end)
+
+		if entity.Type == EntityTextLink {
+			var url string
+			if i < len(text) && text[i] == '(' {
+				urlEnd := strings.IndexByte(text[i:], ')')
+				if urlEnd == -1 {
+					return "", nil, newMarkupError(begin, "Can't find end of a URL")
+				}
+				url = text[i+1 : i+urlEnd]
+				i += urlEnd + 1
+			}
+
+			var ok bool
+			if entity, ok = linkEntity(url); !ok {
+				continue
+			}
+		}
+
+		w.addEntity(entity, start)
+	}
+
+	return w.text.String(), w.entities, nil
+}
+
+// markdownPreLanguage returns the language of a pre block when it is given
+// on the first line, and the number of bytes taken by the line.
+func markdownPreLanguage(text string) (string, int) {
+	newline := strings.IndexByte(text, '\n')
+	if newline == -1 {
+		return "", 0
+	}
+
+	closing := strings.Index(text, "```")
+	if closing != -1 && closing < newline {
+		return "", 0
+	}
+
+	language := strings.TrimSpace(text[:newline])
+	if strings.ContainsAny(language, " \t") {
+		return "", 0
+	}
+
+	return language, newline + 1
+}
+
+// markdownV2Reserved are the characters which must be escaped in MarkdownV2
+// text outside of code.
+const markdownV2Reserved = "_*[]()~`>#+-=|{}.!"
+
+// markdownV2EntityNames are the names used by Telegram for entities in
+// parsing errors.
+var markdownV2EntityNames = map[string]string{
+	EntityBold:          "Bold",
+	EntityItalic:        "Italic",
+	EntityUnderline:     "Underline",
+	EntityStrikethrough: "Strikethrough",
+	EntitySpoiler:       "Spoiler",
+	EntityTextLink:      "TextUrl",
+	EntityCode:          "Code",
+	EntityPre:           "Pre",
+	EntityCustomEmoji:   "CustomEmoji",
+}
+
+type markdownV2Entity struct {
+	entity MessageEntity
+	offset int
+	start  int
+}
+
+func parseMarkdownV2(text string) (string, []MessageEntity, error) {
+	w := &markupWriter{}
+	stack := []markdownV2Entity{}
+	quote := -1
+	lineStart := true
+
+	next := func(i int) byte {
+		if i+1 < len(text) {
+			return text[i+1]
+		}
+		return 0
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		var top *markdownV2Entity
+		if len(stack) > 0 {
+			top = &stack[len(stack)-1]
+		}
+		inCode := top != nil && (top.entity.Type == EntityCode || top.entity.Type == EntityPre)
+
+		// Carriage returns are removed by Telegram, but still separate
+		// markers, as in "_italic_\r__underline__".
+		if c == '\r' {
+			continue
+		}
+
+		if lineStart && !inCode && c == '>' {
+			if quote == -1 {
+				quote = w.length
+			}
+			lineStart = false
+			continue
+		}
+		lineStart = false
+
+		if c == '\\' && next(i) > 0 && next(i) <= 126 {
+			i++
+			w.writeString(text[i : i+1])
+			continue
+		}
+
+		if c == '\n' {
+			if quote != -1 && !inCode && next(i) != '>' {
+				w.addEntity(MessageEntity{Type: EntityBlockquote}, quote)
+				quote = -1
+			}
+
+			w.writeString("\n")
+			lineStart = true
+			continue
+		}
+
+		if inCode {
+			switch {
+			case top.entity.Type == EntityCode && c == '`':
+				w.addEntity(top.entity, top.start)
+				stack = stack[:len(stack)-1]
+			case top.entity.Type == EntityPre && strings.HasPrefix(text[i:], "```"):
+				w.addEntity(top.entity, top.start)
+				stack = stack[:len(stack)-1]
+				i += 2
+			default:
+				r, size := utf8.DecodeRuneInString(text[i:])
+				w.writeRune(r)
+				i += size - 1
+			}
+			continue
+		}
+
+		if strings.IndexByte(markdownV2Reserved, c) == -1 {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			w.writeRune(r)
+			i += size - 1
+			continue
+		}
+
+		if top != nil && isMarkdownV2End(top.entity.Type, c, next(i)) {
+			stack = stack[:len(stack)-1]
+			entity := top.entity
+
+			switch entity.Type {
+			case EntityUnderline, EntitySpoiler:
+				i++
+			case EntityTextLink:
+				var url string
+				if next(i) == '(' {
+					end, value, err := parseMarkdownV2URL(text, i+1)
+					if err != nil {
+						return "", nil, err
+					}
+					url, i = value, end
+				} else {
+					text := w.text.String()
+					url = text[utf16Offsets(text)[top.start]:]
+				}
+
+				var ok bool
+				if entity, ok = linkEntity(url); !ok {
+					continue
+				}
+			case EntityCustomEmoji:
+				if next(i) != '(' {
+					return "", nil, newMarkupError(i, "Custom emoji entity must contain a tg://emoji URL")
+				}
+
+				end, url, err := parseMarkdownV2URL(text, i+1)
+				if err != nil {
+					return "", nil, err
+				}
+				i = end
+
+				const emojiPrefix = "tg://emoji?id="
+				id := strings.TrimPrefix(url, emojiPrefix)
+				if _, err := strconv.ParseInt(id, 10, 64); err != nil || !strings.HasPrefix(url, emojiPrefix) {
+					return "", nil, newMarkupError(top.offset, "Invalid custom emoji identifier specified")
+				}
+				entity.CustomEmojiID = id
+			}
+
+			w.addEntity(entity, top.start)
+			continue
+		}
+
+		begin := i
+		entity := MessageEntity{}
+
+		switch c {
+		case '_':
+			entity.Type = EntityItalic
+			if next(i) == '_' {
+				entity.Type = EntityUnderline
+				i++
+			}
+		case '*':
+			entity.Type = EntityBold
+		case '~':
+			entity.Type = EntityStrikethrough
+		case '|':
+			if next(i) != '|' {
+				return "", nil, newMarkupError(i, "Character '|' is reserved and must be escaped with the preceding '\\'")
+			}
+			entity.Type = EntitySpoiler
+			i++
+		case '[':
+			entity.Type = EntityTextLink
+		case '!':
+			if next(i) != '[' {
+				return "", nil, newMarkupError(i, "Character '!' is reserved and must be escaped with the preceding '\\'")
+			}
+			entity.Type = EntityCustomEmoji
+			i++
+		case '`':
+			entity.Type = EntityCode
+			if strings.HasPrefix(text[i:], "```") {
+				entity.Type = EntityPre
+				i += 2
+
+				language, size := markdownPreLanguage(text[i+1:])
+				entity.Language = language
+				i += size
+			}
+		default:
+			return "", nil, newMarkupError(i, "Character '%c' is reserved and must be escaped with the preceding '\\'", c)
+		}
+
+		stack = append(stack, markdownV2Entity{entity: entity, offset: begin, start: w.length})
+	}
+
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return "", nil, newMarkupError(top.offset, "Can't find end of %s entity", markdownV2EntityNames[top.entity.Type])
+	}
+
+	if quote != -1 {
+		w.addEntity(MessageEntity{Type: EntityBlockquote}, quote)
+	}
+
+	return w.text.String(), w.entities, nil
+}
+
+// isMarkdownV2End reports if the character c, followed by next, ends an
+// entity of the given type.
+func isMarkdownV2End(entityType string, c, next byte) bool {
+	switch entityType {
+	case EntityBold:
+		return c == '*'
+	case EntityItalic:
+		return c == '_' && next != '_'
+	case EntityUnderline:
+		return c == '_' && next == '_'
+	case EntityStrikethrough:
+		return c == '~'
+	case EntitySpoiler:
+		return c == '|' && next == '|'
+	case EntityTextLink, EntityCustomEmoji:
+		return c == ']'
+	}
+
+	return false
+}
+
+// parseMarkdownV2URL parses a URL in parentheses starting at offset. It
+// returns the offset of the closing parenthesis and the unescaped URL.
+func parseMarkdownV2URL(text string, offset int) (int, string, error) {
+	var b strings.Builder
+
+	for i := offset + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if i+1 < len(text) {
+				i++
+				b.WriteByte(text[i])
+			}
+		case ')':
+			return i, b.String(), nil
+		default:
+			b.WriteByte(text[i])
+		}
+	}
+
+	return 0, "", newMarkupError(offset, "Can't find end of a URL")
+}
@@ -0,0 +1,176 @@
+package tgbotapi
+
+import (
+	"testing"
+)
+
+func TestParseMarkup(t *testing.T) {
+	tests := []struct {
+		parseMode string
+		input     string
+		text      string
+		entities  []MessageEntity
+	}{
+		{
+			ModeHTML,
+			`<b>bold <i>both</i></b> &lt;a&gt; &amp; &#128077; <a href="https://example.com">link</a>`,
+			"bold both <a> & 👍 link",
+			[]MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 9},
+				{Type: EntityItalic, Offset: 5, Length: 4},
+				{Type: EntityTextLink, Offset: 19, Length: 4, URL: "https://example.com"},
+			},
+		},
+		{
+			ModeHTML,
+			`<pre><code class="language-go">x := 1</code></pre><span class="tg-spoiler">s</span>`,
+			"x := 1s",
+			[]MessageEntity{
+				{Type: EntityPre, Offset: 0, Length: 6, Language: "go"},
+				{Type: EntitySpoiler, Offset: 6, Length: 1},
+			},
+		},
+		{
+			ModeMarkdown,
+			"*bold* _it_ \\_x [user](tg://user?id=5) `co_de`",
+			"bold it _x user co_de",
+			[]MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 4},
+				{Type: EntityItalic, Offset: 5, Length: 2},
+				{Type: EntityTextMention, Offset: 11, Length: 4, User: &User{ID: 5}},
+				{Type: EntityCode, Offset: 16, Length: 5},
+			},
+		},
+		{
+			ModeMarkdownV2,
+			"*b _i_* __u__ ||s|| \\. [l](https://e\\.com)\n```go\nfmt\n```",
+			"b i u s . l\nfmt\n",
+			[]MessageEntity{
+				{Type: EntityBold, Offset: 0, Length: 3},
+				{Type: EntityItalic, Offset: 2, Length: 1},
+				{Type: EntityUnderline, Offset: 4, Length: 1},
+				{Type: EntitySpoiler, Offset: 6, Length: 1},
+				{Type: EntityTextLink, Offset: 10, Length: 1, URL: "https://e.com"},
+				{Type: EntityPre, Offset: 12, Length: 4, Language: "go"},
+			},
+		},
+		{
+			ModeMarkdownV2,
+			"___it_\r__ \\>not\n>quote\n>more\nend",
+			"it >not\nquote\nmore\nend",
+			[]MessageEntity{
+				{Type: EntityItalic, Offset: 0, Length: 2},
+				{Type: EntityUnderline, Offset: 0, Length: 2},
+				{Type: EntityBlockquote, Offset: 8, Length: 10},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		text, entities, err := ParseMarkup(test.parseMode, test.input)
+		if err != nil {
+			t.Errorf("%s %q: %s", test.parseMode, test.input, err)
+			continue
+		}
+
+		if text != test.text {
+			t.Errorf("%s %q: expected text %q, got %q", test.parseMode, test.input, test.text, text)
+		}
+
+		if len(entities) != len(test.entities) {
+			t.Errorf("%s %q: expected entities %+v, got %+v", test.parseMode, test.input, test.entities, entities)
+			continue
+		}
+
+		for i, expected := range test.entities {
+			actual := entities[i]
+			if expected.User != nil {
+				if actual.User == nil || actual.User.ID != expected.User.ID {
+					t.Errorf("%s %q: entity %d should mention user %d", test.parseMode, test.input, i, expected.User.ID)
+				}
+				expected.User, actual.User = nil, nil
+			}
+			if actual != expected {
+				t.Errorf("%s %q: entity %d: expected %+v, got %+v", test.parseMode, test.input, i, expected, actual)
+			}
+		}
+	}
+}
+
+func TestValidateMarkup(t *testing.T) {
+	tests := []struct {
+		parseMode string
+		input     string
+		offset    int
+	}{
+		{ModeHTML, "a <b>b", 2},
+		{ModeHTML, "<b>a</i>", 4},
+		{ModeHTML, "a <foo>", 2},
+		{ModeHTML, "</b>", 0},
+		{ModeMarkdown, "a *b", 2},
+		{ModeMarkdownV2, "Hello.", 5},
+		{ModeMarkdownV2, "a *b", 2},
+		{ModeMarkdownV2, "a |b", 2},
+	}
+
+	for _, test := range tests {
+		err := ValidateMarkup(test.parseMode, test.input)
+
+		markupErr, ok := err.(*MarkupError)
+		if !ok {
+			t.Errorf("%s %q: expected a MarkupError, got %v", test.parseMode, test.input, err)
+			continue
+		}
+
+		if markupErr.Offset != test.offset {
+			t.Errorf("%s %q: expected offset %d, got %d (%s)", test.parseMode, test.input, test.offset, markupErr.Offset, err)
+		}
+	}
+
+	if err := ValidateMarkup(ModeMarkdownV2, "Hello\\. `a.b` _ok_"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRequestCheckMarkup(t *testing.T) {
+	bot, client := newRecordingBot()
+	bot.CheckMarkup = true
+
+	msg := NewMessage(ChatID, "Hello.")
+	msg.ParseMode = ModeMarkdownV2
+
+	if _, err := bot.Request(msg); err == nil {
+		t.Fatal("expected invalid markup to be rejected")
+	}
+
+	if len(client.requests) != 0 {
+		t.Fatal("invalid markup should not be sent")
+	}
+
+	photo := NewInputMediaPhoto(FileID("photo"))
+	photo.Caption = "Hello."
+	photo.ParseMode = ModeMarkdownV2
+
+	poll := NewPoll(ChatID, "Question", "A", "B")
+	poll.Explanation = "*Because"
+	poll.ExplanationParseMode = ModeMarkdownV2
+
+	for _, c := range []Chattable{
+		NewMediaGroup(ChatID, []interface{}{NewInputMediaPhoto(FileID("first")), photo}),
+		EditMessageMediaConfig{BaseEdit: BaseEdit{ChatID: ChatID, MessageID: 1}, Media: photo},
+		poll,
+	} {
+		if _, err := bot.Request(c); err == nil {
+			t.Errorf("expected invalid markup in %s to be rejected", c.Method())
+		}
+	}
+
+	if len(client.requests) != 0 {
+		t.Fatal("invalid markup should not be sent")
+	}
+
+	photo.Caption = "Hello\\."
+	if _, err := bot.Request(NewMediaGroup(ChatID, []interface{}{photo})); err != nil {
+		t.Errorf("valid caption rejected: %v", err)
+	}
+}
@@ -0,0 +1,396 @@
+package tgbotapi
+
+import (
+	"errors"
+	"sync"
+)
+
+// MenuNode is a single screen of a Menu.
+type MenuNode struct {
+	// ID identifies the node in callback data. It must be unique within the
+	// menu and short enough to fit into 64 bytes of callback data.
+	ID string
+	// Label is the text of the button opening this node from its parent.
+	Label string
+	// Text is the message text shown while the node is open.
+	Text string
+	// TextFunc creates the message text for a user, replacing Text.
+	//
+	// optional
+	TextFunc func(user *User) string
+	// Children are the submenus of this node.
+	//
+	// optional
+	Children []*MenuNode
+	// Columns is the number of child buttons per row. Defaults to 1.
+	//
+	// optional
+	Columns int
+	// Buttons are additional rows shown below the children, such as URL
+	// buttons or callback buttons handled elsewhere.
+	//
+	// optional
+	Buttons [][]InlineKeyboardButton
+	// Allow reports if a user may see and open this node. Nodes without
+	// Allow are visible to everyone.
+	//
+	// optional
+	Allow func(user *User) bool
+	// Action is called when the node's button is pressed instead of
+	// opening it. It is meant for leaf nodes.
+	//
+	// optional
+	Action func(bot Sender, query *CallbackQuery) error
+}
+
+// NewMenuNode creates a new MenuNode with the given children.
+func NewMenuNode(id, label, text string, children ...*MenuNode) *MenuNode {
+	return &MenuNode{
+		ID:       id,
+		Label:    label,
+		Text:     text,
+		Children: children,
+	}
+}
+
+func (n *MenuNode) text(user *User) string {
+	if n.TextFunc != nil {
+		return n.TextFunc(user)
+	}
+
+	return n.Text
+}
+
+func (n *MenuNode) allowed(user *User) bool {
+	return n.Allow == nil || n.Allow(user)
+}
+
+// MenuHistory stores the path of open menu nodes for each chat.
+type MenuHistory interface {
+	// Get returns the IDs of the nodes opened in a chat, starting below the
+	// root node.
+	Get(chatID int64) []string
+	// Set replaces the path of opened nodes in a chat.
+	Set(chatID int64, path []string)
+}
+
+// MemoryMenuHistory is a MenuHistory kept in memory.
+type MemoryMenuHistory struct {
+	mu    sync.Mutex
+	paths map[int64][]string
+}
+
+// NewMemoryMenuHistory creates an empty MemoryMenuHistory.
+func NewMemoryMenuHistory() *MemoryMenuHistory {
+	return &MemoryMenuHistory{paths: make(map[int64][]string)}
+}
+
+// Get returns the IDs of the nodes opened in a chat.
+func (h *MemoryMenuHistory) Get(chatID int64) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	path := h.paths[chatID]
+
+	return append([]string(nil), path...)
+}
+
+// Set replaces the path of opened nodes in a chat.
+func (h *MemoryMenuHistory) Set(chatID int64, path []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(path) == 0 {
+		delete(h.paths, chatID)
+		return
+	}
+
+	h.paths[chatID] = append([]string(nil), path...)
+}
+
+const (
+	menuActionOpen = "open"
+	menuActionBack = "back"
+	menuActionHome = "home"
+)
+
+// Menu is a tree of MenuNodes navigated with an inline keyboard.
+//
+// A menu is sent with Message, and callback queries from its keyboard should
+// be passed to HandleCallback, which edits the message as the user moves
+// between nodes.
+type Menu struct {
+	// Prefix is prepended to the callback data of every button. It must be
+	// unique among the widgets handling the same callback queries.
+	Prefix string
+	// Root is the node shown when the menu is opened.
+	Root *MenuNode
+	// History stores the open nodes for each chat. Defaults to a
+	// MemoryMenuHistory.
+	History MenuHistory
+	// ParseMode is the parse mode of the node texts.
+	//
+	// optional
+	ParseMode string
+	// BackText is the text of the button returning to the parent node.
+	// Defaults to "« Back".
+	//
+	// optional
+	BackText string
+	// HomeText is the text of the button returning to the root node.
+	// Defaults to "⌂ Home".
+	//
+	// optional
+	HomeText string
+	// DeniedText is shown to users opening a node they are not allowed to.
+	// Defaults to "Access denied".
+	//
+	// optional
+	DeniedText string
+
+	historyOnce sync.Once
+	nodesOnce   sync.Once
+	nodes       map[string]*MenuNode
+	parents     map[string]*MenuNode
+}
+
+// NewMenu creates a new Menu with the given callback data prefix.
+func NewMenu(prefix string, root *MenuNode) *Menu {
+	return &Menu{
+		Prefix:  prefix,
+		Root:    root,
+		History: NewMemoryMenuHistory(),
+	}
+}
+
+// Message creates a message showing the root node of the menu to a user.
+//
+// It also resets the navigation history of the chat.
+func (m *Menu) Message(chatID int64, user *User) MessageConfig {
+	m.history().Set(chatID, nil)
+
+	msg := NewMessage(chatID, m.Root.text(user))
+	msg.ParseMode = m.ParseMode
+	msg.ReplyMarkup = m.markup(m.Root, 0, user)
+
+	return msg
+}
+
+// HandleCallback processes a callback query produced by the menu.
+//
+// It returns false if the query does not belong to this menu, in which case
+// nothing is sent to Telegram.
+func (m *Menu) HandleCallback(bot Sender, query *CallbackQuery) (bool, error) {
+	action, value, ok := parseWidgetData(m.Prefix, query)
+	if !ok {
+		return false, nil
+	}
+
+	chatID := query.From.ID
+	if query.Message != nil {
+		chatID = query.Message.Chat.ID
+	}
+
+	path := m.history().Get(chatID)
+
+	switch action {
+	case menuActionOpen:
+		node, ok := m.node(value)
+		if !ok {
+			return true, errors.New("unknown menu node")
+		}
+
+		// The node and all its ancestors must be allowed, so a button from
+		// an old message can't reach a node below a hidden one.
+		path = m.pathTo(node)
+		if allowed, ok := m.allowedPath(path, query.From); !ok || len(allowed) < len(path) {
+			_, err := bot.Request(NewCallbackWithAlert(query.ID, m.deniedText()))
+			return true, err
+		}
+
+		if node.Action != nil {
+			if _, err := bot.Request(NewCallback(query.ID, "")); err != nil {
+				return true, err
+			}
+
+			return true, node.Action(bot, query)
+		}
+	case menuActionBack:
+		if len(path) > 0 {
+			path = path[:len(path)-1]
+		}
+	case menuActionHome:
+		path = nil
+	default:
+		_, err := bot.Request(NewCallback(query.ID, ""))
+		return true, err
+	}
+
+	// Going back or home stops at the deepest node the user may still see,
+	// as permissions may have changed since the nodes were opened.
+	path, ok = m.allowedPath(path, query.From)
+	if !ok {
+		_, err := bot.Request(NewCallbackWithAlert(query.ID, m.deniedText()))
+		return true, err
+	}
+
+	node := m.Root
+	if len(path) > 0 {
+		node, _ = m.node(path[len(path)-1])
+	}
+
+	m.history().Set(chatID, path)
+
+	edit := EditMessageTextConfig{
+		BaseEdit: BaseEdit{
+			InlineMessageID: query.InlineMessageID,
+		},
+		Text:      node.text(query.From),
+		ParseMode: m.ParseMode,
+	}
+	if query.Message != nil {
+		edit.ChatID = query.Message.Chat.ID
+		edit.MessageID = query.Message.MessageID
+	}
+
+	markup := m.markup(node, len(path), query.From)
+	edit.ReplyMarkup = &markup
+
+	if _, err := bot.Request(edit); err != nil {
+		return true, err
+	}
+
+	_, err := bot.Request(NewCallback(query.ID, ""))
+
+	return true, err
+}
+
+func (m *Menu) history() MenuHistory {
+	m.historyOnce.Do(func() {
+		if m.History == nil {
+			m.History = NewMemoryMenuHistory()
+		}
+	})
+
+	return m.History
+}
+
+func (m *Menu) deniedText() string {
+	if m.DeniedText != "" {
+		return m.DeniedText
+	}
+
+	return "Access denied"
+}
+
+// node finds a node anywhere in the menu by ID.
+func (m *Menu) node(id string) (*MenuNode, bool) {
+	m.index()
+
+	node, ok := m.nodes[id]
+
+	return node, ok
+}
+
+// index builds the maps of nodes and their parents by ID once.
+func (m *Menu) index() {
+	m.nodesOnce.Do(func() {
+		m.nodes = make(map[string]*MenuNode)
+		m.parents = make(map[string]*MenuNode)
+
+		var walk func(node *MenuNode)
+		walk = func(node *MenuNode) {
+			m.nodes[node.ID] = node
+			for _, child := range node.Children {
+				m.parents[child.ID] = node
+				walk(child)
+			}
+		}
+		walk(m.Root)
+	})
+}
+
+// pathTo returns the IDs of the nodes from below the root to a node.
+func (m *Menu) pathTo(node *MenuNode) []string {
+	m.index()
+
+	var path []string
+	for node != m.Root && node != nil {
+		path = append([]string{node.ID}, path...)
+		node = m.parents[node.ID]
+	}
+
+	return path
+}
+
+// allowedPath returns the longest start of a path whose nodes a user may
+// see, each being a child of the one before. It returns false if the user
+// may not see the root node.
+func (m *Menu) allowedPath(path []string, user *User) ([]string, bool) {
+	if !m.Root.allowed(user) {
+		return nil, false
+	}
+
+	m.index()
+
+	parent := m.Root
+	for i, id := range path {
+		node, ok := m.nodes[id]
+		if !ok || m.parents[id] != parent || !node.allowed(user) {
+			return path[:i], true
+		}
+		parent = node
+	}
+
+	return path, true
+}
+
+func (m *Menu) markup(node *MenuNode, depth int, user *User) InlineKeyboardMarkup {
+	columns := node.Columns
+	if columns <= 0 {
+		columns = 1
+	}
+
+	rows := [][]InlineKeyboardButton{}
+	row := []InlineKeyboardButton{}
+
+	for _, child := range node.Children {
+		if !child.allowed(user) {
+			continue
+		}
+
+		row = append(row, NewInlineKeyboardButtonData(child.Label, widgetData(m.Prefix, menuActionOpen, child.ID)))
+		if len(row) == columns {
+			rows = append(rows, row)
+			row = []InlineKeyboardButton{}
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, node.Buttons...)
+
+	nav := []InlineKeyboardButton{}
+	if depth > 0 {
+		text := m.BackText
+		if text == "" {
+			text = "« Back"
+		}
+		nav = append(nav, NewInlineKeyboardButtonData(text, widgetData(m.Prefix, menuActionBack, "")))
+	}
+	if depth > 1 {
+		text := m.HomeText
+		if text == "" {
+			text = "⌂ Home"
+		}
+		nav = append(nav, NewInlineKeyboardButtonData(text, widgetData(m.Prefix, menuActionHome, "")))
+	}
+
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	return NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,119 @@
+package tgbotapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestMenu() *Menu {
+	admin := NewMenuNode("admin", "Admin", "Admin tools")
+	admin.Allow = func(user *User) bool { return user.ID == 42 }
+
+	settings := NewMenuNode("settings", "Settings", "Settings",
+		NewMenuNode("lang", "Language", "Pick a language"),
+	)
+
+	root := NewMenuNode("root", "", "", settings, admin)
+	root.TextFunc = func(user *User) string { return "Hello, " + user.FirstName }
+
+	return NewMenu("m", root)
+}
+
+func TestMenuMessage(t *testing.T) {
+	menu := newTestMenu()
+
+	msg := menu.Message(20, &User{ID: 1, FirstName: "Ann"})
+	if msg.Text != "Hello, Ann" {
+		t.Fatalf("unexpected text %q", msg.Text)
+	}
+
+	rows := msg.ReplyMarkup.(InlineKeyboardMarkup).InlineKeyboard
+	if len(rows) != 1 || rows[0][0].Text != "Settings" {
+		t.Fatalf("hidden nodes must not be rendered, got %v", rows)
+	}
+}
+
+func TestMenuHandleCallback(t *testing.T) {
+	bot, client := newRecordingBot()
+	menu := newTestMenu()
+
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:open:settings")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:open:lang")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := menu.History.Get(20); len(got) != 2 || got[1] != "lang" {
+		t.Fatalf("unexpected history %v", got)
+	}
+
+	edit := client.requests[2]
+	if client.methods[2] != "editMessageText" || edit.Get("text") != "Pick a language" {
+		t.Fatalf("unexpected edit %v", edit)
+	}
+	if !strings.Contains(edit.Get("reply_markup"), "m:back:") || !strings.Contains(edit.Get("reply_markup"), "m:home:") {
+		t.Fatal("expected back and home buttons")
+	}
+
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:back:")); err != nil {
+		t.Fatal(err)
+	}
+	if got := menu.History.Get(20); len(got) != 1 || got[0] != "settings" {
+		t.Fatalf("unexpected history after back %v", got)
+	}
+
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:home:")); err != nil {
+		t.Fatal(err)
+	}
+	if got := menu.History.Get(20); len(got) != 0 {
+		t.Fatalf("unexpected history after home %v", got)
+	}
+
+	client.methods = nil
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:open:admin")); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.methods) != 1 || client.methods[0] != "answerCallbackQuery" {
+		t.Fatalf("denied nodes must only be answered, got %v", client.methods)
+	}
+}
+
+func TestMenuPermissionsOnPath(t *testing.T) {
+	bot, client := newRecordingBot()
+
+	allowed := true
+	settings := NewMenuNode("settings", "Settings", "Settings",
+		NewMenuNode("lang", "Language", "Pick a language",
+			NewMenuNode("dialect", "Dialect", "Pick a dialect"),
+		),
+	)
+	settings.Allow = func(user *User) bool { return allowed }
+	menu := &Menu{Prefix: "m", Root: NewMenuNode("root", "", "", settings)}
+
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:open:lang")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:open:dialect")); err != nil {
+		t.Fatal(err)
+	}
+	if got := menu.History.Get(20); len(got) != 3 || got[0] != "settings" {
+		t.Fatalf("unexpected history %v", got)
+	}
+
+	allowed = false
+	client.methods = nil
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:open:lang")); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.methods) != 1 || client.methods[0] != "answerCallbackQuery" {
+		t.Fatalf("nodes below a denied node must not open, got %v", client.methods)
+	}
+
+	if _, err := menu.HandleCallback(bot, newWidgetQuery("m:back:")); err != nil {
+		t.Fatal(err)
+	}
+	if got := menu.History.Get(20); len(got) != 0 {
+		t.Fatalf("back must stop above the denied node, got %v", got)
+	}
+}
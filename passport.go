@@ -1,5 +1,13 @@
 package tgbotapi
 
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
 // PassportRequestInfoConfig allows you to request passport info
 type PassportRequestInfoConfig struct {
 	BotID     int            `json:"bot_id"`
@@ -8,6 +16,40 @@ type PassportRequestInfoConfig struct {
 	PublicKey string         `json:"public_key"`
 }
 
+// Link returns the tg://resolve?domain=telegrampassport link opening the
+// authorization request in Telegram. PublicKey is the PEM encoded public key
+// of the bot.
+func (config PassportRequestInfoConfig) Link() (string, error) {
+	if config.BotID == 0 || config.Nonce == "" || config.PublicKey == "" {
+		return "", errors.New("passport request needs a bot ID, a nonce and a public key")
+	}
+	if config.Scope == nil || len(config.Scope.Data) == 0 {
+		return "", errors.New("passport request needs a scope")
+	}
+
+	scope, err := json.Marshal(config.Scope)
+	if err != nil {
+		return "", err
+	}
+
+	params := []struct{ key, value string }{
+		{"domain", "telegrampassport"},
+		{"bot_id", strconv.Itoa(config.BotID)},
+		{"scope", string(scope)},
+		{"public_key", config.PublicKey},
+		{"nonce", config.Nonce},
+	}
+
+	query := make([]string, len(params))
+	for i, param := range params {
+		// Spaces are encoded as %20, as Telegram decodes the query like
+		// decodeURIComponent.
+		query[i] = param.key + "=" + strings.ReplaceAll(url.QueryEscape(param.value), "+", "%20")
+	}
+
+	return "tg://resolve?" + strings.Join(query, "&"), nil
+}
+
 // PassportScopeElement supports using one or one of several elements.
 type PassportScopeElement interface {
 	ScopeType() string
@@ -22,6 +64,9 @@ type PassportScope struct {
 // PassportScopeElementOneOfSeveral allows you to request any one of the
 // requested documents.
 type PassportScopeElementOneOfSeveral struct {
+	OneOf       []PassportScopeElementOne `json:"one_of"`
+	Selfie      bool                      `json:"selfie,omitempty"`
+	Translation bool                      `json:"translation,omitempty"`
 }
 
 // ScopeType is the scope type.
@@ -32,9 +77,9 @@ func (eo *PassportScopeElementOneOfSeveral) ScopeType() string {
 // PassportScopeElementOne requires the specified element be provided.
 type PassportScopeElementOne struct {
 	Type        string `json:"type"` // One of “personal_details”, “passport”, “driver_license”, “identity_card”, “internal_passport”, “address”, “utility_bill”, “bank_statement”, “rental_agreement”, “passport_registration”, “temporary_registration”, “phone_number”, “email”
-	Selfie      bool   `json:"selfie"`
-	Translation bool   `json:"translation"`
-	NativeNames bool   `json:"native_name"`
+	Selfie      bool   `json:"selfie,omitempty"`
+	Translation bool   `json:"translation,omitempty"`
+	NativeNames bool   `json:"native_names,omitempty"`
 }
 
 // ScopeType is the scope type.
@@ -111,6 +156,15 @@ type (
 		// "identity_card" and "internal_passport". The file can be decrypted
 		// and verified using the accompanying EncryptedCredentials.
 		Selfie *PassportFile `json:"selfie,omitempty"`
+
+		// Array of encrypted files with translated versions of documents
+		// provided by the user. The files can be decrypted and verified using
+		// the accompanying EncryptedCredentials.
+		Translation []PassportFile `json:"translation,omitempty"`
+
+		// Base64-encoded element hash for using in
+		// PassportElementErrorUnspecified
+		Hash string `json:"hash"`
 	}
 
 	// EncryptedCredentials contains data required for decrypting and
@@ -248,6 +302,56 @@ type (
 		Message string `json:"message"`
 	}
 
+	// PassportElementErrorTranslationFile represents an issue with one of the
+	// files that constitute the translation of a document. The error is
+	// considered resolved when the file changes.
+	PassportElementErrorTranslationFile struct {
+		// Error source, must be translation_file
+		Source string `json:"source"`
+
+		// Type of element of the user's Telegram Passport which has the issue
+		Type string `json:"type"`
+
+		// Base64-encoded file hash
+		FileHash string `json:"file_hash"`
+
+		// Error message
+		Message string `json:"message"`
+	}
+
+	// PassportElementErrorTranslationFiles represents an issue with the
+	// translated version of a document. The error is considered resolved when
+	// a file with the document translation changes.
+	PassportElementErrorTranslationFiles struct {
+		// Error source, must be translation_files
+		Source string `json:"source"`
+
+		// Type of element of the user's Telegram Passport which has the issue
+		Type string `json:"type"`
+
+		// List of base64-encoded file hashes
+		FileHashes []string `json:"file_hashes"`
+
+		// Error message
+		Message string `json:"message"`
+	}
+
+	// PassportElementErrorUnspecified represents an issue in an unspecified
+	// place. The error is considered resolved when new data is added.
+	PassportElementErrorUnspecified struct {
+		// Error source, must be unspecified
+		Source string `json:"source"`
+
+		// Type of element of the user's Telegram Passport which has the issue
+		Type string `json:"type"`
+
+		// Base64-encoded element hash
+		ElementHash string `json:"element_hash"`
+
+		// Error message
+		Message string `json:"message"`
+	}
+
 	// Credentials contains encrypted data.
 	Credentials struct {
 		Data SecureData `json:"secure_data"`
@@ -309,6 +413,16 @@ type (
 		MiddleNameNative     string `json:"middle_name_native"`
 	}
 
+	// ResidentialAddress https://core.telegram.org/passport#residentialaddress
+	ResidentialAddress struct {
+		StreetLine1 string `json:"street_line1"`
+		StreetLine2 string `json:"street_line2"`
+		City        string `json:"city"`
+		State       string `json:"state"`
+		CountryCode string `json:"country_code"`
+		PostCode    string `json:"post_code"`
+	}
+
 	// IDDocumentData https://core.telegram.org/passport#iddocumentdata
 	IDDocumentData struct {
 		DocumentNumber string `json:"document_no"`
@@ -0,0 +1,200 @@
+package tgbotapi
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrPassportDataHash is returned when decrypted passport data doesn't match
+// its hash.
+var ErrPassportDataHash = errors.New("passport data hash is not valid")
+
+// DecryptPassportCredentials decrypts the credentials of passport data with
+// the private key of the bot, whose public key was used to request the data.
+//
+// https://core.telegram.org/passport#decrypting-data
+func DecryptPassportCredentials(key *rsa.PrivateKey, credentials *EncryptedCredentials) (*Credentials, error) {
+	if credentials == nil {
+		return nil, errors.New("passport data has no credentials")
+	}
+
+	encryptedSecret, err := base64.StdEncoding.DecodeString(credentials.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding secret %w", err)
+	}
+
+	secret, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, key, encryptedSecret, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting secret %w", err)
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(credentials.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding hash %w", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(credentials.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding data %w", err)
+	}
+
+	decrypted, err := decryptPassportData(secret, hash, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Credentials
+	err = json.Unmarshal(decrypted, &result)
+
+	return &result, err
+}
+
+// DecryptPassportElementData decrypts the data of a passport element into v,
+// with the credentials of its type.
+func DecryptPassportElementData(credentials *Credentials, element EncryptedPassportElement, v interface{}) error {
+	value := credentials.Data[element.Type]
+	if value == nil || value.Data == nil {
+		return fmt.Errorf("no data credentials for %s", element.Type)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(value.Data.Secret)
+	if err != nil {
+		return fmt.Errorf("error decoding secret %w", err)
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(value.Data.DataHash)
+	if err != nil {
+		return fmt.Errorf("error decoding hash %w", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(element.Data)
+	if err != nil {
+		return fmt.Errorf("error decoding data %w", err)
+	}
+
+	decrypted, err := decryptPassportData(secret, hash, data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(decrypted, v)
+}
+
+// DecryptPersonalDetails decrypts the data of a "personal_details" element.
+func DecryptPersonalDetails(credentials *Credentials, element EncryptedPassportElement) (PersonalDetails, error) {
+	var details PersonalDetails
+	err := DecryptPassportElementData(credentials, element, &details)
+
+	return details, err
+}
+
+// DecryptIDDocumentData decrypts the data of a "passport",
+// "driver_license", "identity_card" or "internal_passport" element.
+func DecryptIDDocumentData(credentials *Credentials, element EncryptedPassportElement) (IDDocumentData, error) {
+	var document IDDocumentData
+	err := DecryptPassportElementData(credentials, element, &document)
+
+	return document, err
+}
+
+// DecryptResidentialAddress decrypts the data of an "address" element.
+func DecryptResidentialAddress(credentials *Credentials, element EncryptedPassportElement) (ResidentialAddress, error) {
+	var address ResidentialAddress
+	err := DecryptPassportElementData(credentials, element, &address)
+
+	return address, err
+}
+
+// DecryptPassportFile decrypts the content of a PassportFile, with the
+// credentials of the file in the SecureValue of its element.
+func DecryptPassportFile(credentials *FileCredentials, data []byte) ([]byte, error) {
+	if credentials == nil {
+		return nil, errors.New("no file credentials")
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(credentials.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding secret %w", err)
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(credentials.FileHash)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding hash %w", err)
+	}
+
+	return decryptPassportData(secret, hash, data)
+}
+
+// DownloadPassportFile downloads a PassportFile and decrypts it with
+// DecryptPassportFile.
+func (bot *BotAPI) DownloadPassportFile(file PassportFile, credentials *FileCredentials) ([]byte, error) {
+	link, err := bot.GetFileDirectURL(file.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := bot.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading file %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecryptPassportFile(credentials, data)
+}
+
+// decryptPassportData decrypts data with AES-256-CBC, using the key and IV
+// derived from the secret and the hash. It checks the hash of the decrypted
+// data and removes its random padding.
+func decryptPassportData(secret, hash, data []byte) ([]byte, error) {
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("passport data is not a multiple of the block size")
+	}
+
+	secretHash := sha512.Sum512(append(append([]byte{}, secret...), hash...))
+
+	block, err := aes.NewCipher(secretHash[:32])
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, secretHash[32:48]).CryptBlocks(decrypted, data)
+
+	dataHash := sha256.Sum256(decrypted)
+	if !bytes.Equal(dataHash[:], hash) {
+		return nil, ErrPassportDataHash
+	}
+
+	padding := int(decrypted[0])
+	if padding < 32 || padding > len(decrypted) {
+		return nil, errors.New("passport data has invalid padding")
+	}
+
+	return decrypted[padding:], nil
+}
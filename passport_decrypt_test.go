@@ -0,0 +1,124 @@
+package tgbotapi
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+// encryptPassportData encrypts data like Telegram does, returning the
+// encrypted data and its hash.
+func encryptPassportData(t *testing.T, secret, data []byte) ([]byte, []byte) {
+	padding := 32 + (aes.BlockSize-(len(data)+32)%aes.BlockSize)%aes.BlockSize
+
+	padded := make([]byte, padding, padding+len(data))
+	if _, err := rand.Read(padded); err != nil {
+		t.Fatal(err)
+	}
+	padded[0] = byte(padding)
+	padded = append(padded, data...)
+
+	hash := sha256.Sum256(padded)
+	secretHash := sha512.Sum512(append(append([]byte{}, secret...), hash[:]...))
+
+	block, err := aes.NewCipher(secretHash[:32])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, secretHash[32:48]).CryptBlocks(encrypted, padded)
+
+	return encrypted, hash[:]
+}
+
+func encryptPassportJSON(t *testing.T, secret []byte, v interface{}) (string, string) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, hash := encryptPassportData(t, secret, data)
+
+	return base64.StdEncoding.EncodeToString(encrypted), base64.StdEncoding.EncodeToString(hash)
+}
+
+func TestDecryptPassportData(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	detailsSecret := []byte("0123456789abcdef0123456789abcdef")
+	detailsData, detailsHash := encryptPassportJSON(t, detailsSecret, PersonalDetails{FirstName: "Ada", CountryCode: "GB"})
+
+	addressSecret := []byte("fedcba9876543210fedcba9876543210")
+	addressData, addressHash := encryptPassportJSON(t, addressSecret, ResidentialAddress{City: "London", CountryCode: "GB"})
+
+	fileSecret := []byte("file secret file secret file sec")
+	file, fileHash := encryptPassportData(t, fileSecret, []byte("jpeg"))
+
+	credentialsSecret := []byte("credentials secret credentials s")
+	credentialsData, credentialsHash := encryptPassportJSON(t, credentialsSecret, Credentials{
+		Nonce: "nonce",
+		Data: SecureData{
+			"personal_details": {Data: &DataCredentials{
+				DataHash: detailsHash,
+				Secret:   base64.StdEncoding.EncodeToString(detailsSecret),
+			}},
+			"address": {Data: &DataCredentials{
+				DataHash: addressHash,
+				Secret:   base64.StdEncoding.EncodeToString(addressSecret),
+			}},
+			"utility_bill": {Files: []*FileCredentials{{
+				FileHash: base64.StdEncoding.EncodeToString(fileHash),
+				Secret:   base64.StdEncoding.EncodeToString(fileSecret),
+			}}},
+		},
+	})
+
+	encryptedSecret, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, &key.PublicKey, credentialsSecret, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentials, err := DecryptPassportCredentials(key, &EncryptedCredentials{
+		Data:   credentialsData,
+		Hash:   credentialsHash,
+		Secret: base64.StdEncoding.EncodeToString(encryptedSecret),
+	})
+	if err != nil || credentials.Nonce != "nonce" {
+		t.Fatalf("unexpected credentials %+v (%v)", credentials, err)
+	}
+
+	details, err := DecryptPersonalDetails(credentials, EncryptedPassportElement{Type: "personal_details", Data: detailsData})
+	if err != nil || details.FirstName != "Ada" || details.CountryCode != "GB" {
+		t.Errorf("unexpected personal details %+v (%v)", details, err)
+	}
+
+	address, err := DecryptResidentialAddress(credentials, EncryptedPassportElement{Type: "address", Data: addressData})
+	if err != nil || address.City != "London" {
+		t.Errorf("unexpected address %+v (%v)", address, err)
+	}
+
+	content, err := DecryptPassportFile(credentials.Data["utility_bill"].Files[0], file)
+	if err != nil || string(content) != "jpeg" {
+		t.Errorf("unexpected file %q (%v)", content, err)
+	}
+
+	if _, err := DecryptIDDocumentData(credentials, EncryptedPassportElement{Type: "passport"}); err == nil {
+		t.Error("element without credentials decrypted")
+	}
+
+	file[len(file)-1] ^= 1
+	if _, err := DecryptPassportFile(credentials.Data["utility_bill"].Files[0], file); err != ErrPassportDataHash {
+		t.Errorf("expected invalid hash, got %v", err)
+	}
+}
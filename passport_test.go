@@ -0,0 +1,46 @@
+package tgbotapi
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPassportRequestInfoConfigLink(t *testing.T) {
+	config := PassportRequestInfoConfig{
+		BotID: 123,
+		Scope: NewPassportScope(
+			&PassportScopeElementOne{Type: "personal_details", NativeNames: true},
+			&PassportScopeElementOneOfSeveral{
+				OneOf:  []PassportScopeElementOne{{Type: "passport"}, {Type: "identity_card"}},
+				Selfie: true,
+			},
+		),
+		Nonce:     "nonce+1",
+		PublicKey: "-----BEGIN PUBLIC KEY-----\nKEY\n-----END PUBLIC KEY-----",
+	}
+
+	link, err := config.Link()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(link, "tg://resolve?domain=telegrampassport&bot_id=123&") || strings.Contains(link, "+") {
+		t.Errorf("unexpected link %s", link)
+	}
+
+	query, err := url.ParseQuery(strings.TrimPrefix(link, "tg://resolve?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope := `{"v":1,"data":[{"type":"personal_details","native_names":true},{"one_of":[{"type":"passport"},{"type":"identity_card"}],"selfie":true}]}`
+	if query.Get("scope") != scope || query.Get("nonce") != "nonce+1" || query.Get("public_key") != config.PublicKey {
+		t.Errorf("unexpected query %v", query)
+	}
+
+	config.Nonce = ""
+	if _, err := config.Link(); err == nil {
+		t.Error("request without nonce accepted")
+	}
+}
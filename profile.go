@@ -0,0 +1,239 @@
+package tgbotapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// BotProfile is the name, description and short description of a bot in a
+// language. Nil texts are left unchanged by SyncBotProfiles, and empty ones
+// are cleared, so the texts of the default language are shown instead.
+type BotProfile struct {
+	// Name of the bot, 0-64 characters.
+	Name *string `json:"name,omitempty"`
+	// Description shown in empty chats with the bot, 0-512 characters.
+	Description *string `json:"description,omitempty"`
+	// ShortDescription shown on the profile page of the bot and when sharing
+	// it, 0-120 characters.
+	ShortDescription *string `json:"short_description,omitempty"`
+}
+
+// text returns the text of a profile by the name of its param.
+func (p BotProfile) text(param string) *string {
+	switch param {
+	case "name":
+		return p.Name
+	case "description":
+		return p.Description
+	default:
+		return p.ShortDescription
+	}
+}
+
+// BotProfiles are the profiles of a bot by language code. The profile of the
+// empty language code is shown to users without a dedicated one.
+type BotProfiles map[string]BotProfile
+
+// defaultBotProfile is the key of the profile of the empty language code in
+// profile files.
+const defaultBotProfile = "default"
+
+// ParseBotProfilesJSON parses profiles from a JSON object with a key per
+// language code, and "default" for users without a dedicated language.
+//
+//	{"default": {"name": "Weather", "short_description": "Forecasts"},
+//	 "de": {"name": "Wetter"}}
+func ParseBotProfilesJSON(data []byte) (BotProfiles, error) {
+	var languages map[string]BotProfile
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&languages); err != nil {
+		return nil, err
+	}
+
+	profiles := make(BotProfiles, len(languages))
+	for language, profile := range languages {
+		if language == defaultBotProfile {
+			language = ""
+		}
+		profiles[language] = profile
+	}
+
+	return profiles, nil
+}
+
+// LoadBotProfiles loads profiles from a .json file.
+func LoadBotProfiles(filename string) (BotProfiles, error) {
+	if filepath.Ext(filename) != ".json" {
+		return nil, fmt.Errorf("unsupported profile file %s", filename)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := ParseBotProfilesJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return profiles, nil
+}
+
+// Validate checks the language codes and the length of the texts.
+func (p BotProfiles) Validate() error {
+	for _, language := range p.languages() {
+		if language != "" && len(language) != 2 {
+			return fmt.Errorf("language code %q must have two letters", language)
+		}
+
+		for _, field := range botProfileFields {
+			text := p[language].text(field.param)
+			if text == nil {
+				continue
+			}
+
+			if n := utf8.RuneCountInString(*text); n > field.maxLength {
+				return fmt.Errorf("%s of language %q has %d characters, at most %d are allowed",
+					strings.ReplaceAll(field.param, "_", " "), language, n, field.maxLength)
+			}
+		}
+	}
+
+	return nil
+}
+
+// languages returns the language codes of the profiles, sorted, so the
+// default profile comes first.
+func (p BotProfiles) languages() []string {
+	languages := make([]string, 0, len(p))
+	for language := range p {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	return languages
+}
+
+// botProfileFields are the texts of a profile, by the name of their param
+// in the configs getting and setting them.
+var botProfileFields = []struct {
+	param     string
+	maxLength int
+	get       func(language string) Chattable
+	set       func(text, language string) Chattable
+}{
+	{
+		param:     "name",
+		maxLength: 64,
+		get:       func(language string) Chattable { return GetMyNameConfig{LanguageCode: language} },
+		set: func(text, language string) Chattable {
+			return SetMyNameConfig{Name: text, LanguageCode: language}
+		},
+	},
+	{
+		param:     "description",
+		maxLength: 512,
+		get:       func(language string) Chattable { return GetMyDescriptionConfig{LanguageCode: language} },
+		set: func(text, language string) Chattable {
+			return SetMyDescriptionConfig{Description: text, LanguageCode: language}
+		},
+	},
+	{
+		param:     "short_description",
+		maxLength: 120,
+		get:       func(language string) Chattable { return GetMyShortDescriptionConfig{LanguageCode: language} },
+		set: func(text, language string) Chattable {
+			return SetMyShortDescriptionConfig{ShortDescription: text, LanguageCode: language}
+		},
+	},
+}
+
+// SyncBotProfiles applies profiles to a bot. The current texts are requested
+// first, so only the texts which differ are set. Languages without a profile
+// are left unchanged.
+//
+// Telegram returns the text of the default language for languages without
+// their own, so a localized text equal to the default one can't be told
+// apart from a missing one. Such texts are always set, so they stay when the
+// default changes. Clearing a localized text is requested unless both it and
+// the default text are empty.
+//
+// It returns the configs which were applied.
+func SyncBotProfiles(bot Requester, profiles BotProfiles) ([]Chattable, error) {
+	if err := profiles.Validate(); err != nil {
+		return nil, err
+	}
+
+	// defaults are the current texts of the default language by param,
+	// requested when a localized text needs them.
+	defaults := make(map[string]string)
+
+	var applied []Chattable
+	for _, language := range profiles.languages() {
+		for _, field := range botProfileFields {
+			text := profiles[language].text(field.param)
+			if text == nil {
+				continue
+			}
+
+			current, err := botProfileText(bot, field.get(language), field.param)
+			if err != nil {
+				return applied, err
+			}
+
+			changed := current != *text
+			if language == "" {
+				defaults[field.param] = *text
+			} else if *text != "" && !changed {
+				fallback, ok := defaults[field.param]
+				if !ok {
+					if fallback, err = botProfileText(bot, field.get(""), field.param); err != nil {
+						return applied, err
+					}
+					defaults[field.param] = fallback
+				}
+				changed = current == fallback
+			}
+
+			if !changed {
+				continue
+			}
+
+			config := field.set(*text, language)
+			if _, err := bot.Request(config); err != nil {
+				return applied, err
+			}
+			applied = append(applied, config)
+		}
+	}
+
+	return applied, nil
+}
+
+// botProfileText requests a text of the profile of a bot.
+func botProfileText(bot Requester, config Chattable, param string) (string, error) {
+	var result map[string]string
+	if err := requestResult(bot, config, &result); err != nil {
+		return "", err
+	}
+
+	return result[param], nil
+}
+
+func requestResult(bot Requester, c Chattable, result interface{}) error {
+	resp, err := bot.Request(c)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp.Result, result)
+}
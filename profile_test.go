@@ -0,0 +1,107 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// profileBot answers the profile getters with fixed texts by method and
+// language code, and records the other requests.
+type profileBot struct {
+	current  map[string]string
+	requests []Chattable
+}
+
+func (b *profileBot) Request(c Chattable) (*APIResponse, error) {
+	params, err := c.Params()
+	if err != nil {
+		return nil, err
+	}
+
+	key := c.Method() + "/" + params["language_code"]
+	switch c.Method() {
+	case "getMyName":
+		return profileResult(BotName{Name: b.current[key]})
+	case "getMyDescription":
+		return profileResult(BotDescription{Description: b.current[key]})
+	case "getMyShortDescription":
+		return profileResult(BotShortDescription{ShortDescription: b.current[key]})
+	}
+
+	b.requests = append(b.requests, c)
+
+	return profileResult(true)
+}
+
+func profileResult(v interface{}) (*APIResponse, error) {
+	data, err := json.Marshal(v)
+
+	return &APIResponse{Ok: true, Result: data}, err
+}
+
+func TestParseBotProfilesJSON(t *testing.T) {
+	profiles, err := ParseBotProfilesJSON([]byte(`{
+		"default": {"name": "Weather", "short_description": "Forecasts"},
+		"de": {"name": "Wetter", "description": ""}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def, de := profiles[""], profiles["de"]
+	if *def.Name != "Weather" || *def.ShortDescription != "Forecasts" || def.Description != nil ||
+		*de.Name != "Wetter" || de.Description == nil || *de.Description != "" {
+		t.Errorf("unexpected profiles %+v", profiles)
+	}
+
+	if _, err := ParseBotProfilesJSON([]byte(`{"default": {"title": "Weather"}}`)); err == nil {
+		t.Error("unknown field accepted")
+	}
+}
+
+func TestSyncBotProfiles(t *testing.T) {
+	bot := &profileBot{current: map[string]string{
+		"getMyName/":             "Weather",
+		"getMyShortDescription/": "Old forecasts",
+		"getMyName/de":           "Weather",
+	}}
+
+	weather, wetter, forecasts := "Weather", "Wetter", "Forecasts"
+	applied, err := SyncBotProfiles(bot, BotProfiles{
+		"":   {Name: &weather, ShortDescription: &forecasts},
+		"de": {Name: &wetter},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Chattable{
+		SetMyShortDescriptionConfig{ShortDescription: "Forecasts"},
+		SetMyNameConfig{Name: "Wetter", LanguageCode: "de"},
+	}
+	if len(applied) != len(expected) || len(bot.requests) != len(expected) {
+		t.Fatalf("applied %+v, expected %+v", applied, expected)
+	}
+	for i := range expected {
+		if applied[i] != expected[i] {
+			t.Errorf("applied %+v, expected %+v", applied[i], expected[i])
+		}
+	}
+}
+
+func TestSyncBotProfilesValidation(t *testing.T) {
+	bot := &profileBot{}
+
+	long := strings.Repeat("a", 65)
+	_, err := SyncBotProfiles(bot, BotProfiles{"": {Name: &long}})
+	if err == nil || len(bot.requests) != 0 {
+		t.Errorf("long name accepted: %v", err)
+	}
+
+	tempo := "Tempo"
+	_, err = SyncBotProfiles(bot, BotProfiles{"pt-br": {Name: &tempo}})
+	if err == nil {
+		t.Error("regional language code accepted")
+	}
+}
@@ -0,0 +1,199 @@
+package tgbotapi
+
+import (
+	"unicode/utf16"
+)
+
+// Telegram limits on the length of texts, in UTF-16 code units.
+const (
+	// MaxMessageTextLength is the maximum length of a message text.
+	MaxMessageTextLength = 4096
+	// MaxCaptionLength is the maximum length of a media caption.
+	MaxCaptionLength = 1024
+)
+
+// TextPart is a piece of a text produced by SplitText, with entities
+// relative to the piece.
+type TextPart struct {
+	Text     string
+	Entities []MessageEntity
+}
+
+// SplitText splits text and its entities into parts no longer than limit
+// UTF-16 code units.
+//
+// Texts are split at paragraph breaks when possible, then at line breaks,
+// then at spaces, and only as a last resort in the middle of a word.
+// Whitespace around a split is dropped. Entities crossing a split are
+// clipped so they continue in the next part.
+//
+// Use MaxMessageTextLength for message texts or MaxCaptionLength for
+// captions.
+func SplitText(text string, entities []MessageEntity, limit int) []TextPart {
+	units := utf16.Encode([]rune(text))
+	if limit <= 0 || len(units) <= limit {
+		return []TextPart{{Text: text, Entities: entities}}
+	}
+
+	var parts []TextPart
+
+	start := 0
+	for start < len(units) {
+		end := len(units)
+		next := end
+
+		if end-start > limit {
+			end = splitPoint(units, start, start+limit)
+			next = end
+		}
+
+		for end > start && isSplitSpace(units[end-1]) {
+			end--
+		}
+		for next < len(units) && isSplitSpace(units[next]) {
+			next++
+		}
+
+		if end > start {
+			parts = append(parts, TextPart{
+				Text:     string(utf16.Decode(units[start:end])),
+				Entities: clipEntities(entities, start, end),
+			})
+		}
+
+		start = next
+	}
+
+	return parts
+}
+
+// splitPoint finds where to end a part starting at start. The text before
+// the returned point is at most limit long once trailing whitespace is
+// dropped.
+func splitPoint(units []uint16, start, limit int) int {
+	for _, sep := range [][]uint16{{'\n', '\n'}, {'\n'}, {' '}} {
+		for i := limit; i > start; i-- {
+			if unitsHavePrefix(units[i:], sep) {
+				return i + len(sep)
+			}
+		}
+	}
+
+	// Avoid splitting a surrogate pair.
+	if high := units[limit-1]; high >= 0xd800 && high < 0xdc00 && limit-1 > start {
+		return limit - 1
+	}
+
+	return limit
+}
+
+func unitsHavePrefix(units, prefix []uint16) bool {
+	if len(units) < len(prefix) {
+		return false
+	}
+
+	for i := range prefix {
+		if units[i] != prefix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isSplitSpace(unit uint16) bool {
+	return unit == ' ' || unit == '\n' || unit == '\t' || unit == '\r'
+}
+
+// clipEntities returns the entities covering the range from start to end,
+// made relative to start.
+func clipEntities(entities []MessageEntity, start, end int) []MessageEntity {
+	var clipped []MessageEntity
+
+	for _, entity := range entities {
+		entityStart := clampInt(entity.Offset, start, end)
+		entityEnd := clampInt(entity.Offset+entity.Length, start, end)
+		if entityStart >= entityEnd {
+			continue
+		}
+
+		entity.Offset = entityStart - start
+		entity.Length = entityEnd - entityStart
+		clipped = append(clipped, entity)
+	}
+
+	return clipped
+}
+
+// SplitMessage splits a message with a text longer than MaxMessageTextLength
+// into several messages, as described by SplitText.
+//
+// Only the first message replies, to ReplyToMessageID or with
+// ReplyParameters, and only the last one has the ReplyMarkup. Texts using a
+// ParseMode are converted to entities with ParseMarkup first.
+func SplitMessage(config MessageConfig) ([]MessageConfig, error) {
+	if UTF16Len(config.Text) <= MaxMessageTextLength {
+		return []MessageConfig{config}, nil
+	}
+
+	if config.ParseMode != "" {
+		text, entities, err := ParseMarkup(config.ParseMode, config.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Text = text
+		config.Entities = append(entities, config.Entities...)
+		config.ParseMode = ""
+	}
+
+	parts := SplitText(config.Text, config.Entities, MaxMessageTextLength)
+	messages := make([]MessageConfig, len(parts))
+
+	for i, part := range parts {
+		msg := config
+		msg.Text = part.Text
+		msg.Entities = part.Entities
+
+		if i > 0 {
+			msg.ReplyToMessageID = 0
+			msg.ReplyParameters = nil
+		}
+		if i < len(parts)-1 {
+			msg.ReplyMarkup = nil
+		}
+
+		messages[i] = msg
+	}
+
+	return messages, nil
+}
+
+// SendLongMessage sends a message of any length by splitting it with
+// SplitMessage and sending the parts in order.
+//
+// It returns the messages sent so far if sending a part fails.
+func (bot *BotAPI) SendLongMessage(config MessageConfig) ([]Message, error) {
+	return SendLongMessage(bot, config)
+}
+
+// SendLongMessage sends a message of any length with a Sender, like
+// BotAPI.SendLongMessage.
+func SendLongMessage(sender Sender, config MessageConfig) ([]Message, error) {
+	configs, err := SplitMessage(config)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, 0, len(configs))
+	for _, c := range configs {
+		message, err := sender.Send(c)
+		if err != nil {
+			return messages, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,111 @@
+package tgbotapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitTextBoundaries(t *testing.T) {
+	text := "first paragraph\n\nsecond line\nthird words here"
+
+	parts := SplitText(text, nil, 20)
+	expected := []string{"first paragraph", "second line", "third words here"}
+
+	if len(parts) != len(expected) {
+		t.Fatalf("unexpected parts %v", parts)
+	}
+	for i := range expected {
+		if parts[i].Text != expected[i] {
+			t.Errorf("part %d: expected %q, got %q", i, expected[i], parts[i].Text)
+		}
+	}
+
+	parts = SplitText(strings.Repeat("👍", 5), nil, 3)
+	if len(parts) != 5 || parts[0].Text != "👍" {
+		t.Fatalf("surrogate pairs must not be split, got %v", parts)
+	}
+}
+
+func TestSplitTextEntities(t *testing.T) {
+	text := "aaaa 👍bbb cccc"
+	entities := []MessageEntity{
+		{Type: EntityBold, Offset: 2, Length: 8},
+		{Type: EntityItalic, Offset: 11, Length: 4},
+	}
+
+	parts := SplitText(text, entities, 10)
+	if len(parts) != 2 || parts[0].Text != "aaaa 👍bbb" || parts[1].Text != "cccc" {
+		t.Fatalf("unexpected parts %v", parts)
+	}
+
+	if len(parts[0].Entities) != 1 || parts[0].Entities[0].Offset != 2 || parts[0].Entities[0].Length != 8 {
+		t.Errorf("unexpected entities in first part %v", parts[0].Entities)
+	}
+	if len(parts[1].Entities) != 1 || parts[1].Entities[0].Offset != 0 || parts[1].Entities[0].Length != 4 {
+		t.Errorf("unexpected entities in second part %v", parts[1].Entities)
+	}
+
+	parts = SplitText("bold text", []MessageEntity{{Type: EntityBold, Offset: 0, Length: 9}}, 5)
+	if len(parts) != 2 || parts[1].Entities[0].Offset != 0 || parts[1].Entities[0].Length != 4 {
+		t.Fatalf("entities crossing a split must be reopened, got %v", parts)
+	}
+}
+
+func TestSplitMessage(t *testing.T) {
+	msg := NewMessage(ChatID, strings.Repeat("word ", 2000))
+	msg.ReplyToMessageID = ReplyToMessageID
+	msg.ReplyMarkup = NewRemoveKeyboard(false)
+
+	messages, err := SplitMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+	for i, m := range messages {
+		if UTF16Len(m.Text) > MaxMessageTextLength {
+			t.Errorf("message %d is too long", i)
+		}
+		if (m.ReplyToMessageID != 0) != (i == 0) {
+			t.Errorf("only the first message should be a reply")
+		}
+		if (m.ReplyMarkup != nil) != (i == len(messages)-1) {
+			t.Errorf("only the last message should have a keyboard")
+		}
+	}
+
+	long := strings.Repeat("word ", 2000)
+	for _, reply := range []MessageConfig{
+		NewMessageReply(&Message{MessageID: 7, Chat: &Chat{ID: ChatID}}, long),
+		NewQuoteReply(&Message{MessageID: 7, Text: "quoted", Chat: &Chat{ID: ChatID}}, "quoted", long),
+	} {
+		messages, err := SplitMessage(reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, m := range messages {
+			if (m.ReplyParameters != nil) != (i == 0) {
+				t.Errorf("message %d: only the first message should reply, got %+v", i, m.ReplyParameters)
+			}
+		}
+	}
+
+	msg.Text = "<b>" + msg.Text + "</b>"
+	msg.ParseMode = ModeHTML
+	messages, err = SplitMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range messages {
+		if m.ParseMode != "" || len(m.Entities) != 1 || m.Entities[0].Type != EntityBold {
+			t.Errorf("message %d: expected markup converted to entities, got %v", i, m.Entities)
+		}
+	}
+
+	msg.Text = "<b>" + strings.Repeat("word ", 2000)
+	if _, err := SplitMessage(msg); err == nil {
+		t.Fatal("expected an error for invalid markup")
+	}
+}
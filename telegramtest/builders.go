@@ -0,0 +1,603 @@
+package telegramtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+// Default values used by the builders.
+var (
+	// DefaultUser is the sender of built updates.
+	DefaultUser = tgbotapi.User{ID: 1001, FirstName: "Test", UserName: "test_user", LanguageCode: "en"}
+	// DefaultBot is the bot sending messages in built callback queries.
+	DefaultBot = tgbotapi.User{ID: 123456, IsBot: true, FirstName: "Test Bot", UserName: "test_bot"}
+	// DefaultDate is the date of built updates.
+	DefaultDate = time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+)
+
+var lastUpdateID int64
+
+// nextUpdateID returns a new update ID, so built updates have distinct IDs.
+func nextUpdateID() int {
+	return int(atomic.AddInt64(&lastUpdateID, 1))
+}
+
+// PrivateChat returns the private chat with a user.
+func PrivateChat(user tgbotapi.User) tgbotapi.Chat {
+	return tgbotapi.Chat{
+		ID:        user.ID,
+		Type:      "private",
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		UserName:  user.UserName,
+	}
+}
+
+// GroupChat returns a supergroup.
+func GroupChat(id int64, title string) tgbotapi.Chat {
+	return tgbotapi.Chat{ID: id, Type: "supergroup", Title: title}
+}
+
+// ChannelChat returns a channel.
+func ChannelChat(id int64, title string) tgbotapi.Chat {
+	return tgbotapi.Chat{ID: id, Type: "channel", Title: title}
+}
+
+// UpdateJSON encodes an update as sent to webhooks.
+func UpdateJSON(update tgbotapi.Update) []byte {
+	data, err := json.Marshal(update)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+// WebhookRequest creates a request delivering an update to a webhook
+// handler, for use with httptest.NewRecorder.
+func WebhookRequest(update tgbotapi.Update) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(UpdateJSON(update)))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req
+}
+
+// MessageBuilder builds messages and updates containing them.
+type MessageBuilder struct {
+	message tgbotapi.Message
+}
+
+// NewMessage starts building an empty message from DefaultUser in their
+// private chat.
+func NewMessage() *MessageBuilder {
+	user := DefaultUser
+	chat := PrivateChat(user)
+
+	return &MessageBuilder{message: tgbotapi.Message{
+		MessageID: 1,
+		From:      &user,
+		Date:      int(DefaultDate.Unix()),
+		Chat:      &chat,
+	}}
+}
+
+// NewTextMessage starts building a text message.
+func NewTextMessage(text string) *MessageBuilder {
+	return NewMessage().Text(text)
+}
+
+// NewCommand starts building a message with a command, such as "start" or
+// "start@test_bot", followed by optional arguments. The message has the
+// bot_command entity Telegram adds.
+func NewCommand(command, args string) *MessageBuilder {
+	command = "/" + strings.TrimPrefix(command, "/")
+
+	text := command
+	if args != "" {
+		text += " " + args
+	}
+
+	return NewTextMessage(text).Entity(tgbotapi.MessageEntity{
+		Type:   tgbotapi.EntityBotCommand,
+		Offset: 0,
+		Length: tgbotapi.UTF16Len(command),
+	})
+}
+
+// ID sets the message ID.
+func (b *MessageBuilder) ID(id int) *MessageBuilder {
+	b.message.MessageID = id
+	return b
+}
+
+// From sets the sender. Messages in private chats are moved to the private
+// chat of the sender.
+func (b *MessageBuilder) From(user tgbotapi.User) *MessageBuilder {
+	b.message.From = &user
+
+	if b.message.Chat.Type == "private" {
+		chat := PrivateChat(user)
+		b.message.Chat = &chat
+	}
+
+	return b
+}
+
+// Chat sets the chat of the message.
+func (b *MessageBuilder) Chat(chat tgbotapi.Chat) *MessageBuilder {
+	b.message.Chat = &chat
+	return b
+}
+
+// Date sets the date of the message.
+func (b *MessageBuilder) Date(date time.Time) *MessageBuilder {
+	b.message.Date = int(date.Unix())
+	return b
+}
+
+// Text sets the text of the message.
+func (b *MessageBuilder) Text(text string) *MessageBuilder {
+	b.message.Text = text
+	return b
+}
+
+// Entity adds an entity to the text of the message.
+func (b *MessageBuilder) Entity(entity tgbotapi.MessageEntity) *MessageBuilder {
+	b.message.Entities = append(b.message.Entities, entity)
+	return b
+}
+
+// Caption sets the caption of media messages.
+func (b *MessageBuilder) Caption(caption string) *MessageBuilder {
+	b.message.Caption = caption
+	return b
+}
+
+// ReplyTo makes the message a reply.
+func (b *MessageBuilder) ReplyTo(message tgbotapi.Message) *MessageBuilder {
+	b.message.ReplyToMessage = &message
+	return b
+}
+
+// Photo adds a photo with a file ID to the message.
+func (b *MessageBuilder) Photo(fileID string) *MessageBuilder {
+	b.message.Photo = []tgbotapi.PhotoSize{{FileID: fileID, FileUniqueID: "unique-" + fileID, Width: 800, Height: 600}}
+	return b
+}
+
+// Document adds a document with a file ID to the message.
+func (b *MessageBuilder) Document(fileID, fileName string) *MessageBuilder {
+	b.message.Document = &tgbotapi.Document{FileID: fileID, FileUniqueID: "unique-" + fileID, FileName: fileName}
+	return b
+}
+
+// Video adds a video with a file ID to the message.
+func (b *MessageBuilder) Video(fileID string) *MessageBuilder {
+	b.message.Video = &tgbotapi.Video{FileID: fileID, FileUniqueID: "unique-" + fileID, Width: 1280, Height: 720}
+	return b
+}
+
+// MediaGroup sets the media group of the message.
+func (b *MessageBuilder) MediaGroup(id string) *MessageBuilder {
+	b.message.MediaGroupID = id
+	return b
+}
+
+// SuccessfulPayment adds a successful payment to the message.
+func (b *MessageBuilder) SuccessfulPayment(payload, currency string, totalAmount int) *MessageBuilder {
+	b.message.SuccessfulPayment = &tgbotapi.SuccessfulPayment{
+		Currency:                currency,
+		TotalAmount:             totalAmount,
+		InvoicePayload:          payload,
+		TelegramPaymentChargeID: fmt.Sprintf("tg-charge-%d", b.message.MessageID),
+		ProviderPaymentChargeID: fmt.Sprintf("provider-charge-%d", b.message.MessageID),
+	}
+	return b
+}
+
+// Business sets the business connection the message was received from.
+// Update and EditedUpdate then return business message updates.
+func (b *MessageBuilder) Business(connectionID string) *MessageBuilder {
+	b.message.BusinessConnectionID = connectionID
+	return b
+}
+
+// Message returns the built message.
+//
+// The message does not share memory with the builder, so it stays the same
+// when the builder is changed, and changing it does not change the builder.
+func (b *MessageBuilder) Message() tgbotapi.Message {
+	message := b.message
+
+	if message.From != nil {
+		from := *message.From
+		message.From = &from
+	}
+	if message.Chat != nil {
+		chat := *message.Chat
+		message.Chat = &chat
+	}
+	if message.ReplyToMessage != nil {
+		reply := *message.ReplyToMessage
+		message.ReplyToMessage = &reply
+	}
+	if message.Document != nil {
+		document := *message.Document
+		message.Document = &document
+	}
+	if message.Video != nil {
+		video := *message.Video
+		message.Video = &video
+	}
+	if message.SuccessfulPayment != nil {
+		payment := *message.SuccessfulPayment
+		message.SuccessfulPayment = &payment
+	}
+	message.Entities = append([]tgbotapi.MessageEntity(nil), message.Entities...)
+	message.Photo = append([]tgbotapi.PhotoSize(nil), message.Photo...)
+
+	return message
+}
+
+// Update returns an update with the message, as a channel post for
+// channels.
+func (b *MessageBuilder) Update() tgbotapi.Update {
+	message := b.Message()
+
+	if message.BusinessConnectionID != "" {
+		return tgbotapi.Update{UpdateID: nextUpdateID(), BusinessMessage: &message}
+	}
+	if message.Chat.Type == "channel" {
+		return tgbotapi.Update{UpdateID: nextUpdateID(), ChannelPost: &message}
+	}
+
+	return tgbotapi.Update{UpdateID: nextUpdateID(), Message: &message}
+}
+
+// EditedUpdate returns an update with the message as an edited message.
+func (b *MessageBuilder) EditedUpdate() tgbotapi.Update {
+	message := b.Message()
+	message.EditDate = message.Date + 60
+
+	if message.BusinessConnectionID != "" {
+		return tgbotapi.Update{UpdateID: nextUpdateID(), EditedBusinessMessage: &message}
+	}
+	if message.Chat.Type == "channel" {
+		return tgbotapi.Update{UpdateID: nextUpdateID(), EditedChannelPost: &message}
+	}
+
+	return tgbotapi.Update{UpdateID: nextUpdateID(), EditedMessage: &message}
+}
+
+// JSON returns the update with the message encoded as a webhook body.
+func (b *MessageBuilder) JSON() []byte {
+	return UpdateJSON(b.Update())
+}
+
+// NewMediaGroup builds the updates of an album, with one message per file
+// ID. The caption is set on the first message, as Telegram clients do.
+func NewMediaGroup(groupID, caption string, photoFileIDs ...string) []tgbotapi.Update {
+	updates := make([]tgbotapi.Update, len(photoFileIDs))
+
+	for i, fileID := range photoFileIDs {
+		b := NewMessage().ID(i + 1).Photo(fileID).MediaGroup(groupID)
+		if i == 0 {
+			b.Caption(caption)
+		}
+
+		updates[i] = b.Update()
+	}
+
+	return updates
+}
+
+// CallbackQueryBuilder builds callback query updates.
+type CallbackQueryBuilder struct {
+	query tgbotapi.CallbackQuery
+}
+
+// NewCallbackQuery starts building a callback query from DefaultUser for a
+// button of a message sent by DefaultBot in their private chat.
+func NewCallbackQuery(data string) *CallbackQueryBuilder {
+	user := DefaultUser
+	bot := DefaultBot
+	message := NewTextMessage("Message with buttons").From(user).Message()
+	message.From = &bot
+
+	return &CallbackQueryBuilder{query: tgbotapi.CallbackQuery{
+		ID:           "query-" + strconv.Itoa(int(atomic.LoadInt64(&lastUpdateID))+1),
+		From:         &user,
+		Message:      &message,
+		ChatInstance: strconv.FormatInt(message.Chat.ID, 10),
+		Data:         data,
+	}}
+}
+
+// ID sets the callback query ID.
+func (b *CallbackQueryBuilder) ID(id string) *CallbackQueryBuilder {
+	b.query.ID = id
+	return b
+}
+
+// From sets the user who pressed the button.
+func (b *CallbackQueryBuilder) From(user tgbotapi.User) *CallbackQueryBuilder {
+	b.query.From = &user
+	return b
+}
+
+// Message sets the message with the button.
+func (b *CallbackQueryBuilder) Message(message tgbotapi.Message) *CallbackQueryBuilder {
+	b.query.Message = &message
+	b.query.InlineMessageID = ""
+	if message.Chat != nil {
+		b.query.ChatInstance = strconv.FormatInt(message.Chat.ID, 10)
+	}
+	return b
+}
+
+// InlineMessage makes the button part of an inline message.
+func (b *CallbackQueryBuilder) InlineMessage(inlineMessageID string) *CallbackQueryBuilder {
+	b.query.Message = nil
+	b.query.InlineMessageID = inlineMessageID
+	return b
+}
+
+// CallbackQuery returns the built callback query, which does not share
+// memory with the builder.
+func (b *CallbackQueryBuilder) CallbackQuery() tgbotapi.CallbackQuery {
+	query := b.query
+
+	if query.From != nil {
+		from := *query.From
+		query.From = &from
+	}
+	if query.Message != nil {
+		message := (&MessageBuilder{message: *query.Message}).Message()
+		query.Message = &message
+	}
+
+	return query
+}
+
+// Update returns an update with the callback query.
+func (b *CallbackQueryBuilder) Update() tgbotapi.Update {
+	query := b.CallbackQuery()
+	return tgbotapi.Update{UpdateID: nextUpdateID(), CallbackQuery: &query}
+}
+
+// JSON returns the update encoded as a webhook body.
+func (b *CallbackQueryBuilder) JSON() []byte {
+	return UpdateJSON(b.Update())
+}
+
+// InlineQueryBuilder builds inline query updates.
+type InlineQueryBuilder struct {
+	query tgbotapi.InlineQuery
+}
+
+// NewInlineQuery starts building an inline query from DefaultUser.
+func NewInlineQuery(query string) *InlineQueryBuilder {
+	user := DefaultUser
+
+	return &InlineQueryBuilder{query: tgbotapi.InlineQuery{
+		ID:       "inline-" + strconv.Itoa(int(atomic.LoadInt64(&lastUpdateID))+1),
+		From:     &user,
+		Query:    query,
+		ChatType: "sender",
+	}}
+}
+
+// From sets the user who sent the query.
+func (b *InlineQueryBuilder) From(user tgbotapi.User) *InlineQueryBuilder {
+	b.query.From = &user
+	return b
+}
+
+// Offset sets the offset of the requested results.
+func (b *InlineQueryBuilder) Offset(offset string) *InlineQueryBuilder {
+	b.query.Offset = offset
+	return b
+}
+
+// ChatType sets the type of the chat the query was sent from.
+func (b *InlineQueryBuilder) ChatType(chatType string) *InlineQueryBuilder {
+	b.query.ChatType = chatType
+	return b
+}
+
+// Location sets the location of the user.
+func (b *InlineQueryBuilder) Location(latitude, longitude float64) *InlineQueryBuilder {
+	b.query.Location = &tgbotapi.Location{Latitude: latitude, Longitude: longitude}
+	return b
+}
+
+// Update returns an update with the inline query.
+func (b *InlineQueryBuilder) Update() tgbotapi.Update {
+	query := b.query
+	return tgbotapi.Update{UpdateID: nextUpdateID(), InlineQuery: &query}
+}
+
+// JSON returns the update encoded as a webhook body.
+func (b *InlineQueryBuilder) JSON() []byte {
+	return UpdateJSON(b.Update())
+}
+
+// ChatMemberBuilder builds updates of chat member changes.
+type ChatMemberBuilder struct {
+	updated tgbotapi.ChatMemberUpdated
+}
+
+// NewChatMemberUpdate starts building a change of the status of a user in
+// a chat, such as from "left" to "member". The change is made by the user.
+func NewChatMemberUpdate(chat tgbotapi.Chat, user tgbotapi.User, oldStatus, newStatus string) *ChatMemberBuilder {
+	oldUser, newUser := user, user
+
+	return &ChatMemberBuilder{updated: tgbotapi.ChatMemberUpdated{
+		Chat:          chat,
+		From:          user,
+		Date:          int(DefaultDate.Unix()),
+		OldChatMember: tgbotapi.ChatMember{User: &oldUser, Status: oldStatus},
+		NewChatMember: tgbotapi.ChatMember{User: &newUser, Status: newStatus},
+	}}
+}
+
+// From sets the user who made the change, such as an administrator.
+func (b *ChatMemberBuilder) From(user tgbotapi.User) *ChatMemberBuilder {
+	b.updated.From = user
+	return b
+}
+
+// InviteLink sets the invite link used to join.
+func (b *ChatMemberBuilder) InviteLink(link string) *ChatMemberBuilder {
+	b.updated.InviteLink = &tgbotapi.ChatInviteLink{InviteLink: link}
+	return b
+}
+
+// Update returns a chat_member update.
+func (b *ChatMemberBuilder) Update() tgbotapi.Update {
+	updated := b.updated
+	return tgbotapi.Update{UpdateID: nextUpdateID(), ChatMember: &updated}
+}
+
+// MyChatMemberUpdate returns a my_chat_member update, as sent when the
+// status of the bot itself changes.
+func (b *ChatMemberBuilder) MyChatMemberUpdate() tgbotapi.Update {
+	updated := b.updated
+	return tgbotapi.Update{UpdateID: nextUpdateID(), MyChatMember: &updated}
+}
+
+// JSON returns the chat_member update encoded as a webhook body.
+func (b *ChatMemberBuilder) JSON() []byte {
+	return UpdateJSON(b.Update())
+}
+
+// ChatJoinRequestBuilder builds chat join request updates.
+type ChatJoinRequestBuilder struct {
+	request tgbotapi.ChatJoinRequest
+}
+
+// NewChatJoinRequest starts building a request of DefaultUser to join a
+// chat.
+func NewChatJoinRequest(chat tgbotapi.Chat) *ChatJoinRequestBuilder {
+	return &ChatJoinRequestBuilder{request: tgbotapi.ChatJoinRequest{
+		Chat: chat,
+		From: DefaultUser,
+		Date: int(DefaultDate.Unix()),
+	}}
+}
+
+// From sets the user asking to join.
+func (b *ChatJoinRequestBuilder) From(user tgbotapi.User) *ChatJoinRequestBuilder {
+	b.request.From = user
+	return b
+}
+
+// Bio sets the bio of the user.
+func (b *ChatJoinRequestBuilder) Bio(bio string) *ChatJoinRequestBuilder {
+	b.request.Bio = bio
+	return b
+}
+
+// InviteLink sets the invite link used to send the request.
+func (b *ChatJoinRequestBuilder) InviteLink(link string) *ChatJoinRequestBuilder {
+	b.request.InviteLink = &tgbotapi.ChatInviteLink{InviteLink: link, CreatesJoinRequest: true}
+	return b
+}
+
+// Update returns an update with the join request.
+func (b *ChatJoinRequestBuilder) Update() tgbotapi.Update {
+	request := b.request
+	return tgbotapi.Update{UpdateID: nextUpdateID(), ChatJoinRequest: &request}
+}
+
+// JSON returns the update encoded as a webhook body.
+func (b *ChatJoinRequestBuilder) JSON() []byte {
+	return UpdateJSON(b.Update())
+}
+
+// NewShippingQuery builds a shipping query update from DefaultUser.
+func NewShippingQuery(payload string, address tgbotapi.ShippingAddress) tgbotapi.Update {
+	user := DefaultUser
+
+	return tgbotapi.Update{
+		UpdateID: nextUpdateID(),
+		ShippingQuery: &tgbotapi.ShippingQuery{
+			ID:              "shipping-" + payload,
+			From:            &user,
+			InvoicePayload:  payload,
+			ShippingAddress: &address,
+		},
+	}
+}
+
+// NewPreCheckoutQuery builds a pre-checkout query update from DefaultUser.
+// The amount is in the smallest units of the currency.
+func NewPreCheckoutQuery(payload, currency string, totalAmount int) tgbotapi.Update {
+	user := DefaultUser
+
+	return tgbotapi.Update{
+		UpdateID: nextUpdateID(),
+		PreCheckoutQuery: &tgbotapi.PreCheckoutQuery{
+			ID:             "checkout-" + payload,
+			From:           &user,
+			Currency:       currency,
+			TotalAmount:    totalAmount,
+			InvoicePayload: payload,
+		},
+	}
+}
+
+// NewMessageReaction builds an update of DefaultUser setting emoji
+// reactions on a message, which had no reactions of the user before.
+func NewMessageReaction(message tgbotapi.Message, emoji ...string) tgbotapi.Update {
+	user := DefaultUser
+
+	reactions := make([]tgbotapi.ReactionType, 0, len(emoji))
+	for _, e := range emoji {
+		reactions = append(reactions, tgbotapi.NewReactionEmoji(e))
+	}
+
+	var chat tgbotapi.Chat
+	if message.Chat != nil {
+		chat = *message.Chat
+	}
+
+	return tgbotapi.Update{
+		UpdateID: nextUpdateID(),
+		MessageReaction: &tgbotapi.MessageReactionUpdated{
+			Chat:        chat,
+			MessageID:   message.MessageID,
+			User:        &user,
+			Date:        int(DefaultDate.Unix()),
+			OldReaction: []tgbotapi.ReactionType{},
+			NewReaction: reactions,
+		},
+	}
+}
+
+// NewBusinessConnection builds an update of DefaultUser connecting the bot
+// to their business account.
+func NewBusinessConnection(connectionID string, canReply bool) tgbotapi.Update {
+	user := DefaultUser
+
+	return tgbotapi.Update{
+		UpdateID: nextUpdateID(),
+		BusinessConnection: &tgbotapi.BusinessConnection{
+			ID:         connectionID,
+			User:       user,
+			UserChatID: user.ID,
+			Date:       int(DefaultDate.Unix()),
+			CanReply:   canReply,
+			IsEnabled:  true,
+		},
+	}
+}
@@ -0,0 +1,144 @@
+package telegramtest
+
+import (
+	"encoding/json"
+	"testing"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+func TestCommandBuilder(t *testing.T) {
+	update := NewCommand("start", "ref_42").Update()
+
+	if !update.Message.IsCommand() || update.Message.Command() != "start" || update.Message.CommandArguments() != "ref_42" {
+		t.Fatalf("unexpected command message %+v", update.Message)
+	}
+
+	if update.SentFrom().ID != DefaultUser.ID || update.FromChat().ID != DefaultUser.ID {
+		t.Fatal("expected the default user in their private chat")
+	}
+
+	group := NewCommand("help@test_bot", "").Chat(GroupChat(-100, "Group")).Update()
+	if group.Message.Command() != "help" || group.Message.Entities[0].Length != len("/help@test_bot") {
+		t.Fatalf("unexpected command entity %+v", group.Message.Entities)
+	}
+
+	if update.UpdateID == group.UpdateID {
+		t.Fatal("update IDs should be distinct")
+	}
+}
+
+func TestBuildersJSON(t *testing.T) {
+	user := tgbotapi.User{ID: 5, FirstName: "Ann", LanguageCode: "de"}
+
+	for _, data := range [][]byte{
+		NewTextMessage("hi").From(user).JSON(),
+		NewCallbackQuery("data").From(user).JSON(),
+		NewInlineQuery("q").From(user).JSON(),
+		NewChatMemberUpdate(GroupChat(-1, "G"), user, "left", "member").JSON(),
+		NewChatJoinRequest(GroupChat(-1, "G")).From(user).JSON(),
+	} {
+		var update tgbotapi.Update
+		if err := json.Unmarshal(data, &update); err != nil {
+			t.Fatal(err)
+		}
+
+		if from := update.SentFrom(); from == nil || from.ID != user.ID {
+			t.Errorf("expected an update from user 5, got %s", data)
+		}
+	}
+}
+
+func TestBuildersWebhook(t *testing.T) {
+	bot := &tgbotapi.BotAPI{}
+
+	update, err := bot.HandleUpdate(WebhookRequest(NewPreCheckoutQuery("order-1", "XTR", 100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if update.PreCheckoutQuery == nil || update.PreCheckoutQuery.InvoicePayload != "order-1" {
+		t.Fatalf("unexpected update %+v", update)
+	}
+}
+
+func TestMediaGroupBuilder(t *testing.T) {
+	updates := NewMediaGroup("album", "caption", "a", "b", "c")
+
+	if len(updates) != 3 || updates[0].Message.Caption != "caption" || updates[1].Message.Caption != "" {
+		t.Fatalf("unexpected media group %+v", updates)
+	}
+
+	for _, update := range updates {
+		if update.Message.MediaGroupID != "album" || len(update.Message.Photo) != 1 {
+			t.Errorf("unexpected album message %+v", update.Message)
+		}
+	}
+}
+
+func TestMessageReactionBuilder(t *testing.T) {
+	message := NewTextMessage("hi").Chat(GroupChat(-5, "G")).Message()
+
+	var update tgbotapi.Update
+	if err := json.Unmarshal(UpdateJSON(NewMessageReaction(message, "👍")), &update); err != nil {
+		t.Fatal(err)
+	}
+
+	reaction := update.MessageReaction
+	if reaction == nil || len(reaction.NewReaction) != 1 || reaction.NewReaction[0].Emoji != "👍" {
+		t.Fatalf("unexpected reaction %+v", reaction)
+	}
+
+	if chat := update.FromChat(); chat == nil || chat.ID != -5 {
+		t.Errorf("unexpected chat %+v", chat)
+	}
+	if from := update.SentFrom(); from == nil || from.ID != DefaultUser.ID {
+		t.Errorf("unexpected sender %+v", from)
+	}
+}
+
+func TestBusinessMessageBuilder(t *testing.T) {
+	update := NewTextMessage("hello").Business("conn-1").Update()
+
+	if update.BusinessMessage == nil || update.Message != nil {
+		t.Fatalf("expected a business message update, got %+v", update)
+	}
+	if chat := update.FromChat(); chat == nil || chat.ID != DefaultUser.ID {
+		t.Errorf("unexpected chat %+v", chat)
+	}
+
+	params, err := tgbotapi.NewMessageReply(update.BusinessMessage, "hi").Params()
+	if err != nil || params["business_connection_id"] != "conn-1" {
+		t.Errorf("reply not sent on behalf of the business account: %v (%v)", params, err)
+	}
+
+	connection := NewBusinessConnection("conn-1", true)
+	if from := connection.SentFrom(); from == nil || from.ID != DefaultUser.ID {
+		t.Errorf("unexpected business connection sender %+v", from)
+	}
+}
+
+func TestBuildersCopy(t *testing.T) {
+	b := NewCommand("start", "")
+	message := b.Message()
+
+	b.From(tgbotapi.User{ID: 7, FirstName: "Ann"}).Entity(tgbotapi.MessageEntity{Type: tgbotapi.EntityBold, Offset: 1, Length: 5})
+	message.Chat.Title = "changed"
+	message.Entities[0].Length = 100
+
+	if message.From.ID != DefaultUser.ID || message.Chat.ID != DefaultUser.ID || len(message.Entities) != 1 {
+		t.Fatalf("message changed with the builder %+v", message)
+	}
+	if built := b.Message(); built.Chat.Title != "" || built.Entities[0].Length != len("/start") {
+		t.Fatalf("builder changed with the message %+v", built)
+	}
+
+	qb := NewCallbackQuery("data")
+	query := qb.CallbackQuery()
+	query.From.ID = 7
+	query.Message.Chat.ID = 7
+
+	if built := qb.CallbackQuery(); built.From.ID != DefaultUser.ID || built.Message.Chat.ID != DefaultUser.ID {
+		t.Fatalf("builder changed with the callback query %+v", built)
+	}
+}
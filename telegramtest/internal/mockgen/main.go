@@ -0,0 +1,153 @@
+// Command mockgen generates the telegramtest.MockBot methods from an
+// interface of the tgbotapi package.
+//
+// Usage:
+//
+//	go run ./internal/mockgen -source ../interfaces.go -interface BotClient -output mock_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	source := flag.String("source", "../interfaces.go", "file declaring the interface")
+	name := flag.String("interface", "BotClient", "interface to mock")
+	output := flag.String("output", "mock_gen.go", "generated file")
+	mock := flag.String("mock", "MockBot", "name of the mock type")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *source, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	interfaces := map[string]*ast.InterfaceType{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+				interfaces[spec.Name.Name] = iface
+			}
+		}
+		return true
+	})
+
+	if interfaces[*name] == nil {
+		log.Fatalf("interface %s not found in %s", *name, *source)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen from %s; DO NOT EDIT.\n\n", *name)
+	fmt.Fprintf(&buf, "package telegramtest\n\n")
+	fmt.Fprintf(&buf, "import tgbotapi \"github.com/preaverage/telegram-bot-api\"\n")
+
+	for _, method := range methods(interfaces, *name) {
+		writeMethod(&buf, *mock, method)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %s\n%s", err, buf.String())
+	}
+
+	if err := os.WriteFile(*output, formatted, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// methods returns the methods of an interface, including the ones of the
+// interfaces it embeds, in declaration order.
+func methods(interfaces map[string]*ast.InterfaceType, name string) []*ast.Field {
+	var fields []*ast.Field
+
+	for _, field := range interfaces[name].Methods.List {
+		if len(field.Names) == 0 {
+			embedded, ok := field.Type.(*ast.Ident)
+			if !ok || interfaces[embedded.Name] == nil {
+				log.Fatalf("unsupported embedded interface in %s", name)
+			}
+			fields = append(fields, methods(interfaces, embedded.Name)...)
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func writeMethod(buf *bytes.Buffer, mock string, method *ast.Field) {
+	name := method.Names[0].Name
+	fn := method.Type.(*ast.FuncType)
+
+	var params, args []string
+	if fn.Params != nil {
+		for i, param := range fn.Params.List {
+			paramNames := param.Names
+			if len(paramNames) == 0 {
+				paramNames = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i))}
+			}
+
+			for _, paramName := range paramNames {
+				params = append(params, paramName.Name+" "+typeString(param.Type))
+				args = append(args, paramName.Name)
+			}
+		}
+	}
+
+	var results []string
+	if fn.Results != nil {
+		for _, result := range fn.Results.List {
+			results = append(results, typeString(result.Type))
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// %s records the call and returns the scripted results.\n", name)
+	fmt.Fprintf(buf, "func (m *%s) %s(%s) (%s) {\n", mock, name, strings.Join(params, ", "), strings.Join(results, ", "))
+
+	callArgs := append([]string{fmt.Sprintf("%q", name), fmt.Sprint(len(results))}, args...)
+	fmt.Fprintf(buf, "\tresults := m.Called(%s)\n\n", strings.Join(callArgs, ", "))
+
+	var names []string
+	for i, result := range results {
+		fmt.Fprintf(buf, "\tr%d, _ := results[%d].(%s)\n", i, i, result)
+		names = append(names, fmt.Sprintf("r%d", i))
+	}
+
+	fmt.Fprintf(buf, "\n\treturn %s\n}\n", strings.Join(names, ", "))
+}
+
+// typeString returns the source of a type, qualifying exported types with
+// the tgbotapi package.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "tgbotapi." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+
+	log.Fatalf("unsupported type %T", expr)
+	return ""
+}
@@ -0,0 +1,663 @@
+package telegramtest
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+// mediaFields are the file params of the methods sending media.
+var mediaFields = map[string]string{
+	"sendphoto":     "photo",
+	"senddocument":  "document",
+	"sendaudio":     "audio",
+	"sendvideo":     "video",
+	"sendvoice":     "voice",
+	"sendanimation": "animation",
+	"sendvideonote": "video_note",
+	"sendsticker":   "sticker",
+}
+
+func (s *Server) defaultHandlers() map[string]HandlerFunc {
+	handlers := map[string]HandlerFunc{
+		"getme":                  s.getMe,
+		"logout":                 s.ok,
+		"close":                  s.ok,
+		"sendchataction":         s.ok,
+		"leavechat":              s.ok,
+		"sendmessage":            s.sendMessage,
+		"sendmediagroup":         s.sendMediaGroup,
+		"forwardmessage":         s.forwardMessage,
+		"copymessage":            s.copyMessage,
+		"editmessagetext":        s.editMessageText,
+		"editmessagecaption":     s.editMessageCaption,
+		"editmessagereplymarkup": s.editMessageReplyMarkup,
+		"deletemessage":          s.deleteMessage,
+		"getupdates":             s.getUpdates,
+		"setwebhook":             s.setWebhook,
+		"deletewebhook":          s.deleteWebhook,
+		"getwebhookinfo":         s.getWebhookInfo,
+		"answercallbackquery":    s.answerCallbackQuery,
+		"getchat":                s.getChat,
+		"getchatmember":          s.getChatMember,
+		"getfile":                s.getFile,
+		"getmyname":              s.getMyText("name"),
+		"setmyname":              s.setMyText("name"),
+		"getmydescription":       s.getMyText("description"),
+		"setmydescription":       s.setMyText("description"),
+		"getmyshortdescription":  s.getMyText("short_description"),
+		"setmyshortdescription":  s.setMyText("short_description"),
+	}
+
+	for method := range mediaFields {
+		handlers[method] = s.sendMedia
+	}
+
+	return handlers
+}
+
+func (s *Server) ok(r *Request) (interface{}, error) {
+	return true, nil
+}
+
+func (s *Server) getMe(r *Request) (interface{}, error) {
+	return s.Bot, nil
+}
+
+// newMessage creates a message sent by the bot from the common params of
+// send methods. It must be called with the lock held.
+func (s *Server) newMessage(r *Request) (*tgbotapi.Message, error) {
+	chat, err := s.findChat(r.Params.Get("chat_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	bot := s.Bot
+	message := &tgbotapi.Message{
+		MessageID:           s.nextMessageID(chat.ID),
+		From:                &bot,
+		Date:                int(s.Now().Unix()),
+		Chat:                chat,
+		HasProtectedContent: r.Bool("protect_content"),
+
+		BusinessConnectionID: r.Params.Get("business_connection_id"),
+	}
+
+	if thread := r.Int("message_thread_id"); thread != 0 && chat.IsForum {
+		message.MessageThreadID = thread
+		message.IsTopicMessage = true
+	}
+
+	replyParameters := tgbotapi.ReplyParameters{
+		MessageID:                r.Int("reply_to_message_id"),
+		AllowSendingWithoutReply: r.Bool("allow_sending_without_reply"),
+	}
+	if err := r.JSON("reply_parameters", &replyParameters); err != nil {
+		return nil, err
+	}
+
+	if replyChat := replyParameters.ChatID; replyChat != 0 && replyChat != chat.ID {
+		reply, ok := s.messages[replyChat][replyParameters.MessageID]
+		if !ok {
+			return nil, BadRequest("replied message not found")
+		}
+
+		message.ExternalReply = externalReply(reply)
+	} else if replyTo := replyParameters.MessageID; replyTo != 0 {
+		reply, ok := s.messages[chat.ID][replyTo]
+		if ok {
+			replyCopy := *reply
+			replyCopy.ReplyToMessage = nil
+			message.ReplyToMessage = &replyCopy
+		} else if !replyParameters.AllowSendingWithoutReply {
+			return nil, BadRequest("replied message not found")
+		}
+	}
+
+	if err := s.setReplyMarkup(r, message); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// externalReply describes a message of another chat being replied to.
+func externalReply(reply *tgbotapi.Message) *tgbotapi.ExternalReplyInfo {
+	origin := tgbotapi.MessageOrigin{Type: "user", Date: reply.Date, SenderUser: reply.From}
+	if reply.Chat.Type == "channel" {
+		origin = tgbotapi.MessageOrigin{Type: "channel", Date: reply.Date, Chat: reply.Chat, MessageID: reply.MessageID}
+	}
+
+	return &tgbotapi.ExternalReplyInfo{
+		Origin:    origin,
+		Chat:      reply.Chat,
+		MessageID: reply.MessageID,
+		Photo:     reply.Photo,
+		Document:  reply.Document,
+	}
+}
+
+// setReplyMarkup sets the inline keyboard of a message, ignoring other
+// kinds of reply markup which are not part of messages.
+func (s *Server) setReplyMarkup(r *Request, message *tgbotapi.Message) error {
+	var markup struct {
+		InlineKeyboard [][]tgbotapi.InlineKeyboardButton `json:"inline_keyboard"`
+	}
+	if err := r.JSON("reply_markup", &markup); err != nil {
+		return err
+	}
+
+	// Like Telegram, edits without a keyboard remove the current one.
+	if markup.InlineKeyboard != nil {
+		message.ReplyMarkup = &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: markup.InlineKeyboard}
+	} else {
+		message.ReplyMarkup = nil
+	}
+
+	return nil
+}
+
+// send stores a message sent by the bot. It must be called with the lock
+// held.
+func (s *Server) send(message *tgbotapi.Message) tgbotapi.Message {
+	s.storeMessage(message)
+	s.sent = append(s.sent, *message)
+
+	return *message
+}
+
+func (s *Server) sendMessage(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	text := r.Params.Get("text")
+	if strings.TrimSpace(text) == "" {
+		return nil, BadRequest("message text is empty")
+	}
+
+	message, err := s.newMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	message.Text = text
+	if err := r.JSON("entities", &message.Entities); err != nil {
+		return nil, err
+	}
+
+	return s.send(message), nil
+}
+
+// fileParam resolves the value of a file param, which is either an
+// attached upload, a known file ID or a URL. Uploads are also looked up by
+// the name of the param. It must be called with the lock held.
+func (s *Server) fileParam(r *Request, field, value string) (*storedFile, error) {
+	if strings.HasPrefix(value, "attach://") {
+		field, value = strings.TrimPrefix(value, "attach://"), ""
+	}
+
+	if upload, ok := r.Files[field]; ok {
+		return s.addFile(upload.Name, upload.Data), nil
+	}
+
+	if value == "" {
+		return nil, BadRequest(fmt.Sprintf("there is no %s in the request", field))
+	}
+
+	if f, ok := s.files[value]; ok {
+		return f, nil
+	}
+
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		return s.addFile(value[strings.LastIndex(value, "/")+1:], nil), nil
+	}
+
+	return nil, BadRequest("wrong file identifier/HTTP URL specified")
+}
+
+// setMedia sets the media field of a message for a file.
+func setMedia(message *tgbotapi.Message, kind string, f *storedFile) {
+	id, uniqueID, size := f.file.FileID, f.file.FileUniqueID, f.file.FileSize
+
+	switch kind {
+	case "photo":
+		message.Photo = []tgbotapi.PhotoSize{{FileID: id, FileUniqueID: uniqueID, FileSize: size}}
+	case "document":
+		message.Document = &tgbotapi.Document{FileID: id, FileUniqueID: uniqueID, FileName: f.name, FileSize: size}
+	case "audio":
+		message.Audio = &tgbotapi.Audio{FileID: id, FileUniqueID: uniqueID, FileName: f.name, FileSize: size}
+	case "video":
+		message.Video = &tgbotapi.Video{FileID: id, FileUniqueID: uniqueID, FileName: f.name, FileSize: size}
+	case "voice":
+		message.Voice = &tgbotapi.Voice{FileID: id, FileUniqueID: uniqueID, FileSize: size}
+	case "animation":
+		message.Animation = &tgbotapi.Animation{FileID: id, FileUniqueID: uniqueID, FileName: f.name, FileSize: size}
+	case "video_note":
+		message.VideoNote = &tgbotapi.VideoNote{FileID: id, FileUniqueID: uniqueID, FileSize: size}
+	case "sticker":
+		message.Sticker = &tgbotapi.Sticker{FileID: id, FileUniqueID: uniqueID, FileSize: size}
+	}
+}
+
+func (s *Server) sendMedia(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	field := mediaFields[strings.ToLower(r.Method)]
+
+	f, err := s.fileParam(r, field, r.Params.Get(field))
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.newMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	setMedia(message, field, f)
+	message.Caption = r.Params.Get("caption")
+	if err := r.JSON("caption_entities", &message.CaptionEntities); err != nil {
+		return nil, err
+	}
+
+	return s.send(message), nil
+}
+
+func (s *Server) sendMediaGroup(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var media []struct {
+		Type    string `json:"type"`
+		Media   string `json:"media"`
+		Caption string `json:"caption"`
+	}
+	if err := r.JSON("media", &media); err != nil {
+		return nil, err
+	}
+
+	if len(media) < 2 || len(media) > 10 {
+		return nil, BadRequest("wrong number of messages in the media group")
+	}
+
+	groupID := fmt.Sprintf("group-%d", len(s.sent)+1)
+	messages := make([]tgbotapi.Message, 0, len(media))
+
+	for _, item := range media {
+		f, err := s.fileParam(r, "media", item.Media)
+		if err != nil {
+			return nil, err
+		}
+
+		message, err := s.newMessage(r)
+		if err != nil {
+			return nil, err
+		}
+
+		setMedia(message, item.Type, f)
+		message.Caption = item.Caption
+		message.MediaGroupID = groupID
+
+		messages = append(messages, s.send(message))
+	}
+
+	return messages, nil
+}
+
+// sourceMessage finds the message referenced by from_chat_id and
+// message_id. It must be called with the lock held.
+func (s *Server) sourceMessage(r *Request) (*tgbotapi.Message, error) {
+	fromChat, err := s.findChat(r.Params.Get("from_chat_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	source, ok := s.messages[fromChat.ID][r.Int("message_id")]
+	if !ok {
+		return nil, BadRequest("message to copy not found")
+	}
+
+	return source, nil
+}
+
+func (s *Server) forwardMessage(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, err := s.sourceMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.newMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	forwarded := *source
+	forwarded.MessageID, forwarded.From, forwarded.Date, forwarded.Chat = message.MessageID, message.From, message.Date, message.Chat
+	forwarded.ForwardFrom = source.From
+	forwarded.ForwardDate = source.Date
+	if source.Chat.Type == "channel" {
+		forwarded.ForwardFromChat = source.Chat
+		forwarded.ForwardFromMessageID = source.MessageID
+	}
+
+	return s.send(&forwarded), nil
+}
+
+func (s *Server) copyMessage(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, err := s.sourceMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.newMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := *source
+	copied.MessageID, copied.From, copied.Date, copied.Chat = message.MessageID, message.From, message.Date, message.Chat
+	copied.ReplyToMessage, copied.ReplyMarkup = message.ReplyToMessage, message.ReplyMarkup
+	if _, ok := r.Params["caption"]; ok {
+		copied.Caption = r.Params.Get("caption")
+	}
+
+	s.send(&copied)
+
+	return tgbotapi.MessageID{MessageID: copied.MessageID}, nil
+}
+
+// editedMessage finds the message to edit. Inline messages are not stored,
+// so editing them returns nil. It must be called with the lock held.
+func (s *Server) editedMessage(r *Request) (*tgbotapi.Message, error) {
+	if r.Params.Get("inline_message_id") != "" {
+		return nil, nil
+	}
+
+	chat, err := s.findChat(r.Params.Get("chat_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	message, ok := s.messages[chat.ID][r.Int("message_id")]
+	if !ok {
+		return nil, BadRequest("message to edit not found")
+	}
+
+	return message, nil
+}
+
+// edit applies an edit to a message, failing if it did not change like
+// Telegram does.
+func (s *Server) edit(r *Request, apply func(message *tgbotapi.Message) error) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message, err := s.editedMessage(r)
+	if err != nil || message == nil {
+		return true, err
+	}
+
+	edited := *message
+	if err := apply(&edited); err != nil {
+		return nil, err
+	}
+	if err := s.setReplyMarkup(r, &edited); err != nil {
+		return nil, err
+	}
+
+	if reflect.DeepEqual(edited, *message) {
+		return nil, BadRequest("message is not modified: specified new message content and reply markup are exactly the same as a current content and reply markup of the message")
+	}
+
+	edited.EditDate = int(s.Now().Unix())
+	*message = edited
+
+	return edited, nil
+}
+
+func (s *Server) editMessageText(r *Request) (interface{}, error) {
+	return s.edit(r, func(message *tgbotapi.Message) error {
+		if strings.TrimSpace(r.Params.Get("text")) == "" {
+			return BadRequest("message text is empty")
+		}
+
+		message.Text = r.Params.Get("text")
+		message.Entities = nil
+
+		return r.JSON("entities", &message.Entities)
+	})
+}
+
+func (s *Server) editMessageCaption(r *Request) (interface{}, error) {
+	return s.edit(r, func(message *tgbotapi.Message) error {
+		message.Caption = r.Params.Get("caption")
+		message.CaptionEntities = nil
+
+		return r.JSON("caption_entities", &message.CaptionEntities)
+	})
+}
+
+func (s *Server) editMessageReplyMarkup(r *Request) (interface{}, error) {
+	return s.edit(r, func(message *tgbotapi.Message) error {
+		return nil
+	})
+}
+
+func (s *Server) deleteMessage(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chat, err := s.findChat(r.Params.Get("chat_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	messageID := r.Int("message_id")
+	if _, ok := s.messages[chat.ID][messageID]; !ok {
+		return nil, BadRequest("message to delete not found")
+	}
+	delete(s.messages[chat.ID], messageID)
+
+	return true, nil
+}
+
+func (s *Server) getUpdates(r *Request) (interface{}, error) {
+	offset := r.Int("offset")
+	limit := r.Int("limit")
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	deadline := time.NewTimer(time.Duration(r.Int("timeout")) * time.Second)
+	defer deadline.Stop()
+
+	for {
+		s.mu.Lock()
+		if s.webhook.URL != "" {
+			s.mu.Unlock()
+			return nil, tgbotapi.Error{
+				Code:    http.StatusConflict,
+				Message: "Conflict: can't use getUpdates method while webhook is active; use deleteWebhook to delete the webhook first",
+			}
+		}
+
+		// Updates before the offset are confirmed and forgotten.
+		for len(s.updates) > 0 && s.updates[0].UpdateID < offset {
+			s.updates = s.updates[1:]
+		}
+
+		updates := s.updates
+		if len(updates) > limit {
+			updates = updates[:limit]
+		}
+		updates = append([]tgbotapi.Update{}, updates...)
+		changed := s.updatesChanged
+		s.mu.Unlock()
+
+		if len(updates) > 0 {
+			return updates, nil
+		}
+
+		select {
+		case <-changed:
+		case <-deadline.C:
+			return updates, nil
+		case <-s.closed:
+			return updates, nil
+		}
+	}
+}
+
+func (s *Server) setWebhook(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhookURL := r.Params.Get("url")
+	if webhookURL != "" && !strings.HasPrefix(webhookURL, "https://") {
+		return nil, BadRequest("bad webhook: An HTTPS URL must be provided for webhook")
+	}
+
+	s.webhook = tgbotapi.WebhookInfo{
+		URL:                  webhookURL,
+		HasCustomCertificate: len(r.Files["certificate"].Data) > 0,
+		IPAddress:            r.Params.Get("ip_address"),
+		MaxConnections:       r.Int("max_connections"),
+	}
+	if r.Bool("drop_pending_updates") {
+		s.updates = nil
+	}
+
+	return true, nil
+}
+
+func (s *Server) deleteWebhook(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhook = tgbotapi.WebhookInfo{}
+	if r.Bool("drop_pending_updates") {
+		s.updates = nil
+	}
+
+	return true, nil
+}
+
+func (s *Server) getWebhookInfo(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.webhookInfo(), nil
+}
+
+func (s *Server) answerCallbackQuery(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queryID := r.Params.Get("callback_query_id")
+	for _, answer := range s.callbackAnswers {
+		if answer.CallbackQueryID == queryID {
+			return nil, BadRequest("query is too old and response timeout expired or query ID is invalid")
+		}
+	}
+
+	s.callbackAnswers = append(s.callbackAnswers, tgbotapi.CallbackConfig{
+		CallbackQueryID: queryID,
+		Text:            r.Params.Get("text"),
+		ShowAlert:       r.Bool("show_alert"),
+		URL:             r.Params.Get("url"),
+		CacheTime:       r.Int("cache_time"),
+	})
+
+	return true, nil
+}
+
+func (s *Server) getChat(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chat, err := s.findChat(r.Params.Get("chat_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+func (s *Server) getChatMember(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chat, err := s.findChat(r.Params.Get("chat_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	userID := r.Int64("user_id")
+	if member, ok := s.members[chat.ID][userID]; ok {
+		return member, nil
+	}
+
+	return tgbotapi.ChatMember{User: &tgbotapi.User{ID: userID}, Status: "member"}, nil
+}
+
+func (s *Server) getFile(r *Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[r.Params.Get("file_id")]
+	if !ok {
+		return nil, BadRequest("invalid file_id")
+	}
+
+	return f.file, nil
+}
+
+// getMyText returns a text of the bot profile, such as its name. Like
+// Telegram, languages without their own text get the default one.
+func (s *Server) getMyText(param string) HandlerFunc {
+	return func(r *Request) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		texts := s.profile[param]
+		text, ok := texts[r.Params.Get("language_code")]
+		if !ok {
+			text = texts[""]
+		}
+
+		return map[string]string{param: text}, nil
+	}
+}
+
+// setMyText sets a text of the bot profile for a language, or removes it
+// if the text is empty.
+func (s *Server) setMyText(param string) HandlerFunc {
+	return func(r *Request) (interface{}, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.profile[param] == nil {
+			s.profile[param] = map[string]string{}
+		}
+
+		language := r.Params.Get("language_code")
+		if text := r.Params.Get(param); text != "" {
+			s.profile[param][language] = text
+		} else {
+			delete(s.profile[param], language)
+		}
+
+		return true, nil
+	}
+}
@@ -0,0 +1,100 @@
+package telegramtest
+
+import (
+	"sync"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+//go:generate go run ./internal/mockgen -source ../interfaces.go -interface BotClient -output mock_gen.go
+
+// Call is a call of a MockBot method.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// MockBot is a tgbotapi.BotClient recording calls and returning scripted
+// results, for testing code without any HTTP requests.
+//
+// Methods return the results set with ReturnOnce or Return, or zero values
+// otherwise. Request returns a successful response by default.
+//
+//	bot := telegramtest.NewMockBot()
+//	bot.ReturnOnce("Send", tgbotapi.Message{MessageID: 1}, nil)
+//	bot.ReturnOnce("Send", tgbotapi.Message{}, telegramtest.Forbidden("bot was blocked by the user"))
+type MockBot struct {
+	mu       sync.Mutex
+	calls    []Call
+	once     map[string][][]interface{}
+	defaults map[string][]interface{}
+}
+
+var _ tgbotapi.BotClient = (*MockBot)(nil)
+
+// NewMockBot creates a MockBot.
+func NewMockBot() *MockBot {
+	return &MockBot{
+		once: map[string][][]interface{}{},
+		defaults: map[string][]interface{}{
+			"Request": {&tgbotapi.APIResponse{Ok: true, Result: []byte("true")}, nil},
+		},
+	}
+}
+
+// ReturnOnce sets the results of the next call of a method. Calling it
+// several times queues results for following calls.
+func (m *MockBot) ReturnOnce(method string, results ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.once[method] = append(m.once[method], results)
+}
+
+// Return sets the results of all calls of a method without queued results.
+func (m *MockBot) Return(method string, results ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.defaults[method] = results
+}
+
+// Calls returns all recorded calls.
+func (m *MockBot) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the recorded calls of a method.
+func (m *MockBot) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Called records a call and returns its n results. It is used by the
+// generated methods.
+func (m *MockBot) Called(method string, n int, args ...interface{}) []interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+
+	results := m.defaults[method]
+	if queued := m.once[method]; len(queued) > 0 {
+		results = queued[0]
+		m.once[method] = queued[1:]
+	}
+
+	padded := make([]interface{}, n)
+	copy(padded, results)
+
+	return padded
+}
@@ -0,0 +1,405 @@
+// Code generated by mockgen from BotClient; DO NOT EDIT.
+
+package telegramtest
+
+import tgbotapi "github.com/preaverage/telegram-bot-api"
+
+// Request records the call and returns the scripted results.
+func (m *MockBot) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	results := m.Called("Request", 2, c)
+
+	r0, _ := results[0].(*tgbotapi.APIResponse)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// Send records the call and returns the scripted results.
+func (m *MockBot) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	results := m.Called("Send", 2, c)
+
+	r0, _ := results[0].(tgbotapi.Message)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// SendMediaGroup records the call and returns the scripted results.
+func (m *MockBot) SendMediaGroup(config tgbotapi.MediaGroupConfig) ([]tgbotapi.Message, error) {
+	results := m.Called("SendMediaGroup", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.Message)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetMe records the call and returns the scripted results.
+func (m *MockBot) GetMe() (tgbotapi.User, error) {
+	results := m.Called("GetMe", 2)
+
+	r0, _ := results[0].(tgbotapi.User)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetFile records the call and returns the scripted results.
+func (m *MockBot) GetFile(config tgbotapi.FileConfig) (tgbotapi.File, error) {
+	results := m.Called("GetFile", 2, config)
+
+	r0, _ := results[0].(tgbotapi.File)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetFileDirectURL records the call and returns the scripted results.
+func (m *MockBot) GetFileDirectURL(fileID string) (string, error) {
+	results := m.Called("GetFileDirectURL", 2, fileID)
+
+	r0, _ := results[0].(string)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetUserProfilePhotos records the call and returns the scripted results.
+func (m *MockBot) GetUserProfilePhotos(config tgbotapi.UserProfilePhotosConfig) (tgbotapi.UserProfilePhotos, error) {
+	results := m.Called("GetUserProfilePhotos", 2, config)
+
+	r0, _ := results[0].(tgbotapi.UserProfilePhotos)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetUpdates records the call and returns the scripted results.
+func (m *MockBot) GetUpdates(config tgbotapi.UpdateConfig) ([]tgbotapi.Update, error) {
+	results := m.Called("GetUpdates", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.Update)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetWebhookInfo records the call and returns the scripted results.
+func (m *MockBot) GetWebhookInfo() (tgbotapi.WebhookInfo, error) {
+	results := m.Called("GetWebhookInfo", 2)
+
+	r0, _ := results[0].(tgbotapi.WebhookInfo)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetChat records the call and returns the scripted results.
+func (m *MockBot) GetChat(config tgbotapi.ChatInfoConfig) (tgbotapi.Chat, error) {
+	results := m.Called("GetChat", 2, config)
+
+	r0, _ := results[0].(tgbotapi.Chat)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetChatAdministrators records the call and returns the scripted results.
+func (m *MockBot) GetChatAdministrators(config tgbotapi.ChatAdministratorsConfig) ([]tgbotapi.ChatMember, error) {
+	results := m.Called("GetChatAdministrators", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.ChatMember)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetChatMembersCount records the call and returns the scripted results.
+func (m *MockBot) GetChatMembersCount(config tgbotapi.ChatMemberCountConfig) (int, error) {
+	results := m.Called("GetChatMembersCount", 2, config)
+
+	r0, _ := results[0].(int)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetChatMember records the call and returns the scripted results.
+func (m *MockBot) GetChatMember(config tgbotapi.GetChatMemberConfig) (tgbotapi.ChatMember, error) {
+	results := m.Called("GetChatMember", 2, config)
+
+	r0, _ := results[0].(tgbotapi.ChatMember)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetGameHighScores records the call and returns the scripted results.
+func (m *MockBot) GetGameHighScores(config tgbotapi.GetGameHighScoresConfig) ([]tgbotapi.GameHighScore, error) {
+	results := m.Called("GetGameHighScores", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.GameHighScore)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetInviteLink records the call and returns the scripted results.
+func (m *MockBot) GetInviteLink(config tgbotapi.ChatInviteLinkConfig) (string, error) {
+	results := m.Called("GetInviteLink", 2, config)
+
+	r0, _ := results[0].(string)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetStickerSet records the call and returns the scripted results.
+func (m *MockBot) GetStickerSet(config tgbotapi.GetStickerSetConfig) (tgbotapi.StickerSet, error) {
+	results := m.Called("GetStickerSet", 2, config)
+
+	r0, _ := results[0].(tgbotapi.StickerSet)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// StopPoll records the call and returns the scripted results.
+func (m *MockBot) StopPoll(config tgbotapi.StopPollConfig) (tgbotapi.Poll, error) {
+	results := m.Called("StopPoll", 2, config)
+
+	r0, _ := results[0].(tgbotapi.Poll)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetMyCommands records the call and returns the scripted results.
+func (m *MockBot) GetMyCommands() ([]tgbotapi.BotCommand, error) {
+	results := m.Called("GetMyCommands", 2)
+
+	r0, _ := results[0].([]tgbotapi.BotCommand)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetMyCommandsWithConfig records the call and returns the scripted results.
+func (m *MockBot) GetMyCommandsWithConfig(config tgbotapi.GetMyCommandsConfig) ([]tgbotapi.BotCommand, error) {
+	results := m.Called("GetMyCommandsWithConfig", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.BotCommand)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// CopyMessage records the call and returns the scripted results.
+func (m *MockBot) CopyMessage(config tgbotapi.CopyMessageConfig) (tgbotapi.MessageID, error) {
+	results := m.Called("CopyMessage", 2, config)
+
+	r0, _ := results[0].(tgbotapi.MessageID)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// AnswerWebAppQuery records the call and returns the scripted results.
+func (m *MockBot) AnswerWebAppQuery(config tgbotapi.AnswerWebAppQueryConfig) (tgbotapi.SentWebAppMessage, error) {
+	results := m.Called("AnswerWebAppQuery", 2, config)
+
+	r0, _ := results[0].(tgbotapi.SentWebAppMessage)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetMyDefaultAdministratorRights records the call and returns the scripted results.
+func (m *MockBot) GetMyDefaultAdministratorRights(config tgbotapi.GetMyDefaultAdministratorRightsConfig) (tgbotapi.ChatAdministratorRights, error) {
+	results := m.Called("GetMyDefaultAdministratorRights", 2, config)
+
+	r0, _ := results[0].(tgbotapi.ChatAdministratorRights)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// CopyMessages records the call and returns the scripted results.
+func (m *MockBot) CopyMessages(config tgbotapi.CopyMessagesConfig) ([]tgbotapi.MessageID, error) {
+	results := m.Called("CopyMessages", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.MessageID)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// ForwardMessages records the call and returns the scripted results.
+func (m *MockBot) ForwardMessages(config tgbotapi.ForwardMessagesConfig) ([]tgbotapi.MessageID, error) {
+	results := m.Called("ForwardMessages", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.MessageID)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetUserChatBoosts records the call and returns the scripted results.
+func (m *MockBot) GetUserChatBoosts(config tgbotapi.GetUserChatBoostsConfig) (tgbotapi.UserChatBoosts, error) {
+	results := m.Called("GetUserChatBoosts", 2, config)
+
+	r0, _ := results[0].(tgbotapi.UserChatBoosts)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetChatMemberCount records the call and returns the scripted results.
+func (m *MockBot) GetChatMemberCount(config tgbotapi.GetChatMemberCountConfig) (int, error) {
+	results := m.Called("GetChatMemberCount", 2, config)
+
+	r0, _ := results[0].(int)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// CreateChatSubscriptionInviteLink records the call and returns the scripted results.
+func (m *MockBot) CreateChatSubscriptionInviteLink(config tgbotapi.CreateChatSubscriptionInviteLinkConfig) (tgbotapi.ChatInviteLink, error) {
+	results := m.Called("CreateChatSubscriptionInviteLink", 2, config)
+
+	r0, _ := results[0].(tgbotapi.ChatInviteLink)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// EditChatSubscriptionInviteLink records the call and returns the scripted results.
+func (m *MockBot) EditChatSubscriptionInviteLink(config tgbotapi.EditChatSubscriptionInviteLinkConfig) (tgbotapi.ChatInviteLink, error) {
+	results := m.Called("EditChatSubscriptionInviteLink", 2, config)
+
+	r0, _ := results[0].(tgbotapi.ChatInviteLink)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetAvailableGifts records the call and returns the scripted results.
+func (m *MockBot) GetAvailableGifts(config tgbotapi.GetAvailableGiftsConfig) (tgbotapi.Gifts, error) {
+	results := m.Called("GetAvailableGifts", 2, config)
+
+	r0, _ := results[0].(tgbotapi.Gifts)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// SavePreparedInlineMessage records the call and returns the scripted results.
+func (m *MockBot) SavePreparedInlineMessage(config tgbotapi.SavePreparedInlineMessageConfig) (tgbotapi.PreparedInlineMessage, error) {
+	results := m.Called("SavePreparedInlineMessage", 2, config)
+
+	r0, _ := results[0].(tgbotapi.PreparedInlineMessage)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetMyName records the call and returns the scripted results.
+func (m *MockBot) GetMyName(config tgbotapi.GetMyNameConfig) (tgbotapi.BotName, error) {
+	results := m.Called("GetMyName", 2, config)
+
+	r0, _ := results[0].(tgbotapi.BotName)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetMyDescription records the call and returns the scripted results.
+func (m *MockBot) GetMyDescription(config tgbotapi.GetMyDescriptionConfig) (tgbotapi.BotDescription, error) {
+	results := m.Called("GetMyDescription", 2, config)
+
+	r0, _ := results[0].(tgbotapi.BotDescription)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetMyShortDescription records the call and returns the scripted results.
+func (m *MockBot) GetMyShortDescription(config tgbotapi.GetMyShortDescriptionConfig) (tgbotapi.BotShortDescription, error) {
+	results := m.Called("GetMyShortDescription", 2, config)
+
+	r0, _ := results[0].(tgbotapi.BotShortDescription)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetForumTopicIconStickers records the call and returns the scripted results.
+func (m *MockBot) GetForumTopicIconStickers(config tgbotapi.GetForumTopicIconStickersConfig) ([]tgbotapi.Sticker, error) {
+	results := m.Called("GetForumTopicIconStickers", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.Sticker)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// CreateForumTopic records the call and returns the scripted results.
+func (m *MockBot) CreateForumTopic(config tgbotapi.CreateForumTopicConfig) (tgbotapi.ForumTopic, error) {
+	results := m.Called("CreateForumTopic", 2, config)
+
+	r0, _ := results[0].(tgbotapi.ForumTopic)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetBusinessConnection records the call and returns the scripted results.
+func (m *MockBot) GetBusinessConnection(config tgbotapi.GetBusinessConnectionConfig) (tgbotapi.BusinessConnection, error) {
+	results := m.Called("GetBusinessConnection", 2, config)
+
+	r0, _ := results[0].(tgbotapi.BusinessConnection)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetStarTransactions records the call and returns the scripted results.
+func (m *MockBot) GetStarTransactions(config tgbotapi.GetStarTransactionsConfig) (tgbotapi.StarTransactions, error) {
+	results := m.Called("GetStarTransactions", 2, config)
+
+	r0, _ := results[0].(tgbotapi.StarTransactions)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetAllStarTransactions records the call and returns the scripted results.
+func (m *MockBot) GetAllStarTransactions() ([]tgbotapi.StarTransaction, error) {
+	results := m.Called("GetAllStarTransactions", 2)
+
+	r0, _ := results[0].([]tgbotapi.StarTransaction)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// CreateInvoiceLink records the call and returns the scripted results.
+func (m *MockBot) CreateInvoiceLink(config tgbotapi.InvoiceLinkConfig) (string, error) {
+	results := m.Called("CreateInvoiceLink", 2, config)
+
+	r0, _ := results[0].(string)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
+
+// GetCustomEmojiStickers records the call and returns the scripted results.
+func (m *MockBot) GetCustomEmojiStickers(config tgbotapi.GetCustomEmojiStickersConfig) ([]tgbotapi.Sticker, error) {
+	results := m.Called("GetCustomEmojiStickers", 2, config)
+
+	r0, _ := results[0].([]tgbotapi.Sticker)
+	r1, _ := results[1].(error)
+
+	return r0, r1
+}
@@ -0,0 +1,59 @@
+package telegramtest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+func TestMockBot(t *testing.T) {
+	bot := NewMockBot()
+	bot.ReturnOnce("Send", tgbotapi.Message{MessageID: 1}, nil)
+	bot.ReturnOnce("Send", tgbotapi.Message{}, Forbidden("bot was blocked by the user"))
+
+	messages, err := tgbotapi.SendLongMessage(bot, tgbotapi.NewMessage(1, strings.Repeat("word ", 2000)))
+	if err == nil || len(messages) != 1 || messages[0].MessageID != 1 {
+		t.Fatalf("expected the second part to fail, got %v, %v", messages, err)
+	}
+
+	calls := bot.CallsTo("Send")
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(calls))
+	}
+	if config, ok := calls[0].Args[0].(tgbotapi.MessageConfig); !ok || config.ChatID != 1 {
+		t.Fatalf("unexpected call %+v", calls[0])
+	}
+
+	if message, err := bot.Send(tgbotapi.NewMessage(1, "a")); err != nil || message.MessageID != 0 {
+		t.Fatal("calls without scripted results should return zero values")
+	}
+
+	bot.Return("GetChat", tgbotapi.Chat{ID: 5, Type: "private"}, nil)
+	for i := 0; i < 2; i++ {
+		if chat, err := bot.GetChat(tgbotapi.ChatInfoConfig{}); err != nil || chat.ID != 5 {
+			t.Fatalf("unexpected chat %+v, %v", chat, err)
+		}
+	}
+}
+
+func TestMockBotWidgets(t *testing.T) {
+	bot := NewMockBot()
+
+	var selected bool
+	calendar := tgbotapi.NewCalendar("cal", func(bot tgbotapi.Sender, query *tgbotapi.CallbackQuery, date time.Time) error {
+		selected = true
+		return nil
+	})
+
+	query := NewCallbackQuery("cal:pick:20220105").CallbackQuery()
+	handled, err := calendar.HandleCallback(bot, &query)
+	if err != nil || !handled || !selected {
+		t.Fatalf("expected the date to be selected, got %v, %v", handled, err)
+	}
+
+	if len(bot.CallsTo("Request")) == 0 {
+		t.Fatal("expected the callback query to be answered")
+	}
+}
@@ -0,0 +1,326 @@
+package telegramtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+// RecorderMode selects if a Recorder makes real requests or replays them.
+type RecorderMode int
+
+const (
+	// Replay answers requests from the cassette without making them.
+	Replay RecorderMode = iota
+	// Record makes real requests and adds them to the cassette.
+	Record
+)
+
+// redactedToken replaces the bot token in cassettes.
+const redactedToken = "<TOKEN>"
+
+// Interaction is a request and its response stored in a cassette.
+type Interaction struct {
+	// Method is the called Bot API method.
+	Method string `json:"method"`
+	// Params are the normalized request params. JSON values are
+	// re-encoded, so the order of object keys does not matter.
+	Params map[string]string `json:"params,omitempty"`
+	// Files are the SHA-256 hashes of uploaded files, by field name.
+	Files map[string]string `json:"files,omitempty"`
+	// Status is the HTTP status of the response.
+	Status int `json:"status"`
+	// Response is the response body.
+	Response json.RawMessage `json:"response"`
+}
+
+// Cassette is a list of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is a tgbotapi.HTTPClient recording Bot API requests to a
+// cassette file, and replaying them in later runs.
+//
+// Requests are replayed by finding the first unused interaction with the
+// same method, params and uploaded files. The bot token is never written
+// to the cassette.
+//
+//	recorder, err := telegramtest.NewRecorder("testdata/send.json", telegramtest.Replay, nil)
+//	bot, err := tgbotapi.NewBotAPIWithClient(token, tgbotapi.APIEndpoint, recorder)
+//	...
+//	err = recorder.Save()
+type Recorder struct {
+	// Path is the cassette file.
+	Path string
+	// Mode selects if requests are made or replayed.
+	Mode RecorderMode
+	// Client makes the requests when recording.
+	Client tgbotapi.HTTPClient
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates a Recorder. When replaying, the cassette is loaded
+// from path. When recording, requests are made with client, or
+// http.DefaultClient if it is nil.
+func NewRecorder(path string, mode RecorderMode, client tgbotapi.HTTPClient) (*Recorder, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	r := &Recorder{Path: path, Mode: mode, Client: client}
+
+	if mode == Replay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Interactions returns the interactions of the cassette.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.Mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.Path, append(data, '\n'), 0o644)
+}
+
+// Do implements tgbotapi.HTTPClient.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	token, method := splitRequestPath(req.URL.Path)
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	interaction := Interaction{Method: method}
+	if err := interaction.setRequest(req.Header.Get("Content-Type"), body, token); err != nil {
+		return nil, err
+	}
+
+	if r.Mode == Record {
+		return r.record(req, body, token, interaction)
+	}
+
+	return r.replay(req, interaction)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, token string, interaction Interaction) (*http.Response, error) {
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction.Status = resp.StatusCode
+	interaction.Response = json.RawMessage(redact(string(data), token))
+	if !json.Valid(interaction.Response) {
+		return nil, fmt.Errorf("telegramtest: %s returned a response which is not JSON", interaction.Method)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, interaction Interaction) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, recorded := range r.cassette.Interactions {
+		if r.used[i] || !interaction.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+			StatusCode:    recorded.Status,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(recorded.Response)),
+			ContentLength: int64(len(recorded.Response)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("telegramtest: no recorded interaction for %s with params %v", interaction.Method, interaction.Params)
+}
+
+// Unused returns the interactions which were not replayed, which is
+// useful to check that a test made all the recorded requests.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+func (i Interaction) matches(other Interaction) bool {
+	return i.Method == other.Method &&
+		equalStringMaps(i.Params, other.Params) &&
+		equalStringMaps(i.Files, other.Files)
+}
+
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// setRequest sets the normalized params and files of a request body.
+func (i *Interaction) setRequest(contentType string, body []byte, token string) error {
+	i.Params = map[string]string{}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if mediaType == "multipart/form-data" {
+		i.Files = map[string]string{}
+
+		reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return err
+			}
+
+			if part.FileName() != "" {
+				hash := sha256.Sum256(data)
+				i.Files[part.FormName()] = hex.EncodeToString(hash[:])
+			} else {
+				i.Params[part.FormName()] = normalizeParam(redact(string(data), token))
+			}
+		}
+
+		return nil
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+
+	for name := range values {
+		i.Params[name] = normalizeParam(redact(values.Get(name), token))
+	}
+
+	return nil
+}
+
+// normalizeParam re-encodes JSON objects and arrays, so equivalent values
+// compare equal.
+func normalizeParam(value string) string {
+	if !strings.HasPrefix(value, "{") && !strings.HasPrefix(value, "[") {
+		return value
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return value
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return value
+	}
+
+	return string(data)
+}
+
+// splitRequestPath returns the token and method of a Bot API request path,
+// such as "/bot<token>/sendMessage".
+func splitRequestPath(p string) (string, string) {
+	dir, method := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+
+	token := path.Base(dir)
+	if !strings.HasPrefix(token, "bot") {
+		return "", method
+	}
+
+	return strings.TrimPrefix(token, "bot"), method
+}
+
+func redact(s, token string) string {
+	if token == "" {
+		return s
+	}
+
+	return strings.ReplaceAll(s, token, redactedToken)
+}
@@ -0,0 +1,77 @@
+package telegramtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+func TestRecorder(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	run := func(recorder *Recorder) {
+		bot, err := tgbotapi.NewBotAPIWithClient(s.Token, s.Endpoint(), recorder)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg := tgbotapi.NewMessage(5, "hello")
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("a", "b"),
+		))
+		if sent, err := bot.Send(msg); err != nil || sent.Text != "hello" {
+			t.Fatalf("unexpected result %+v, %v", sent, err)
+		}
+
+		doc := tgbotapi.NewDocument(5, tgbotapi.FileBytes{Name: "a.txt", Bytes: []byte("data")})
+		if sent, err := bot.Send(doc); err != nil || sent.Document == nil {
+			t.Fatalf("unexpected result %+v, %v", sent, err)
+		}
+	}
+
+	recorder, err := NewRecorder(cassette, Record, s.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(recorder)
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), s.Token) {
+		t.Fatal("the token must be redacted")
+	}
+
+	requests := len(s.Requests())
+
+	replayer, err := NewRecorder(cassette, Replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(replayer)
+
+	if len(s.Requests()) != requests {
+		t.Fatal("replaying must not make requests")
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Fatalf("expected all interactions to be used, got %v", unused)
+	}
+
+	bot, err := tgbotapi.NewBotAPIWithClient(s.Token, s.Endpoint(), replayer)
+	if err == nil {
+		_, err = bot.Send(tgbotapi.NewMessage(5, "other"))
+	}
+	if err == nil {
+		t.Fatal("unmatched requests should fail")
+	}
+}
@@ -0,0 +1,599 @@
+// Package telegramtest provides utilities for testing bots without
+// connecting to Telegram, similar to what httptest does for HTTP.
+package telegramtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+// DefaultToken is the token accepted by servers created with NewServer.
+const DefaultToken = "123456:TEST-TOKEN"
+
+// Request is a request received by a Server.
+type Request struct {
+	// Method is the name of the called Bot API method.
+	Method string
+	// Params are the form values of the request.
+	Params url.Values
+	// Files are the uploaded files, by field name.
+	Files map[string]UploadedFile
+}
+
+// UploadedFile is a file uploaded in a request.
+type UploadedFile struct {
+	Name string
+	Data []byte
+}
+
+// Int returns a param as an integer, or zero if it is missing or invalid.
+func (r *Request) Int(name string) int {
+	i, _ := strconv.Atoi(r.Params.Get(name))
+	return i
+}
+
+// Int64 returns a param as an int64, or zero if it is missing or invalid.
+func (r *Request) Int64(name string) int64 {
+	i, _ := strconv.ParseInt(r.Params.Get(name), 10, 64)
+	return i
+}
+
+// Bool returns a param as a boolean.
+func (r *Request) Bool(name string) bool {
+	b, _ := strconv.ParseBool(r.Params.Get(name))
+	return b
+}
+
+// JSON decodes a JSON encoded param into v. Missing params are ignored.
+func (r *Request) JSON(name string, v interface{}) error {
+	value := r.Params.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return BadRequest(fmt.Sprintf("can't parse %s JSON object", name))
+	}
+
+	return nil
+}
+
+// HandlerFunc handles a Bot API method, returning the result to encode.
+// Returning a tgbotapi.Error results in the matching API error.
+type HandlerFunc func(r *Request) (interface{}, error)
+
+// Server is an in-process fake Telegram Bot API server.
+//
+// It implements common methods, such as sending and editing messages,
+// getUpdates, webhooks and the texts of the bot profile, with in-memory
+// state. Use NewBot, or pass Endpoint and Client to
+// tgbotapi.NewBotAPIWithClient, to connect a bot. Unknown numeric chat IDs
+// are treated as existing chats.
+type Server struct {
+	// Server is the underlying HTTP server.
+	*httptest.Server
+	// Token is the bot token accepted by the server.
+	Token string
+	// Bot is the user returned by getMe.
+	Bot tgbotapi.User
+	// Now returns the time used for message dates.
+	Now func() time.Time
+
+	mu              sync.Mutex
+	handlers        map[string]HandlerFunc
+	failures        map[string][]error
+	requests        []Request
+	chats           map[int64]*tgbotapi.Chat
+	members         map[int64]map[int64]tgbotapi.ChatMember
+	messages        map[int64]map[int]*tgbotapi.Message
+	lastMessageID   map[int64]int
+	sent            []tgbotapi.Message
+	files           map[string]*storedFile
+	lastFileID      int
+	updates         []tgbotapi.Update
+	lastUpdateID    int
+	updatesChanged  chan struct{}
+	webhook         tgbotapi.WebhookInfo
+	callbackAnswers []tgbotapi.CallbackConfig
+	profile         map[string]map[string]string
+	closed          chan struct{}
+}
+
+type storedFile struct {
+	file tgbotapi.File
+	name string
+	data []byte
+}
+
+// NewServer starts a fake server accepting DefaultToken. It should be
+// closed when done.
+func NewServer() *Server {
+	s := &Server{
+		Token:          DefaultToken,
+		Bot:            DefaultBot,
+		Now:            time.Now,
+		failures:       map[string][]error{},
+		chats:          map[int64]*tgbotapi.Chat{},
+		members:        map[int64]map[int64]tgbotapi.ChatMember{},
+		messages:       map[int64]map[int]*tgbotapi.Message{},
+		lastMessageID:  map[int64]int{},
+		files:          map[string]*storedFile{},
+		profile:        map[string]map[string]string{},
+		updatesChanged: make(chan struct{}),
+		closed:         make(chan struct{}),
+	}
+
+	s.handlers = s.defaultHandlers()
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// Close shuts down the server, ending pending getUpdates requests.
+func (s *Server) Close() {
+	s.mu.Lock()
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+	s.mu.Unlock()
+
+	s.Server.Close()
+}
+
+// Endpoint returns the API endpoint of the server, for use with
+// tgbotapi.NewBotAPIWithAPIEndpoint or BotAPI.SetAPIEndpoint.
+func (s *Server) Endpoint() string {
+	return s.URL + "/bot%s/%s"
+}
+
+// FileURL returns the download URL for the path of a file.
+func (s *Server) FileURL(filePath string) string {
+	return s.URL + "/file/bot" + s.Token + "/" + filePath
+}
+
+// NewBot creates a BotAPI connected to the server.
+func (s *Server) NewBot() (*tgbotapi.BotAPI, error) {
+	return tgbotapi.NewBotAPIWithClient(s.Token, s.Endpoint(), s.Client())
+}
+
+// Handle sets the handler for a method, replacing the built-in one.
+func (s *Server) Handle(method string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[strings.ToLower(method)] = handler
+}
+
+// FailNext makes the next call of a method fail with err, which is usually
+// a tgbotapi.Error such as one created by TooManyRequests. Calling it
+// several times queues failures for following calls.
+func (s *Server) FailNext(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	method = strings.ToLower(method)
+	s.failures[method] = append(s.failures[method], err)
+}
+
+// BadRequest creates a 400 error, as returned for invalid requests.
+func BadRequest(description string) tgbotapi.Error {
+	return tgbotapi.Error{Code: http.StatusBadRequest, Message: "Bad Request: " + description}
+}
+
+// Forbidden creates a 403 error, such as returned when the bot was blocked
+// by the user.
+func Forbidden(description string) tgbotapi.Error {
+	return tgbotapi.Error{Code: http.StatusForbidden, Message: "Forbidden: " + description}
+}
+
+// TooManyRequests creates a 429 flood control error.
+func TooManyRequests(retryAfter int) tgbotapi.Error {
+	return tgbotapi.Error{
+		Code:               http.StatusTooManyRequests,
+		Message:            fmt.Sprintf("Too Many Requests: retry after %d", retryAfter),
+		ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: retryAfter},
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/file/bot") {
+		s.serveFile(w, r)
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/bot"), "/", 2)
+	if len(parts) != 2 || parts[0] != s.Token {
+		writeResponse(w, nil, tgbotapi.Error{Code: http.StatusUnauthorized, Message: "Unauthorized"})
+		return
+	}
+
+	req, err := parseRequest(parts[1], r)
+	if err != nil {
+		writeResponse(w, nil, BadRequest(err.Error()))
+		return
+	}
+
+	method := strings.ToLower(req.Method)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, *req)
+
+	var failure error
+	if failures := s.failures[method]; len(failures) > 0 {
+		failure = failures[0]
+		s.failures[method] = failures[1:]
+	}
+
+	handler := s.handlers[method]
+	s.mu.Unlock()
+
+	if failure != nil {
+		writeResponse(w, nil, failure)
+		return
+	}
+
+	if handler == nil {
+		writeResponse(w, nil, tgbotapi.Error{Code: http.StatusNotFound, Message: "Not Found"})
+		return
+	}
+
+	result, err := handler(req)
+	writeResponse(w, result, err)
+}
+
+func parseRequest(method string, r *http.Request) (*Request, error) {
+	req := &Request{Method: method, Params: url.Values{}, Files: map[string]UploadedFile{}}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+
+		for name, headers := range r.MultipartForm.File {
+			f, err := headers[0].Open()
+			if err != nil {
+				return nil, err
+			}
+
+			data, err := io.ReadAll(f)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+
+			req.Files[name] = UploadedFile{Name: headers[0].Filename, Data: data}
+		}
+	} else if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	for name, values := range r.Form {
+		req.Params[name] = values
+	}
+
+	return req, nil
+}
+
+func writeResponse(w http.ResponseWriter, result interface{}, err error) {
+	var resp tgbotapi.APIResponse
+
+	if err != nil {
+		var apiErr tgbotapi.Error
+		if e, ok := err.(*tgbotapi.Error); ok {
+			apiErr = *e
+		} else if !errors.As(err, &apiErr) {
+			apiErr = BadRequest(err.Error())
+		}
+
+		resp.ErrorCode = apiErr.Code
+		resp.Description = apiErr.Message
+		if apiErr.RetryAfter != 0 || apiErr.MigrateToChatID != 0 {
+			parameters := apiErr.ResponseParameters
+			resp.Parameters = &parameters
+		}
+	} else {
+		data, err := json.Marshal(result)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resp.Ok = true
+		resp.Result = data
+	}
+
+	status := http.StatusOK
+	if !resp.Ok {
+		status = resp.ErrorCode
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request) {
+	filePath := strings.TrimPrefix(r.URL.Path, "/file/bot"+s.Token+"/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range s.files {
+		if f.file.FilePath == filePath && f.data != nil {
+			w.Write(f.data)
+			return
+		}
+	}
+
+	http.NotFound(w, r)
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsFor returns the requests received for a method.
+func (s *Server) RequestsFor(method string) []Request {
+	var requests []Request
+	for _, r := range s.Requests() {
+		if strings.EqualFold(r.Method, method) {
+			requests = append(requests, r)
+		}
+	}
+
+	return requests
+}
+
+// Sent returns the messages sent by the bot, as they were when sent.
+func (s *Server) Sent() []tgbotapi.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]tgbotapi.Message(nil), s.sent...)
+}
+
+// SentTo returns the messages sent by the bot to a chat.
+func (s *Server) SentTo(chatID int64) []tgbotapi.Message {
+	var messages []tgbotapi.Message
+	for _, m := range s.Sent() {
+		if m.Chat.ID == chatID {
+			messages = append(messages, m)
+		}
+	}
+
+	return messages
+}
+
+// Message returns the current state of a message, including edits.
+func (s *Server) Message(chatID int64, messageID int) (tgbotapi.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.messages[chatID][messageID]; ok {
+		return *m, true
+	}
+
+	return tgbotapi.Message{}, false
+}
+
+// CallbackAnswers returns the answers to callback queries sent by the bot.
+func (s *Server) CallbackAnswers() []tgbotapi.CallbackConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]tgbotapi.CallbackConfig(nil), s.callbackAnswers...)
+}
+
+// Webhook returns the current webhook.
+func (s *Server) Webhook() tgbotapi.WebhookInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.webhookInfo()
+}
+
+func (s *Server) webhookInfo() tgbotapi.WebhookInfo {
+	info := s.webhook
+	info.PendingUpdateCount = len(s.updates)
+
+	return info
+}
+
+// AddChat adds a chat, so it can be found by getChat, including by its
+// username.
+func (s *Server) AddChat(chat tgbotapi.Chat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.chats[chat.ID] = &chat
+}
+
+// SetChatMember sets a member of a chat returned by getChatMember. Users
+// without a member set are returned as members.
+func (s *Server) SetChatMember(chatID int64, member tgbotapi.ChatMember) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.members[chatID] == nil {
+		s.members[chatID] = map[int64]tgbotapi.ChatMember{}
+	}
+	s.members[chatID][member.User.ID] = member
+}
+
+// AddFile stores a file which may be used by its ID and downloaded.
+func (s *Server) AddFile(name string, data []byte) tgbotapi.File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addFile(name, data).file
+}
+
+// FileData returns the content of a stored or uploaded file.
+func (s *Server) FileData(fileID string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[fileID]
+	if !ok || f.data == nil {
+		return nil, false
+	}
+
+	return f.data, true
+}
+
+func (s *Server) addFile(name string, data []byte) *storedFile {
+	s.lastFileID++
+	id := fmt.Sprintf("file-%d", s.lastFileID)
+
+	f := &storedFile{
+		file: tgbotapi.File{
+			FileID:       id,
+			FileUniqueID: "unique-" + id,
+			FileSize:     len(data),
+			FilePath:     "files/" + id,
+		},
+		name: name,
+		data: data,
+	}
+	s.files[id] = f
+
+	return f
+}
+
+// AddUpdate queues an update for getUpdates, setting its UpdateID. It
+// returns the update as queued.
+func (s *Server) AddUpdate(update tgbotapi.Update) tgbotapi.Update {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastUpdateID++
+	update.UpdateID = s.lastUpdateID
+	s.updates = append(s.updates, update)
+
+	if update.Message != nil && update.Message.Chat != nil {
+		s.storeMessage(update.Message)
+	}
+
+	close(s.updatesChanged)
+	s.updatesChanged = make(chan struct{})
+
+	return update
+}
+
+// AddMessage queues an update with a text message sent by a user to a chat.
+// The message gets the next message ID of the chat.
+func (s *Server) AddMessage(from tgbotapi.User, chatID int64, text string) tgbotapi.Message {
+	s.mu.Lock()
+	message := tgbotapi.Message{
+		MessageID: s.nextMessageID(chatID),
+		From:      &from,
+		Date:      int(s.Now().Unix()),
+		Chat:      s.chat(chatID),
+		Text:      text,
+	}
+	s.mu.Unlock()
+
+	s.AddUpdate(tgbotapi.Update{Message: &message})
+
+	return message
+}
+
+// AddCallbackQuery queues an update with a callback query from pressing a
+// button of a message.
+//
+// Messages without a chat are treated as sent to the private chat with the
+// user pressing the button.
+func (s *Server) AddCallbackQuery(from tgbotapi.User, message tgbotapi.Message, data string) tgbotapi.CallbackQuery {
+	chatID := from.ID
+	if message.Chat != nil {
+		chatID = message.Chat.ID
+	}
+
+	s.mu.Lock()
+	query := tgbotapi.CallbackQuery{
+		ID:           fmt.Sprintf("query-%d", s.lastUpdateID+1),
+		From:         &from,
+		Message:      &message,
+		ChatInstance: strconv.FormatInt(chatID, 10),
+		Data:         data,
+	}
+	s.mu.Unlock()
+
+	s.AddUpdate(tgbotapi.Update{CallbackQuery: &query})
+
+	return query
+}
+
+func (s *Server) nextMessageID(chatID int64) int {
+	s.lastMessageID[chatID]++
+	return s.lastMessageID[chatID]
+}
+
+// chat returns a known chat, creating chats for unknown IDs.
+func (s *Server) chat(chatID int64) *tgbotapi.Chat {
+	if chat, ok := s.chats[chatID]; ok {
+		return chat
+	}
+
+	chat := &tgbotapi.Chat{ID: chatID, Type: "private"}
+	if chatID < 0 {
+		chat.Type = "supergroup"
+	}
+	s.chats[chatID] = chat
+
+	return chat
+}
+
+// findChat finds the chat for a chat_id param, which may be a username.
+func (s *Server) findChat(value string) (*tgbotapi.Chat, error) {
+	if value == "" {
+		return nil, BadRequest("chat_id is empty")
+	}
+
+	if strings.HasPrefix(value, "@") {
+		for _, chat := range s.chats {
+			if strings.EqualFold("@"+chat.UserName, value) {
+				return chat, nil
+			}
+		}
+
+		return nil, BadRequest("chat not found")
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, BadRequest("chat not found")
+	}
+
+	return s.chat(id), nil
+}
+
+func (s *Server) storeMessage(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	if s.messages[chatID] == nil {
+		s.messages[chatID] = map[int]*tgbotapi.Message{}
+	}
+	s.messages[chatID][message.MessageID] = message
+
+	if message.MessageID > s.lastMessageID[chatID] {
+		s.lastMessageID[chatID] = message.MessageID
+	}
+}
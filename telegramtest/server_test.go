@@ -0,0 +1,234 @@
+package telegramtest
+
+import (
+	"net/http"
+	"testing"
+
+	tgbotapi "github.com/preaverage/telegram-bot-api"
+)
+
+func newTestBot(t *testing.T) (*Server, *tgbotapi.BotAPI) {
+	t.Helper()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	bot, err := s.NewBot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, bot
+}
+
+func TestServerMessages(t *testing.T) {
+	s, bot := newTestBot(t)
+
+	if bot.Self.UserName != "test_bot" {
+		t.Fatalf("unexpected bot %+v", bot.Self)
+	}
+
+	msg := tgbotapi.NewMessage(42, "hello")
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("a", "b"),
+	))
+
+	sent, err := bot.Send(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.MessageID != 1 || sent.Text != "hello" || sent.ReplyMarkup == nil {
+		t.Fatalf("unexpected message %+v", sent)
+	}
+
+	if _, err := bot.Send(tgbotapi.NewEditMessageText(42, sent.MessageID, "edited")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = bot.Send(tgbotapi.NewEditMessageText(42, sent.MessageID, "edited"))
+	if apiErr, ok := err.(*tgbotapi.Error); !ok || apiErr.Code != http.StatusBadRequest {
+		t.Fatalf("expected a not modified error, got %v", err)
+	}
+
+	current, ok := s.Message(42, sent.MessageID)
+	if !ok || current.Text != "edited" || current.ReplyMarkup != nil || current.EditDate == 0 {
+		t.Fatalf("unexpected edited message %+v", current)
+	}
+
+	photo := tgbotapi.NewPhoto(42, tgbotapi.FileBytes{Name: "a.jpg", Bytes: []byte("image")})
+	photoMessage, err := bot.Send(photo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url, err := bot.GetFileDirectURL(photoMessage.Photo[0].FileID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := s.FileData(photoMessage.Photo[0].FileID); !ok || string(data) != "image" || url == "" {
+		t.Fatal("uploaded file was not stored")
+	}
+
+	if sent := s.SentTo(42); len(sent) != 2 {
+		t.Fatalf("expected 2 sent messages, got %d", len(sent))
+	}
+	if len(s.RequestsFor("editMessageText")) != 2 {
+		t.Fatal("expected edit requests to be recorded")
+	}
+}
+
+func TestServerReplies(t *testing.T) {
+	_, bot := newTestBot(t)
+
+	original, err := bot.Send(tgbotapi.NewMessage(42, "question"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := bot.Send(tgbotapi.NewQuoteReply(&original, "quest", "answer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.ReplyToMessage == nil || reply.ReplyToMessage.MessageID != original.MessageID {
+		t.Fatalf("unexpected reply %+v", reply)
+	}
+
+	crossChat := tgbotapi.NewMessage(43, "answer")
+	crossChat.ReplyParameters = &tgbotapi.ReplyParameters{ChatID: 42, MessageID: original.MessageID}
+
+	reply, err = bot.Send(crossChat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.ReplyToMessage != nil || reply.ExternalReply == nil || reply.ExternalReply.MessageID != original.MessageID {
+		t.Fatalf("unexpected cross-chat reply %+v", reply)
+	}
+
+	missing := tgbotapi.NewMessage(42, "answer")
+	missing.ReplyParameters = &tgbotapi.ReplyParameters{MessageID: 100}
+	if _, err := bot.Send(missing); err == nil {
+		t.Fatal("expected an error for a missing replied message")
+	}
+}
+
+func TestServerUpdates(t *testing.T) {
+	s, bot := newTestBot(t)
+
+	user := tgbotapi.User{ID: 7, FirstName: "Ann"}
+	message := s.AddMessage(user, 7, "/start")
+	s.AddCallbackQuery(user, message, "data")
+
+	updates, err := bot.GetUpdates(tgbotapi.UpdateConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 || updates[0].Message.Text != "/start" || updates[1].CallbackData() != "data" {
+		t.Fatalf("unexpected updates %+v", updates)
+	}
+
+	if _, err := bot.Request(tgbotapi.NewCallback(updates[1].CallbackQuery.ID, "ok")); err != nil {
+		t.Fatal(err)
+	}
+	if answers := s.CallbackAnswers(); len(answers) != 1 || answers[0].Text != "ok" {
+		t.Fatalf("unexpected answers %+v", answers)
+	}
+
+	updates, err = bot.GetUpdates(tgbotapi.UpdateConfig{Offset: updates[1].UpdateID + 1})
+	if err != nil || len(updates) != 0 {
+		t.Fatalf("confirmed updates should be dropped, got %v, %v", updates, err)
+	}
+
+	query := s.AddCallbackQuery(user, tgbotapi.Message{MessageID: 1}, "no chat")
+	if query.ChatInstance != "7" {
+		t.Fatalf("unexpected chat instance %q", query.ChatInstance)
+	}
+
+	webhook, _ := tgbotapi.NewWebhook("https://example.com/hook")
+	if _, err := bot.Request(webhook); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.GetUpdates(tgbotapi.UpdateConfig{}); err == nil {
+		t.Fatal("getUpdates should fail while a webhook is set")
+	}
+	if info, _ := bot.GetWebhookInfo(); info.URL != "https://example.com/hook" {
+		t.Fatalf("unexpected webhook %+v", info)
+	}
+}
+
+func TestServerFailures(t *testing.T) {
+	s, bot := newTestBot(t)
+
+	s.FailNext("sendMessage", TooManyRequests(3))
+	s.FailNext("sendMessage", Forbidden("bot was blocked by the user"))
+
+	_, err := bot.Send(tgbotapi.NewMessage(1, "a"))
+	if apiErr, ok := err.(*tgbotapi.Error); !ok || apiErr.Code != http.StatusTooManyRequests || apiErr.RetryAfter != 3 {
+		t.Fatalf("expected flood error, got %#v", err)
+	}
+
+	_, err = bot.Send(tgbotapi.NewMessage(1, "a"))
+	if apiErr, ok := err.(*tgbotapi.Error); !ok || apiErr.Code != http.StatusForbidden {
+		t.Fatalf("expected forbidden error, got %#v", err)
+	}
+
+	if _, err := bot.Send(tgbotapi.NewMessage(1, "a")); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Handle("getMyCommands", func(r *Request) (interface{}, error) {
+		return []tgbotapi.BotCommand{{Command: "start", Description: "Start"}}, nil
+	})
+	commands, err := bot.GetMyCommands()
+	if err != nil || len(commands) != 1 {
+		t.Fatalf("unexpected commands %v, %v", commands, err)
+	}
+}
+
+func TestServerBotProfiles(t *testing.T) {
+	s, bot := newTestBot(t)
+
+	weather, forecasts, report, empty := "Weather", "Forecasts", "Wetterbericht", ""
+	profiles := tgbotapi.BotProfiles{
+		"":   {Name: &weather, ShortDescription: &forecasts},
+		"de": {Name: &weather, Description: &report},
+	}
+
+	sync := func(profiles tgbotapi.BotProfiles) (applied []tgbotapi.Chattable, requests int) {
+		t.Helper()
+
+		before := len(s.Requests())
+		applied, err := tgbotapi.SyncBotProfiles(bot, profiles)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return applied, len(s.Requests()) - before
+	}
+
+	// The German name equals the default one, so it is set although the
+	// getter already returns it.
+	if applied, requests := sync(profiles); len(applied) != 4 || requests != 8 {
+		t.Fatalf("applied %d configs in %d requests", len(applied), requests)
+	}
+
+	// Only the German name, which can't be told apart from the default, is
+	// set again. Comparing the German description with the default one
+	// takes one more request.
+	applied, requests := sync(profiles)
+	if len(applied) != 1 || requests != 6 || applied[0] != (tgbotapi.SetMyNameConfig{Name: "Weather", LanguageCode: "de"}) {
+		t.Fatalf("applied %+v in %d requests", applied, requests)
+	}
+
+	if applied, _ := sync(tgbotapi.BotProfiles{"de": {Description: &empty}}); len(applied) != 1 {
+		t.Fatalf("expected the description to be cleared, applied %+v", applied)
+	}
+
+	description, err := bot.GetMyDescription(tgbotapi.GetMyDescriptionConfig{LanguageCode: "de"})
+	if err != nil || description.Description != "" {
+		t.Fatalf("unexpected description %+v, %v", description, err)
+	}
+
+	if applied, requests := sync(tgbotapi.BotProfiles{"de": {Description: &empty}}); len(applied) != 0 || requests != 1 {
+		t.Fatalf("applied %+v in %d requests", applied, requests)
+	}
+}
@@ -0,0 +1,352 @@
+package tgbotapi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TrustedMarkup is text which is already formatted for the parse mode of a
+// MessageTemplate. It is inserted into the output without escaping.
+type TrustedMarkup string
+
+// MessageTemplate is a text/template which escapes the values it inserts
+// for a parse mode, similar to html/template.
+//
+// The escaping depends on where a value is inserted. For ModeHTML, values
+// are escaped for text and quoted attribute values, and may not be used
+// inside tags otherwise. For ModeMarkdownV2, values are escaped differently
+// for text, code and pre blocks, and link URLs.
+//
+//	t, err := NewMessageTemplate("greeting", ModeMarkdownV2).
+//		Parse("Hello, *{{.Name}}*! [Profile]({{.URL}})")
+type MessageTemplate struct {
+	parseMode string
+	tmpl      *template.Template
+	escaped   map[*parse.Tree]bool
+}
+
+// Names of the escaping functions added to the templates.
+const (
+	templateEscapeText = "tgEscapeText"
+	templateEscapeCode = "tgEscapeCode"
+	templateEscapeURL  = "tgEscapeURL"
+)
+
+// NewMessageTemplate creates an empty template for a parse mode, which must
+// be ModeHTML or ModeMarkdownV2.
+func NewMessageTemplate(name, parseMode string) *MessageTemplate {
+	t := &MessageTemplate{
+		parseMode: parseMode,
+		tmpl:      template.New(name),
+		escaped:   map[*parse.Tree]bool{},
+	}
+
+	t.tmpl.Funcs(template.FuncMap{
+		templateEscapeText: t.escaper(contextText),
+		templateEscapeCode: t.escaper(contextCode),
+		templateEscapeURL:  t.escaper(contextURL),
+	})
+
+	return t
+}
+
+// Funcs adds functions to the template, see template.Template.Funcs.
+func (t *MessageTemplate) Funcs(funcs template.FuncMap) *MessageTemplate {
+	t.tmpl.Funcs(funcs)
+
+	return t
+}
+
+// Parse parses text as the template body and adds escaping to its actions.
+func (t *MessageTemplate) Parse(text string) (*MessageTemplate, error) {
+	if t.parseMode != ModeHTML && t.parseMode != ModeMarkdownV2 {
+		return nil, fmt.Errorf("unsupported template parse mode %q", t.parseMode)
+	}
+
+	if _, err := t.tmpl.Parse(text); err != nil {
+		return nil, err
+	}
+
+	for _, tmpl := range t.tmpl.Templates() {
+		if tmpl.Tree == nil || t.escaped[tmpl.Tree] {
+			continue
+		}
+
+		e := &templateEscaper{parseMode: t.parseMode, name: tmpl.Name()}
+		end, err := e.escapeList(tmpl.Tree.Root, contextText)
+		if err != nil {
+			return nil, err
+		}
+		if end != contextText {
+			return nil, fmt.Errorf("template %s ends in the middle of markup", tmpl.Name())
+		}
+
+		t.escaped[tmpl.Tree] = true
+	}
+
+	return t, nil
+}
+
+// MustParseMessageTemplate creates and parses a template, and panics if
+// parsing fails. It is intended for package level templates.
+func MustParseMessageTemplate(name, parseMode, text string) *MessageTemplate {
+	t, err := NewMessageTemplate(name, parseMode).Parse(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ParseMode returns the parse mode of the template.
+func (t *MessageTemplate) ParseMode() string {
+	return t.parseMode
+}
+
+// Execute applies the template to data, returning the formatted text.
+func (t *MessageTemplate) Execute(data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Message applies the template to data and creates a message with the
+// result. The result is checked with ValidateMarkup.
+func (t *MessageTemplate) Message(chatID int64, data interface{}) (MessageConfig, error) {
+	text, err := t.Execute(data)
+	if err != nil {
+		return MessageConfig{}, err
+	}
+
+	if err := ValidateMarkup(t.parseMode, text); err != nil {
+		return MessageConfig{}, err
+	}
+
+	msg := NewMessage(chatID, text)
+	msg.ParseMode = t.parseMode
+
+	return msg, nil
+}
+
+// templateHTMLEscaper escapes values in every HTML context. Unlike
+// htmlEscaper it also escapes single quotes, which end attribute values
+// quoted with them.
+var templateHTMLEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;")
+
+func (t *MessageTemplate) escaper(ctx templateContext) func(args ...interface{}) string {
+	return func(args ...interface{}) string {
+		if len(args) == 1 {
+			if markup, ok := args[0].(TrustedMarkup); ok {
+				return string(markup)
+			}
+		}
+
+		text := fmt.Sprint(args...)
+
+		if t.parseMode == ModeHTML {
+			return templateHTMLEscaper.Replace(text)
+		}
+
+		switch ctx {
+		case contextCode:
+			return markdownV2CodeEscaper.Replace(text)
+		case contextURL:
+			return markdownV2LinkEscaper.Replace(text)
+		default:
+			return markdownV2Escaper.Replace(text)
+		}
+	}
+}
+
+// templateContext is the state of the markup at a point of a template.
+type templateContext int
+
+const (
+	contextText templateContext = iota
+	// contextCode is inside MarkdownV2 inline code.
+	contextCode
+	// contextPre is inside a MarkdownV2 pre block.
+	contextPre
+	// contextURL is inside a MarkdownV2 link URL.
+	contextURL
+	// contextTag is inside an HTML tag, outside of attribute values.
+	contextTag
+	// contextAttrDouble and contextAttrSingle are inside quoted HTML
+	// attribute values.
+	contextAttrDouble
+	contextAttrSingle
+)
+
+type templateEscaper struct {
+	parseMode string
+	name      string
+}
+
+func (e *templateEscaper) escapeList(list *parse.ListNode, ctx templateContext) (templateContext, error) {
+	if list == nil {
+		return ctx, nil
+	}
+
+	var err error
+	for _, node := range list.Nodes {
+		if ctx, err = e.escapeNode(node, ctx); err != nil {
+			return ctx, err
+		}
+	}
+
+	return ctx, nil
+}
+
+func (e *templateEscaper) escapeNode(node parse.Node, ctx templateContext) (templateContext, error) {
+	switch node := node.(type) {
+	case *parse.TextNode:
+		return e.scan(node.Text, ctx), nil
+	case *parse.ActionNode:
+		return ctx, e.escapeAction(node, ctx)
+	case *parse.IfNode:
+		return e.escapeBranch(&node.BranchNode, ctx, false)
+	case *parse.WithNode:
+		return e.escapeBranch(&node.BranchNode, ctx, false)
+	case *parse.RangeNode:
+		return e.escapeBranch(&node.BranchNode, ctx, true)
+	case *parse.TemplateNode:
+		if ctx != contextText {
+			return ctx, fmt.Errorf("template %s: {{template %q}} can only be used in text", e.name, node.Name)
+		}
+	}
+
+	return ctx, nil
+}
+
+func (e *templateEscaper) escapeBranch(node *parse.BranchNode, ctx templateContext, loop bool) (templateContext, error) {
+	end, err := e.escapeList(node.List, ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	if loop && end != ctx {
+		return ctx, fmt.Errorf("template %s: range body must end in the context it started in", e.name)
+	}
+
+	elseEnd, err := e.escapeList(node.ElseList, ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	if end != elseEnd {
+		return ctx, fmt.Errorf("template %s: branches end in different contexts", e.name)
+	}
+
+	return end, nil
+}
+
+func (e *templateEscaper) escapeAction(node *parse.ActionNode, ctx templateContext) error {
+	// Actions declaring variables produce no output.
+	if len(node.Pipe.Decl) > 0 {
+		return nil
+	}
+
+	var name string
+	switch ctx {
+	case contextText, contextAttrDouble, contextAttrSingle:
+		name = templateEscapeText
+	case contextCode, contextPre:
+		name = templateEscapeCode
+	case contextURL:
+		name = templateEscapeURL
+	default:
+		return fmt.Errorf("template %s: action %s is inside an HTML tag, use a quoted attribute value", e.name, node)
+	}
+
+	node.Pipe.Cmds = append(node.Pipe.Cmds, &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Args:     []parse.Node{parse.NewIdentifier(name).SetTree(nil).SetPos(node.Pos)},
+	})
+
+	return nil
+}
+
+// scan returns the context after the literal text, starting in ctx.
+func (e *templateEscaper) scan(text []byte, ctx templateContext) templateContext {
+	if e.parseMode == ModeHTML {
+		return scanHTMLContext(text, ctx)
+	}
+
+	return scanMarkdownV2Context(text, ctx)
+}
+
+func scanHTMLContext(text []byte, ctx templateContext) templateContext {
+	for _, c := range text {
+		switch ctx {
+		case contextText:
+			if c == '<' {
+				ctx = contextTag
+			}
+		case contextTag:
+			switch c {
+			case '"':
+				ctx = contextAttrDouble
+			case '\'':
+				ctx = contextAttrSingle
+			case '>':
+				ctx = contextText
+			}
+		case contextAttrDouble:
+			if c == '"' {
+				ctx = contextTag
+			}
+		case contextAttrSingle:
+			if c == '\'' {
+				ctx = contextTag
+			}
+		}
+	}
+
+	return ctx
+}
+
+func scanMarkdownV2Context(text []byte, ctx templateContext) templateContext {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if c == '\\' {
+			i++
+			continue
+		}
+
+		switch ctx {
+		case contextText:
+			switch {
+			case bytes.HasPrefix(text[i:], []byte("```")):
+				ctx = contextPre
+				i += 2
+			case c == '`':
+				ctx = contextCode
+			case bytes.HasPrefix(text[i:], []byte("](")):
+				ctx = contextURL
+				i++
+			}
+		case contextCode:
+			if c == '`' {
+				ctx = contextText
+			}
+		case contextPre:
+			if bytes.HasPrefix(text[i:], []byte("```")) {
+				ctx = contextText
+				i += 2
+			}
+		case contextURL:
+			if c == ')' {
+				ctx = contextText
+			}
+		}
+	}
+
+	return ctx
+}
@@ -0,0 +1,82 @@
+package tgbotapi
+
+import (
+	"testing"
+)
+
+func TestMessageTemplateMarkdownV2(t *testing.T) {
+	tmpl := MustParseMessageTemplate("test", ModeMarkdownV2,
+		"*{{.Name}}* `{{.Code}}` [link]({{.URL}}){{if .Items}}{{range .Items}}\n\\- {{.}}{{end}}{{end}}")
+
+	msg, err := tmpl.Message(ChatID, map[string]interface{}{
+		"Name":  "a_b.c",
+		"Code":  "x`y",
+		"URL":   "https://example.com/(x)",
+		"Items": []string{"1.", "2!"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "*a\\_b\\.c* `x\\`y` [link](https://example.com/(x\\))\n\\- 1\\.\n\\- 2\\!"
+	if msg.Text != expected {
+		t.Errorf("expected %q, got %q", expected, msg.Text)
+	}
+
+	if msg.ParseMode != ModeMarkdownV2 || msg.ChatID != ChatID {
+		t.Error("message has the wrong parse mode or chat")
+	}
+}
+
+func TestMessageTemplateHTML(t *testing.T) {
+	tmpl, err := NewMessageTemplate("test", ModeHTML).
+		Funcs(map[string]interface{}{
+			"bold": func(s string) TrustedMarkup { return TrustedMarkup("<b>" + htmlEscaper.Replace(s) + "</b>") },
+		}).
+		Parse(`<a href="{{.URL}}">{{.Name}}</a> {{bold .Name}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := tmpl.Execute(map[string]string{"URL": `https://e.com/?a=1&b="2"`, "Name": "<i>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<a href="https://e.com/?a=1&amp;b=&quot;2&quot;">&lt;i&gt;</a> <b>&lt;i&gt;</b>`
+	if text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+}
+
+func TestMessageTemplateHTMLSingleQuotes(t *testing.T) {
+	tmpl := MustParseMessageTemplate("test", ModeHTML, `<a href='{{.URL}}'>{{.Name}}</a>`)
+
+	text, err := tmpl.Execute(map[string]string{"URL": `https://e.com/' onclick='x`, "Name": "it's"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<a href='https://e.com/&#39; onclick=&#39;x'>it&#39;s</a>`
+	if text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+}
+
+func TestMessageTemplateErrors(t *testing.T) {
+	tests := []struct {
+		parseMode string
+		text      string
+	}{
+		{ModeHTML, `<a href={{.}}>x</a>`},
+		{ModeHTML, `<b>{{if .}}<a href="{{end}}</b>`},
+		{ModeMarkdownV2, "`{{.}}"},
+		{ModeMarkdown, "{{.}}"},
+	}
+
+	for _, test := range tests {
+		if _, err := NewMessageTemplate("test", test.parseMode).Parse(test.text); err == nil {
+			t.Errorf("%s %q: expected an error", test.parseMode, test.text)
+		}
+	}
+}
@@ -61,6 +61,41 @@ type Update struct {
 	//
 	// optional
 	EditedChannelPost *Message `json:"edited_channel_post,omitempty"`
+	// MessageReaction is a reaction to a message changed by a user. The bot
+	// must be an administrator in the chat and must explicitly specify
+	// "message_reaction" in the list of allowed_updates to receive these
+	// updates. The update isn't received for reactions set by bots.
+	//
+	// optional
+	MessageReaction *MessageReactionUpdated `json:"message_reaction,omitempty"`
+	// MessageReactionCount are reactions to a message with anonymous
+	// reactions changed. The bot must be an administrator in the chat and
+	// must explicitly specify "message_reaction_count" in the list of
+	// allowed_updates to receive these updates. The updates are grouped and
+	// can be sent with delay up to a few minutes.
+	//
+	// optional
+	MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count,omitempty"`
+	// BusinessConnection is the bot was connected to or disconnected from a
+	// business account, or a user edited an existing connection with the bot
+	//
+	// optional
+	BusinessConnection *BusinessConnection `json:"business_connection,omitempty"`
+	// BusinessMessage is a new non-service message from a connected business
+	// account
+	//
+	// optional
+	BusinessMessage *Message `json:"business_message,omitempty"`
+	// EditedBusinessMessage is a new version of a message from a connected
+	// business account
+	//
+	// optional
+	EditedBusinessMessage *Message `json:"edited_business_message,omitempty"`
+	// DeletedBusinessMessages are messages deleted from a connected business
+	// account
+	//
+	// optional
+	DeletedBusinessMessages *BusinessMessagesDeleted `json:"deleted_business_messages,omitempty"`
 	// InlineQuery new incoming inline query
 	//
 	// optional
@@ -134,6 +169,22 @@ func (u *Update) SentFrom() *User {
 		return u.ShippingQuery.From
 	case u.PreCheckoutQuery != nil:
 		return u.PreCheckoutQuery.From
+	case u.PollAnswer != nil:
+		return &u.PollAnswer.User
+	case u.MyChatMember != nil:
+		return &u.MyChatMember.From
+	case u.ChatMember != nil:
+		return &u.ChatMember.From
+	case u.ChatJoinRequest != nil:
+		return &u.ChatJoinRequest.From
+	case u.MessageReaction != nil:
+		return u.MessageReaction.User
+	case u.BusinessConnection != nil:
+		return &u.BusinessConnection.User
+	case u.BusinessMessage != nil:
+		return u.BusinessMessage.From
+	case u.EditedBusinessMessage != nil:
+		return u.EditedBusinessMessage.From
 	default:
 		return nil
 	}
@@ -160,6 +211,16 @@ func (u *Update) FromChat() *Chat {
 		return u.EditedChannelPost.Chat
 	case u.CallbackQuery != nil:
 		return u.CallbackQuery.Message.Chat
+	case u.MessageReaction != nil:
+		return &u.MessageReaction.Chat
+	case u.MessageReactionCount != nil:
+		return &u.MessageReactionCount.Chat
+	case u.BusinessMessage != nil:
+		return u.BusinessMessage.Chat
+	case u.EditedBusinessMessage != nil:
+		return u.EditedBusinessMessage.Chat
+	case u.DeletedBusinessMessages != nil:
+		return &u.DeletedBusinessMessages.Chat
 	default:
 		return nil
 	}
@@ -261,6 +322,10 @@ type Chat struct {
 	//
 	// optional
 	LastName string `json:"last_name,omitempty"`
+	// IsForum is true if the supergroup chat is a forum (has topics enabled)
+	//
+	// optional
+	IsForum bool `json:"is_forum,omitempty"`
 	// Photo is a chat photo
 	Photo *ChatPhoto `json:"photo"`
 	// Bio is the bio of the other party in a private chat. Returned only in
@@ -274,6 +339,12 @@ type Chat struct {
 	//
 	// optional
 	HasPrivateForwards bool `json:"has_private_forwards,omitempty"`
+	// AvailableReactions is the list of available reactions allowed in the
+	// chat. If omitted, then all emoji reactions are allowed. Returned only in
+	// getChat.
+	//
+	// optional
+	AvailableReactions []ReactionType `json:"available_reactions,omitempty"`
 	// Description for groups, supergroups and channel chats
 	//
 	// optional
@@ -361,10 +432,21 @@ func (c Chat) ChatConfig() ChatConfig {
 type Message struct {
 	// MessageID is a unique message identifier inside this chat
 	MessageID int `json:"message_id"`
+	// MessageThreadID is a unique identifier of a message thread to which the
+	// message belongs; for supergroups only
+	//
+	// optional
+	MessageThreadID int `json:"message_thread_id,omitempty"`
 	// From is a sender, empty for messages sent to channels;
 	//
 	// optional
 	From *User `json:"from,omitempty"`
+	// SenderBusinessBot is the bot that actually sent the message on behalf of
+	// the business account. Available only for outgoing messages sent on
+	// behalf of the connected business account.
+	//
+	// optional
+	SenderBusinessBot *User `json:"sender_business_bot,omitempty"`
 	// SenderChat is the sender of the message, sent on behalf of a chat. The
 	// channel itself for channel messages. The supergroup itself for messages
 	// from anonymous group administrators. The linked channel for messages
@@ -374,6 +456,14 @@ type Message struct {
 	SenderChat *Chat `json:"sender_chat,omitempty"`
 	// Date of the message was sent in Unix time
 	Date int `json:"date"`
+	// BusinessConnectionID is the unique identifier of the business
+	// connection from which the message was received. If non-empty, the
+	// message belongs to a chat of the corresponding business account that is
+	// independent from any potential bot chat which might share the same
+	// identifier.
+	//
+	// optional
+	BusinessConnectionID string `json:"business_connection_id,omitempty"`
 	// Chat is the conversation the message belongs to
 	Chat *Chat `json:"chat"`
 	// ForwardFrom for forwarded messages, sender of the original message;
@@ -404,6 +494,10 @@ type Message struct {
 	//
 	// optional
 	ForwardDate int `json:"forward_date,omitempty"`
+	// IsTopicMessage is true if the message is sent to a forum topic
+	//
+	// optional
+	IsTopicMessage bool `json:"is_topic_message,omitempty"`
 	// IsAutomaticForward is true if the message is a channel post that was
 	// automatically forwarded to the connected discussion group.
 	//
@@ -415,6 +509,16 @@ type Message struct {
 	//
 	// optional
 	ReplyToMessage *Message `json:"reply_to_message,omitempty"`
+	// ExternalReply is information about the message that is being replied
+	// to, which may come from another chat or forum topic
+	//
+	// optional
+	ExternalReply *ExternalReplyInfo `json:"external_reply,omitempty"`
+	// Quote is the quoted part of the message, for replies that quote part of
+	// the original message
+	//
+	// optional
+	Quote *TextQuote `json:"quote,omitempty"`
 	// ViaBot through which the message was sent;
 	//
 	// optional
@@ -444,6 +548,12 @@ type Message struct {
 	//
 	// optional
 	Entities []MessageEntity `json:"entities,omitempty"`
+	// LinkPreviewOptions are the options used for link preview generation
+	// for the message, if it is a text message and link preview options were
+	// changed
+	//
+	// optional
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
 	// Animation message is an animation, information about the animation.
 	// For backward compatibility, when this field is set, the document field will also be set;
 	//
@@ -594,6 +704,11 @@ type Message struct {
 	//
 	// optional
 	SuccessfulPayment *SuccessfulPayment `json:"successful_payment,omitempty"`
+	// RefundedPayment is a service message about a refunded payment,
+	// information about the payment;
+	//
+	// optional
+	RefundedPayment *RefundedPayment `json:"refunded_payment,omitempty"`
 	// ConnectedWebsite is the domain name of the website on which the user has
 	// logged in;
 	//
@@ -608,6 +723,32 @@ type Message struct {
 	//
 	// optional
 	ProximityAlertTriggered *ProximityAlertTriggered `json:"proximity_alert_triggered,omitempty"`
+	// ForumTopicCreated is a service message: forum topic created
+	//
+	// optional
+	ForumTopicCreated *ForumTopicCreated `json:"forum_topic_created,omitempty"`
+	// ForumTopicEdited is a service message: forum topic edited
+	//
+	// optional
+	ForumTopicEdited *ForumTopicEdited `json:"forum_topic_edited,omitempty"`
+	// ForumTopicClosed is a service message: forum topic closed
+	//
+	// optional
+	ForumTopicClosed *ForumTopicClosed `json:"forum_topic_closed,omitempty"`
+	// ForumTopicReopened is a service message: forum topic reopened
+	//
+	// optional
+	ForumTopicReopened *ForumTopicReopened `json:"forum_topic_reopened,omitempty"`
+	// GeneralForumTopicHidden is a service message: the 'General' forum topic
+	// hidden
+	//
+	// optional
+	GeneralForumTopicHidden *GeneralForumTopicHidden `json:"general_forum_topic_hidden,omitempty"`
+	// GeneralForumTopicUnhidden is a service message: the 'General' forum
+	// topic unhidden
+	//
+	// optional
+	GeneralForumTopicUnhidden *GeneralForumTopicUnhidden `json:"general_forum_topic_unhidden,omitempty"`
 	// VideoChatScheduled is a service message: video chat scheduled.
 	//
 	// optional
@@ -641,6 +782,17 @@ func (m *Message) Time() time.Time {
 	return time.Unix(int64(m.Date), 0)
 }
 
+// TopicID returns the forum topic of the message, or 0 if it was not sent
+// to a topic. Unlike MessageThreadID, it is not set for replies outside of
+// forums.
+func (m *Message) TopicID() int {
+	if !m.IsTopicMessage {
+		return 0
+	}
+
+	return m.MessageThreadID
+}
+
 // IsCommand returns true if message starts with a "bot_command" entity.
 func (m *Message) IsCommand() bool {
 	if m.Entities == nil || len(m.Entities) == 0 {
@@ -726,10 +878,12 @@ type MessageEntity struct {
 	//  “underline” (underlined text),
 	//  “strikethrough” (strikethrough text),
 	//  "spoiler" (spoiler message),
+	//  “blockquote” (block quotation),
 	//  “code” (monowidth string),
 	//  “pre” (monowidth block),
 	//  “text_link” (for clickable text URLs),
-	//  “text_mention” (for users without usernames)
+	//  “text_mention” (for users without usernames),
+	//  “custom_emoji” (for inline custom emoji stickers)
 	Type string `json:"type"`
 	// Offset in UTF-16 code units to the start of the entity
 	Offset int `json:"offset"`
@@ -747,6 +901,11 @@ type MessageEntity struct {
 	//
 	// optional
 	Language string `json:"language,omitempty"`
+	// CustomEmojiID for “custom_emoji” only, unique identifier of the custom
+	// emoji
+	//
+	// optional
+	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
 }
 
 // ParseURL attempts to parse a URL contained within a MessageEntity.
@@ -851,7 +1010,7 @@ type Animation struct {
 	// Thumbnail animation thumbnail as defined by sender
 	//
 	// optional
-	Thumbnail *PhotoSize `json:"thumb,omitempty"`
+	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 	// FileName original animation filename as defined by sender
 	//
 	// optional
@@ -900,7 +1059,7 @@ type Audio struct {
 	// Thumbnail is the album cover to which the music file belongs
 	//
 	// optional
-	Thumbnail *PhotoSize `json:"thumb,omitempty"`
+	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 }
 
 // Document represents a general file.
@@ -915,7 +1074,7 @@ type Document struct {
 	// Thumbnail document thumbnail as defined by sender
 	//
 	// optional
-	Thumbnail *PhotoSize `json:"thumb,omitempty"`
+	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 	// FileName original filename as defined by sender
 	//
 	// optional
@@ -948,7 +1107,7 @@ type Video struct {
 	// Thumbnail video thumbnail
 	//
 	// optional
-	Thumbnail *PhotoSize `json:"thumb,omitempty"`
+	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 	// FileName is the original filename as defined by sender
 	//
 	// optional
@@ -978,7 +1137,7 @@ type VideoNote struct {
 	// Thumbnail video thumbnail
 	//
 	// optional
-	Thumbnail *PhotoSize `json:"thumb,omitempty"`
+	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 	// FileSize file size
 	//
 	// optional
@@ -1194,6 +1353,19 @@ func (m *VideoChatScheduled) Time() time.Time {
 	return time.Unix(int64(m.StartDate), 0)
 }
 
+// ForumTopicEdited represents a service message about an edited forum topic.
+type ForumTopicEdited struct {
+	// Name is the new name of the topic, if it was edited
+	//
+	// optional
+	Name string `json:"name,omitempty"`
+	// IconCustomEmojiID is the new identifier of the custom emoji shown as the
+	// topic icon, if it was edited; an empty string if the icon was removed
+	//
+	// optional
+	IconCustomEmojiID *string `json:"icon_custom_emoji_id,omitempty"`
+}
+
 // VideoChatStarted represents a service message about a voice chat started in
 // the chat.
 type VideoChatStarted struct{}
@@ -1591,6 +1763,7 @@ type ChatAdministratorRights struct {
 	CanPostMessages     bool `json:"can_post_messages"`
 	CanEditMessages     bool `json:"can_edit_messages"`
 	CanPinMessages      bool `json:"can_pin_messages"`
+	CanManageTopics     bool `json:"can_manage_topics"`
 }
 
 // ChatMember contains information about one member of a chat.
@@ -1683,6 +1856,12 @@ type ChatMember struct {
 	//
 	// optional
 	CanPinMessages bool `json:"can_pin_messages,omitempty"`
+	// CanManageTopics administrators and restricted only.
+	// True, if the user is allowed to create, rename, close, and reopen forum
+	// topics; supergroups only
+	//
+	// optional
+	CanManageTopics bool `json:"can_manage_topics,omitempty"`
 	// IsMember is true, if the user is a member of the chat at the moment of
 	// the request
 	IsMember bool `json:"is_member"`
@@ -1806,6 +1985,11 @@ type ChatPermissions struct {
 	//
 	// optional
 	CanPinMessages bool `json:"can_pin_messages,omitempty"`
+	// CanManageTopics is true, if the user is allowed to create forum topics.
+	// If omitted defaults to the value of CanPinMessages
+	//
+	// optional
+	CanManageTopics bool `json:"can_manage_topics,omitempty"`
 }
 
 // ChatLocation represents a location to which a chat is connected.
@@ -1874,7 +2058,7 @@ type BaseInputMedia struct {
 	// or pass “attach://<file_attach_name>” to upload a new one
 	// using multipart/form-data under <file_attach_name> name.
 	Media RequestFileData `json:"media"`
-	// thumb intentionally missing as it is not currently compatible
+	// thumbnail intentionally missing as it is not currently compatible
 
 	// Caption of the video to be sent, 0-1024 characters after entities parsing.
 	//
@@ -1905,7 +2089,9 @@ type InputMediaVideo struct {
 	// the file is supported server-side.
 	//
 	// optional
-	Thumb RequestFileData `json:"thumb,omitempty"`
+	Thumbnail RequestFileData `json:"thumbnail,omitempty"`
+	// Deprecated: use Thumbnail instead.
+	Thumb RequestFileData `json:"-"`
 	// Width video width
 	//
 	// optional
@@ -1931,7 +2117,9 @@ type InputMediaAnimation struct {
 	// the file is supported server-side.
 	//
 	// optional
-	Thumb RequestFileData `json:"thumb,omitempty"`
+	Thumbnail RequestFileData `json:"thumbnail,omitempty"`
+	// Deprecated: use Thumbnail instead.
+	Thumb RequestFileData `json:"-"`
 	// Width video width
 	//
 	// optional
@@ -1953,7 +2141,9 @@ type InputMediaAudio struct {
 	// the file is supported server-side.
 	//
 	// optional
-	Thumb RequestFileData `json:"thumb,omitempty"`
+	Thumbnail RequestFileData `json:"thumbnail,omitempty"`
+	// Deprecated: use Thumbnail instead.
+	Thumb RequestFileData `json:"-"`
 	// Duration of the audio in seconds
 	//
 	// optional
@@ -1975,7 +2165,9 @@ type InputMediaDocument struct {
 	// the file is supported server-side.
 	//
 	// optional
-	Thumb RequestFileData `json:"thumb,omitempty"`
+	Thumbnail RequestFileData `json:"thumbnail,omitempty"`
+	// Deprecated: use Thumbnail instead.
+	Thumb RequestFileData `json:"-"`
 	// DisableContentTypeDetection disables automatic server-side content type
 	// detection for files uploaded using multipart/form-data. Always true, if
 	// the document is sent as part of an album
@@ -1993,6 +2185,10 @@ type Sticker struct {
 	// which is supposed to be the same over time and for different bots.
 	// Can't be used to download or reuse the file.
 	FileUniqueID string `json:"file_unique_id"`
+	// Type of the sticker, currently one of “regular”, “mask”,
+	// “custom_emoji”. The type is independent from the format, given by
+	// IsAnimated and IsVideo.
+	Type string `json:"type"`
 	// Width sticker width
 	Width int `json:"width"`
 	// Height sticker height
@@ -2008,7 +2204,7 @@ type Sticker struct {
 	// Thumbnail sticker thumbnail in the .WEBP or .JPG format
 	//
 	// optional
-	Thumbnail *PhotoSize `json:"thumb,omitempty"`
+	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 	// Emoji associated with the sticker
 	//
 	// optional
@@ -2030,6 +2226,11 @@ type Sticker struct {
 	//
 	// optional
 	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
+	// NeedsRepainting true, if the sticker must be repainted to a text color
+	// in messages, white on chat photos, or another appropriate color
+	//
+	// optional
+	NeedsRepainting bool `json:"needs_repainting,omitempty"`
 	// FileSize
 	//
 	// optional
@@ -2049,11 +2250,16 @@ type StickerSet struct {
 	// IsVideo true, if the sticker set contains video stickers
 	IsVideo bool `json:"is_video"`
 	// ContainsMasks true, if the sticker set contains masks
+	//
+	// Deprecated: use StickerType instead.
 	ContainsMasks bool `json:"contains_masks"`
 	// Stickers list of all set stickers
 	Stickers []Sticker `json:"stickers"`
-	// Thumb is the sticker set thumbnail in the .WEBP or .TGS format
-	Thumbnail *PhotoSize `json:"thumb"`
+	// Thumbnail is the sticker set thumbnail in the .WEBP, .TGS or .WEBM
+	// format
+	//
+	// optional
+	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 }
 
 // MaskPosition describes the position on faces where a mask should be placed
@@ -2074,6 +2280,29 @@ type MaskPosition struct {
 	Scale float64 `json:"scale"`
 }
 
+// InputSticker describes a sticker to be added to a sticker set.
+type InputSticker struct {
+	// Sticker is the added sticker. Animated and video stickers can't be
+	// uploaded via HTTP URL.
+	Sticker RequestFileData `json:"sticker"`
+	// Format of the added sticker, one of StickerFormatStatic for a .WEBP or
+	// .PNG image, StickerFormatAnimated for a .TGS animation or
+	// StickerFormatVideo for a .WEBM video
+	Format string `json:"format"`
+	// EmojiList list of 1-20 emoji associated with the sticker
+	EmojiList []string `json:"emoji_list"`
+	// MaskPosition position where the mask should be placed on faces, for
+	// mask stickers only
+	//
+	// optional
+	MaskPosition *MaskPosition `json:"mask_position,omitempty"`
+	// Keywords list of 0-20 search keywords for the sticker with total length
+	// of up to 64 characters, for regular and custom emoji stickers only
+	//
+	// optional
+	Keywords []string `json:"keywords,omitempty"`
+}
+
 // Game represents a game. Use BotFather to create and edit games, their short
 // names will act as unique identifiers.
 type Game struct {
@@ -2490,18 +2719,24 @@ type InlineQueryResultArticle struct {
 	//
 	// optional
 	Description string `json:"description,omitempty"`
-	// ThumbURL url of the thumbnail for the result
+	// ThumbnailURL url of the thumbnail for the result
 	//
 	// optional
-	ThumbURL string `json:"thumb_url,omitempty"`
-	// ThumbWidth thumbnail width
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
+	// ThumbnailWidth thumbnail width
 	//
 	// optional
-	ThumbWidth int `json:"thumb_width,omitempty"`
-	// ThumbHeight thumbnail height
+	ThumbnailWidth int `json:"thumbnail_width,omitempty"`
+	// Deprecated: use ThumbnailWidth instead.
+	ThumbWidth int `json:"-"`
+	// ThumbnailHeight thumbnail height
 	//
 	// optional
-	ThumbHeight int `json:"thumb_height,omitempty"`
+	ThumbnailHeight int `json:"thumbnail_height,omitempty"`
+	// Deprecated: use ThumbnailHeight instead.
+	ThumbHeight int `json:"-"`
 }
 
 // InlineQueryResultAudio is an inline query response audio.
@@ -2557,9 +2792,16 @@ type InlineQueryResultContact struct {
 	VCard               string                `json:"vcard"`
 	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 	InputMessageContent interface{}           `json:"input_message_content,omitempty"`
-	ThumbURL            string                `json:"thumb_url"`
-	ThumbWidth          int                   `json:"thumb_width"`
-	ThumbHeight         int                   `json:"thumb_height"`
+	ThumbnailURL        string                `json:"thumbnail_url"`
+	ThumbnailWidth      int                   `json:"thumbnail_width"`
+	ThumbnailHeight     int                   `json:"thumbnail_height"`
+
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
+	// Deprecated: use ThumbnailWidth instead.
+	ThumbWidth int `json:"-"`
+	// Deprecated: use ThumbnailHeight instead.
+	ThumbHeight int `json:"-"`
 }
 
 // InlineQueryResultGame is an inline query response game.
@@ -2604,18 +2846,24 @@ type InlineQueryResultDocument struct {
 	//
 	// optional
 	InputMessageContent interface{} `json:"input_message_content,omitempty"`
-	// ThumbURL url of the thumbnail (jpeg only) for the file
+	// ThumbnailURL url of the thumbnail (jpeg only) for the file
 	//
 	// optional
-	ThumbURL string `json:"thumb_url,omitempty"`
-	// ThumbWidth thumbnail width
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
+	// ThumbnailWidth thumbnail width
 	//
 	// optional
-	ThumbWidth int `json:"thumb_width,omitempty"`
-	// ThumbHeight thumbnail height
+	ThumbnailWidth int `json:"thumbnail_width,omitempty"`
+	// Deprecated: use ThumbnailWidth instead.
+	ThumbWidth int `json:"-"`
+	// ThumbnailHeight thumbnail height
 	//
 	// optional
-	ThumbHeight int `json:"thumb_height,omitempty"`
+	ThumbnailHeight int `json:"thumbnail_height,omitempty"`
+	// Deprecated: use ThumbnailHeight instead.
+	ThumbHeight int `json:"-"`
 }
 
 // InlineQueryResultGIF is an inline query response GIF.
@@ -2626,8 +2874,15 @@ type InlineQueryResultGIF struct {
 	ID string `json:"id"`
 	// URL a valid URL for the GIF file. File size must not exceed 1MB.
 	URL string `json:"gif_url"`
-	// ThumbURL url of the static (JPEG or GIF) or animated (MPEG4) thumbnail for the result.
-	ThumbURL string `json:"thumb_url"`
+	// ThumbnailURL url of the static (JPEG or GIF) or animated (MPEG4) thumbnail for the result.
+	ThumbnailURL string `json:"thumbnail_url"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
+	// ThumbnailMimeType MIME type of the thumbnail, must be one of
+	// “image/jpeg”, “image/gif”, or “video/mp4”. Defaults to “image/jpeg”
+	//
+	// optional
+	ThumbnailMimeType string `json:"thumbnail_mime_type,omitempty"`
 	// Width of the GIF
 	//
 	// optional
@@ -2710,18 +2965,24 @@ type InlineQueryResultLocation struct {
 	//
 	// optional
 	InputMessageContent interface{} `json:"input_message_content,omitempty"`
-	// ThumbURL url of the thumbnail for the result
+	// ThumbnailURL url of the thumbnail for the result
 	//
 	// optional
-	ThumbURL string `json:"thumb_url,omitempty"`
-	// ThumbWidth thumbnail width
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
+	// ThumbnailWidth thumbnail width
 	//
 	// optional
-	ThumbWidth int `json:"thumb_width,omitempty"`
-	// ThumbHeight thumbnail height
+	ThumbnailWidth int `json:"thumbnail_width,omitempty"`
+	// Deprecated: use ThumbnailWidth instead.
+	ThumbWidth int `json:"-"`
+	// ThumbnailHeight thumbnail height
 	//
 	// optional
-	ThumbHeight int `json:"thumb_height,omitempty"`
+	ThumbnailHeight int `json:"thumbnail_height,omitempty"`
+	// Deprecated: use ThumbnailHeight instead.
+	ThumbHeight int `json:"-"`
 }
 
 // InlineQueryResultMPEG4GIF is an inline query response MPEG4 GIF.
@@ -2744,8 +3005,15 @@ type InlineQueryResultMPEG4GIF struct {
 	//
 	// optional
 	Duration int `json:"mpeg4_duration,omitempty"`
-	// ThumbURL url of the static (JPEG or GIF) or animated (MPEG4) thumbnail for the result.
-	ThumbURL string `json:"thumb_url"`
+	// ThumbnailURL url of the static (JPEG or GIF) or animated (MPEG4) thumbnail for the result.
+	ThumbnailURL string `json:"thumbnail_url"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
+	// ThumbnailMimeType MIME type of the thumbnail, must be one of
+	// “image/jpeg”, “image/gif”, or “video/mp4”. Defaults to “image/jpeg”
+	//
+	// optional
+	ThumbnailMimeType string `json:"thumbnail_mime_type,omitempty"`
 	// Title for the result
 	//
 	// optional
@@ -2794,10 +3062,12 @@ type InlineQueryResultPhoto struct {
 	//
 	// optional
 	Height int `json:"photo_height,omitempty"`
-	// ThumbURL url of the thumbnail for the photo.
+	// ThumbnailURL url of the thumbnail for the photo.
 	//
 	// optional
-	ThumbURL string `json:"thumb_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
 	// Title for the result
 	//
 	// optional
@@ -2870,18 +3140,24 @@ type InlineQueryResultVenue struct {
 	//
 	// optional
 	InputMessageContent interface{} `json:"input_message_content,omitempty"`
-	// ThumbURL url of the thumbnail for the result
+	// ThumbnailURL url of the thumbnail for the result
 	//
 	// optional
-	ThumbURL string `json:"thumb_url,omitempty"`
-	// ThumbWidth thumbnail width
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
+	// ThumbnailWidth thumbnail width
 	//
 	// optional
-	ThumbWidth int `json:"thumb_width,omitempty"`
-	// ThumbHeight thumbnail height
+	ThumbnailWidth int `json:"thumbnail_width,omitempty"`
+	// Deprecated: use ThumbnailWidth instead.
+	ThumbWidth int `json:"-"`
+	// ThumbnailHeight thumbnail height
 	//
 	// optional
-	ThumbHeight int `json:"thumb_height,omitempty"`
+	ThumbnailHeight int `json:"thumbnail_height,omitempty"`
+	// Deprecated: use ThumbnailHeight instead.
+	ThumbHeight int `json:"-"`
 }
 
 // InlineQueryResultVideo is an inline query response video.
@@ -2895,9 +3171,11 @@ type InlineQueryResultVideo struct {
 	// MimeType of the content of video url, “text/html” or “video/mp4”
 	MimeType string `json:"mime_type"`
 	//
-	// ThumbURL url of the thumbnail (jpeg only) for the video
+	// ThumbnailURL url of the thumbnail (jpeg only) for the video
 	// optional
-	ThumbURL string `json:"thumb_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// Deprecated: use ThumbnailURL instead.
+	ThumbURL string `json:"-"`
 	// Title for the result
 	Title string `json:"title"`
 	// Caption of the video to be sent, 0-1024 characters after entities parsing
@@ -3017,8 +3295,15 @@ type InputTextMessageContent struct {
 	//
 	// optional
 	Entities []MessageEntity `json:"entities,omitempty"`
+	// LinkPreviewOptions are the link preview generation options for the
+	// message
+	//
+	// optional
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
 	// DisableWebPagePreview disables link previews for links in the sent message
 	//
+	// Deprecated: use LinkPreviewOptions instead.
+	//
 	// optional
 	DisableWebPagePreview bool `json:"disable_web_page_preview,omitempty"`
 }
@@ -3275,6 +3560,21 @@ type SuccessfulPayment struct {
 	TotalAmount int `json:"total_amount"`
 	// InvoicePayload bot specified invoice payload
 	InvoicePayload string `json:"invoice_payload"`
+	// SubscriptionExpirationDate expiration date of the subscription, in Unix
+	// time; for recurring payments only
+	//
+	// optional
+	SubscriptionExpirationDate int `json:"subscription_expiration_date,omitempty"`
+	// IsRecurring true, if the payment is a recurring payment for a
+	// subscription
+	//
+	// optional
+	IsRecurring bool `json:"is_recurring,omitempty"`
+	// IsFirstRecurring true, if the payment is the first payment for a
+	// subscription
+	//
+	// optional
+	IsFirstRecurring bool `json:"is_first_recurring,omitempty"`
 	// ShippingOptionID identifier of the shipping option chosen by the user
 	//
 	// optional
@@ -3328,3 +3628,30 @@ type PreCheckoutQuery struct {
 	// optional
 	OrderInfo *OrderInfo `json:"order_info,omitempty"`
 }
+
+// chatIDValue returns the value of a chat ID in JSON, the ID or, if it is
+// 0, the username. It is nil if both are empty.
+func chatIDValue(chatID int64, username string) interface{} {
+	switch {
+	case chatID != 0:
+		return chatID
+	case username != "":
+		return username
+	default:
+		return nil
+	}
+}
+
+// setChatIDValue decodes a chat ID in JSON, which may be a number or a
+// username.
+func setChatIDValue(data json.RawMessage, chatID *int64, username *string) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	if data[0] == '"' {
+		return json.Unmarshal(data, username)
+	}
+
+	return json.Unmarshal(data, chatID)
+}
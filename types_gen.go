@@ -0,0 +1,1865 @@
+// Code generated by tgbotapi-gen from schema/api.json; DO NOT EDIT.
+
+package tgbotapi
+
+import "encoding/json"
+
+// AffiliateInfo contains information about the affiliate that received a
+// commission via this transaction.
+type AffiliateInfo struct {
+	// AffiliateUser the bot or the user that received an affiliate commission
+	// if it was received by a bot or a user
+	//
+	// optional
+	AffiliateUser *User `json:"affiliate_user,omitempty"`
+
+	// AffiliateChat the chat that received an affiliate commission if it was
+	// received by a chat
+	//
+	// optional
+	AffiliateChat *Chat `json:"affiliate_chat,omitempty"`
+
+	// CommissionPerMille the number of Telegram Stars received by the affiliate
+	// for each 1000 Telegram Stars received by the bot from referred users
+	CommissionPerMille int `json:"commission_per_mille"`
+
+	// Amount integer amount of Telegram Stars received by the affiliate from
+	// the transaction, rounded to 0; can be negative for refunds
+	Amount int `json:"amount"`
+
+	// NanostarAmount the number of 1/1000000000 shares of Telegram Stars
+	// received by the affiliate; from -999999999 to 999999999; can be negative
+	// for refunds
+	//
+	// optional
+	NanostarAmount int `json:"nanostar_amount,omitempty"`
+}
+
+// BackgroundFill describes the way a background is filled based on the
+// selected colors. Currently, it can be one of
+//
+// It is one of BackgroundFillSolid, BackgroundFillGradient or
+// BackgroundFillFreeformGradient, merged in a single type.
+type BackgroundFill struct {
+	// Type of the background fill
+	Type string `json:"type"`
+
+	// Color the color of the background fill in the RGB24 format
+	//
+	// optional
+	Color int `json:"color,omitempty"`
+
+	// TopColor top color of the gradient in the RGB24 format
+	//
+	// optional
+	TopColor int `json:"top_color,omitempty"`
+
+	// BottomColor bottom color of the gradient in the RGB24 format
+	//
+	// optional
+	BottomColor int `json:"bottom_color,omitempty"`
+
+	// RotationAngle clockwise rotation angle of the background fill in degrees;
+	// 0-359
+	//
+	// optional
+	RotationAngle int `json:"rotation_angle,omitempty"`
+
+	// Colors a list of the 3 or 4 base colors that are used to generate the
+	// freeform gradient in the RGB24 format
+	//
+	// optional
+	Colors []int `json:"colors,omitempty"`
+}
+
+// BackgroundType describes the type of a background. Currently, it can be
+// one of
+//
+// It is one of BackgroundTypeFill, BackgroundTypeWallpaper,
+// BackgroundTypePattern or BackgroundTypeChatTheme, merged in a single type.
+type BackgroundType struct {
+	// Type of the background
+	Type string `json:"type"`
+
+	// Fill the background fill
+	//
+	// optional
+	Fill *BackgroundFill `json:"fill,omitempty"`
+
+	// DarkThemeDimming dimming of the background in dark themes, as a
+	// percentage; 0-100
+	//
+	// optional
+	DarkThemeDimming int `json:"dark_theme_dimming,omitempty"`
+
+	// Document with the wallpaper
+	//
+	// optional
+	Document *Document `json:"document,omitempty"`
+
+	// IsBlurred True, if the wallpaper is downscaled to fit in a 450x450 square
+	// and then box-blurred with radius 12
+	//
+	// optional
+	IsBlurred bool `json:"is_blurred,omitempty"`
+
+	// IsMoving True, if the background moves slightly when the device is tilted
+	//
+	// optional
+	IsMoving bool `json:"is_moving,omitempty"`
+
+	// Intensity of the pattern when it is shown above the filled background;
+	// 0-100
+	//
+	// optional
+	Intensity int `json:"intensity,omitempty"`
+
+	// IsInverted True, if the background fill must be applied only to the
+	// pattern itself. All other pixels are black in this case. For dark themes
+	// only
+	//
+	// optional
+	IsInverted bool `json:"is_inverted,omitempty"`
+
+	// ThemeName name of the chat theme, which is usually an emoji
+	//
+	// optional
+	ThemeName string `json:"theme_name,omitempty"`
+}
+
+// Birthdate describes the birthdate of a user.
+type Birthdate struct {
+	// Day of the user's birth; 1-31
+	Day int `json:"day"`
+
+	// Month of the user's birth; 1-12
+	Month int `json:"month"`
+
+	// Year of the user's birth
+	//
+	// optional
+	Year int `json:"year,omitempty"`
+}
+
+// BotDescription represents the bot's description.
+type BotDescription struct {
+	// Description the bot's description
+	Description string `json:"description"`
+}
+
+// BotName represents the bot's name.
+type BotName struct {
+	// Name the bot's name
+	Name string `json:"name"`
+}
+
+// BotShortDescription represents the bot's short description.
+type BotShortDescription struct {
+	// ShortDescription the bot's short description
+	ShortDescription string `json:"short_description"`
+}
+
+// BusinessConnection describes the connection of the bot with a business
+// account.
+type BusinessConnection struct {
+	// ID unique identifier of the business connection
+	ID string `json:"id"`
+
+	// User business account user that created the business connection
+	User User `json:"user"`
+
+	// UserChatID identifier of a private chat with the user who created the
+	// business connection. This number may have more than 32 significant bits
+	// and some programming languages may have difficulty/silent defects in
+	// interpreting it. But it has at most 52 significant bits, so a 64-bit
+	// integer or double-precision float type are safe for storing this
+	// identifier.
+	UserChatID int64 `json:"user_chat_id"`
+
+	// Date the connection was established in Unix time
+	Date int `json:"date"`
+
+	// CanReply True, if the bot can act on behalf of the business account in
+	// chats that were active in the last 24 hours
+	CanReply bool `json:"can_reply"`
+
+	// IsEnabled True, if the connection is active
+	IsEnabled bool `json:"is_enabled"`
+}
+
+// BusinessIntro contains information about the start page settings of a
+// Telegram Business account.
+type BusinessIntro struct {
+	// Title text of the business intro
+	//
+	// optional
+	Title string `json:"title,omitempty"`
+
+	// Message text of the business intro
+	//
+	// optional
+	Message string `json:"message,omitempty"`
+
+	// Sticker of the business intro
+	//
+	// optional
+	Sticker *Sticker `json:"sticker,omitempty"`
+}
+
+// BusinessLocation contains information about the location of a Telegram
+// Business account.
+type BusinessLocation struct {
+	// Address of the business
+	Address string `json:"address"`
+
+	// Location of the business
+	//
+	// optional
+	Location *Location `json:"location,omitempty"`
+}
+
+// BusinessMessagesDeleted is received when messages are deleted from a
+// connected business account.
+type BusinessMessagesDeleted struct {
+	// BusinessConnectionID unique identifier of the business connection
+	BusinessConnectionID string `json:"business_connection_id"`
+
+	// Chat information about a chat in the business account. The bot may not
+	// have access to the chat or the corresponding user.
+	Chat Chat `json:"chat"`
+
+	// MessageIDs the list of identifiers of deleted messages in the chat of the
+	// business account
+	MessageIDs []int `json:"message_ids"`
+}
+
+// BusinessOpeningHours describes the opening hours of a business.
+type BusinessOpeningHours struct {
+	// TimeZoneName unique name of the time zone for which the opening hours are
+	// defined
+	TimeZoneName string `json:"time_zone_name"`
+
+	// OpeningHours list of time intervals describing business opening hours
+	OpeningHours []BusinessOpeningHoursInterval `json:"opening_hours"`
+}
+
+// BusinessOpeningHoursInterval describes an interval of time during which a
+// business is open.
+type BusinessOpeningHoursInterval struct {
+	// OpeningMinute the minute's sequence number in a week, starting on Monday,
+	// marking the start of the time interval during which the business is open;
+	// 0 - 7 * 24 * 60
+	OpeningMinute int `json:"opening_minute"`
+
+	// ClosingMinute the minute's sequence number in a week, starting on Monday,
+	// marking the end of the time interval during which the business is open; 0
+	// - 8 * 24 * 60
+	ClosingMinute int `json:"closing_minute"`
+}
+
+// ChatBackground represents a chat background.
+type ChatBackground struct {
+	// Type of the background
+	Type BackgroundType `json:"type"`
+}
+
+// ChatBoost contains information about a chat boost.
+type ChatBoost struct {
+	// BoostID unique identifier of the boost
+	BoostID string `json:"boost_id"`
+
+	// AddDate point in time (Unix timestamp) when the chat was boosted
+	AddDate int `json:"add_date"`
+
+	// ExpirationDate point in time (Unix timestamp) when the boost will
+	// automatically expire, unless the booster's Telegram Premium subscription
+	// is prolonged
+	ExpirationDate int `json:"expiration_date"`
+
+	// Source of the added boost
+	Source ChatBoostSource `json:"source"`
+}
+
+// ChatBoostAdded represents a service message about a user boosting a chat.
+type ChatBoostAdded struct {
+	// BoostCount number of boosts added by the user
+	BoostCount int `json:"boost_count"`
+}
+
+// ChatBoostRemoved represents a boost removed from a chat.
+type ChatBoostRemoved struct {
+	// Chat which was boosted
+	Chat Chat `json:"chat"`
+
+	// BoostID unique identifier of the boost
+	BoostID string `json:"boost_id"`
+
+	// RemoveDate point in time (Unix timestamp) when the boost was removed
+	RemoveDate int `json:"remove_date"`
+
+	// Source of the removed boost
+	Source ChatBoostSource `json:"source"`
+}
+
+// ChatBoostSource describes the source of a chat boost. It can be one of
+//
+// It is one of ChatBoostSourcePremium, ChatBoostSourceGiftCode or
+// ChatBoostSourceGiveaway, merged in a single type.
+type ChatBoostSource struct {
+	// Source of the boost
+	Source string `json:"source"`
+
+	// User that boosted the chat
+	//
+	// optional
+	User *User `json:"user,omitempty"`
+
+	// GiveawayMessageID identifier of a message in the chat with the giveaway;
+	// the message could have been deleted already. May be 0 if the message
+	// isn't sent yet.
+	//
+	// optional
+	GiveawayMessageID int `json:"giveaway_message_id,omitempty"`
+
+	// PrizeStarCount the number of Telegram Stars to be split between giveaway
+	// winners; for Telegram Star giveaways only
+	//
+	// optional
+	PrizeStarCount int `json:"prize_star_count,omitempty"`
+
+	// IsUnclaimed True, if the giveaway was completed, but there was no user to
+	// win the prize
+	//
+	// optional
+	IsUnclaimed bool `json:"is_unclaimed,omitempty"`
+}
+
+// ChatBoostUpdated represents a boost added to a chat or changed.
+type ChatBoostUpdated struct {
+	// Chat which was boosted
+	Chat Chat `json:"chat"`
+
+	// Boost information about the chat boost
+	Boost ChatBoost `json:"boost"`
+}
+
+// ChatFullInfo contains full information about a chat.
+type ChatFullInfo struct {
+	// ID unique identifier for this chat. This number may have more than 32
+	// significant bits and some programming languages may have
+	// difficulty/silent defects in interpreting it. But it has at most 52
+	// significant bits, so a signed 64-bit integer or double-precision float
+	// type are safe for storing this identifier.
+	ID int64 `json:"id"`
+
+	// Type of the chat, can be either “private”, “group”,
+	// “supergroup” or “channel”
+	Type string `json:"type"`
+
+	// Title title, for supergroups, channels and group chats
+	//
+	// optional
+	Title string `json:"title,omitempty"`
+
+	// Username username, for private chats, supergroups and channels if
+	// available
+	//
+	// optional
+	Username string `json:"username,omitempty"`
+
+	// FirstName first name of the other party in a private chat
+	//
+	// optional
+	FirstName string `json:"first_name,omitempty"`
+
+	// LastName last name of the other party in a private chat
+	//
+	// optional
+	LastName string `json:"last_name,omitempty"`
+
+	// IsForum True, if the supergroup chat is a forum (has topics enabled)
+	//
+	// optional
+	IsForum bool `json:"is_forum,omitempty"`
+
+	// AccentColorID identifier of the accent color for the chat name and
+	// backgrounds of the chat photo, reply header, and link preview. See accent
+	// colors for more details.
+	AccentColorID int `json:"accent_color_id"`
+
+	// MaxReactionCount the maximum number of reactions that can be set on a
+	// message in the chat
+	MaxReactionCount int `json:"max_reaction_count"`
+
+	// Photo chat photo
+	//
+	// optional
+	Photo *ChatPhoto `json:"photo,omitempty"`
+
+	// ActiveUsernames if non-empty, the list of all active chat usernames; for
+	// private chats, supergroups and channels
+	//
+	// optional
+	ActiveUsernames []string `json:"active_usernames,omitempty"`
+
+	// Birthdate for private chats, the date of birth of the user
+	//
+	// optional
+	Birthdate *Birthdate `json:"birthdate,omitempty"`
+
+	// BusinessIntro for private chats with business accounts, the intro of the
+	// business
+	//
+	// optional
+	BusinessIntro *BusinessIntro `json:"business_intro,omitempty"`
+
+	// BusinessLocation for private chats with business accounts, the location
+	// of the business
+	//
+	// optional
+	BusinessLocation *BusinessLocation `json:"business_location,omitempty"`
+
+	// BusinessOpeningHours for private chats with business accounts, the
+	// opening hours of the business
+	//
+	// optional
+	BusinessOpeningHours *BusinessOpeningHours `json:"business_opening_hours,omitempty"`
+
+	// PersonalChat for private chats, the personal channel of the user
+	//
+	// optional
+	PersonalChat *Chat `json:"personal_chat,omitempty"`
+
+	// AvailableReactions list of available reactions allowed in the chat. If
+	// omitted, then all emoji reactions are allowed.
+	//
+	// optional
+	AvailableReactions []ReactionType `json:"available_reactions,omitempty"`
+
+	// BackgroundCustomEmojiID custom emoji identifier of the emoji chosen by
+	// the chat for the reply header and link preview background
+	//
+	// optional
+	BackgroundCustomEmojiID string `json:"background_custom_emoji_id,omitempty"`
+
+	// ProfileAccentColorID identifier of the accent color for the chat's
+	// profile background. See profile accent colors for more details.
+	//
+	// optional
+	ProfileAccentColorID int `json:"profile_accent_color_id,omitempty"`
+
+	// ProfileBackgroundCustomEmojiID custom emoji identifier of the emoji
+	// chosen by the chat for its profile background
+	//
+	// optional
+	ProfileBackgroundCustomEmojiID string `json:"profile_background_custom_emoji_id,omitempty"`
+
+	// EmojiStatusCustomEmojiID custom emoji identifier of the emoji status of
+	// the chat or the other party in a private chat
+	//
+	// optional
+	EmojiStatusCustomEmojiID string `json:"emoji_status_custom_emoji_id,omitempty"`
+
+	// EmojiStatusExpirationDate expiration date of the emoji status of the chat
+	// or the other party in a private chat, in Unix time, if any
+	//
+	// optional
+	EmojiStatusExpirationDate int `json:"emoji_status_expiration_date,omitempty"`
+
+	// Bio of the other party in a private chat
+	//
+	// optional
+	Bio string `json:"bio,omitempty"`
+
+	// HasPrivateForwards True, if privacy settings of the other party in the
+	// private chat allows to use tg://user?id=<user_id> links only in chats
+	// with the user
+	//
+	// optional
+	HasPrivateForwards bool `json:"has_private_forwards,omitempty"`
+
+	// HasRestrictedVoiceAndVideoMessages True, if the privacy settings of the
+	// other party restrict sending voice and video note messages in the private
+	// chat
+	//
+	// optional
+	HasRestrictedVoiceAndVideoMessages bool `json:"has_restricted_voice_and_video_messages,omitempty"`
+
+	// JoinToSendMessages True, if users need to join the supergroup before they
+	// can send messages
+	//
+	// optional
+	JoinToSendMessages bool `json:"join_to_send_messages,omitempty"`
+
+	// JoinByRequest True, if all users directly joining the supergroup without
+	// using an invite link need to be approved by supergroup administrators
+	//
+	// optional
+	JoinByRequest bool `json:"join_by_request,omitempty"`
+
+	// Description description, for groups, supergroups and channel chats
+	//
+	// optional
+	Description string `json:"description,omitempty"`
+
+	// InviteLink primary invite link, for groups, supergroups and channel chats
+	//
+	// optional
+	InviteLink string `json:"invite_link,omitempty"`
+
+	// PinnedMessage the most recent pinned message (by sending date)
+	//
+	// optional
+	PinnedMessage *Message `json:"pinned_message,omitempty"`
+
+	// Permissions default chat member permissions, for groups and supergroups
+	//
+	// optional
+	Permissions *ChatPermissions `json:"permissions,omitempty"`
+
+	// CanSendGift True, if gifts can be sent to the chat
+	//
+	// optional
+	CanSendGift bool `json:"can_send_gift,omitempty"`
+
+	// CanSendPaidMedia True, if paid media messages can be sent or forwarded to
+	// the channel chat. The field is available only for channel chats.
+	//
+	// optional
+	CanSendPaidMedia bool `json:"can_send_paid_media,omitempty"`
+
+	// SlowModeDelay for supergroups, the minimum allowed delay between
+	// consecutive messages sent by each unprivileged user; in seconds
+	//
+	// optional
+	SlowModeDelay int `json:"slow_mode_delay,omitempty"`
+
+	// UnrestrictBoostCount for supergroups, the minimum number of boosts that a
+	// non-administrator user needs to add in order to ignore slow mode and chat
+	// permissions
+	//
+	// optional
+	UnrestrictBoostCount int `json:"unrestrict_boost_count,omitempty"`
+
+	// MessageAutoDeleteTime the time after which all messages sent to the chat
+	// will be automatically deleted; in seconds
+	//
+	// optional
+	MessageAutoDeleteTime int `json:"message_auto_delete_time,omitempty"`
+
+	// HasAggressiveAntiSpamEnabled True, if aggressive anti-spam checks are
+	// enabled in the supergroup. The field is only available to chat
+	// administrators.
+	//
+	// optional
+	HasAggressiveAntiSpamEnabled bool `json:"has_aggressive_anti_spam_enabled,omitempty"`
+
+	// HasHiddenMembers True, if non-administrators can only get the list of
+	// bots and administrators in the chat
+	//
+	// optional
+	HasHiddenMembers bool `json:"has_hidden_members,omitempty"`
+
+	// HasProtectedContent True, if messages from the chat can't be forwarded to
+	// other chats
+	//
+	// optional
+	HasProtectedContent bool `json:"has_protected_content,omitempty"`
+
+	// HasVisibleHistory True, if new chat members will have access to old
+	// messages; available only to chat administrators
+	//
+	// optional
+	HasVisibleHistory bool `json:"has_visible_history,omitempty"`
+
+	// StickerSetName for supergroups, name of the group sticker set
+	//
+	// optional
+	StickerSetName string `json:"sticker_set_name,omitempty"`
+
+	// CanSetStickerSet True, if the bot can change the group sticker set
+	//
+	// optional
+	CanSetStickerSet bool `json:"can_set_sticker_set,omitempty"`
+
+	// CustomEmojiStickerSetName for supergroups, the name of the group's custom
+	// emoji sticker set. Custom emoji from this set can be used by all users
+	// and bots in the group.
+	//
+	// optional
+	CustomEmojiStickerSetName string `json:"custom_emoji_sticker_set_name,omitempty"`
+
+	// LinkedChatID unique identifier for the linked chat, i.e. the discussion
+	// group identifier for a channel and vice versa; for supergroups and
+	// channel chats. This identifier may be greater than 32 bits and some
+	// programming languages may have difficulty/silent defects in interpreting
+	// it. But it is smaller than 52 bits, so a signed 64 bit integer or
+	// double-precision float type are safe for storing this identifier.
+	//
+	// optional
+	LinkedChatID int64 `json:"linked_chat_id,omitempty"`
+
+	// Location for supergroups, the location to which the supergroup is
+	// connected
+	//
+	// optional
+	Location *ChatLocation `json:"location,omitempty"`
+}
+
+// ChatShared contains information about a chat that was shared with the bot
+// using a KeyboardButtonRequestChat button.
+type ChatShared struct {
+	// RequestID identifier of the request
+	RequestID int `json:"request_id"`
+
+	// ChatID identifier of the shared chat. This number may have more than 32
+	// significant bits and some programming languages may have
+	// difficulty/silent defects in interpreting it. But it has at most 52
+	// significant bits, so a 64-bit integer or double-precision float type are
+	// safe for storing this identifier. The bot may not have access to the chat
+	// and could be unable to use this identifier, unless the chat is already
+	// known to the bot by some other means.
+	ChatID int64 `json:"chat_id"`
+
+	// Title of the chat, if the title was requested by the bot.
+	//
+	// optional
+	Title string `json:"title,omitempty"`
+
+	// Username of the chat, if the username was requested by the bot and
+	// available.
+	//
+	// optional
+	Username string `json:"username,omitempty"`
+
+	// Photo available sizes of the chat photo, if the photo was requested by
+	// the bot
+	//
+	// optional
+	Photo []PhotoSize `json:"photo,omitempty"`
+}
+
+// CopyTextButton represents an inline keyboard button that copies specified
+// text to the clipboard.
+type CopyTextButton struct {
+	// Text the text to be copied to the clipboard; 1-256 characters
+	Text string `json:"text"`
+}
+
+// ExternalReplyInfo contains information about a message that is being
+// replied to, which may come from another chat or forum topic.
+type ExternalReplyInfo struct {
+	// Origin of the message replied to by the given message
+	Origin MessageOrigin `json:"origin"`
+
+	// Chat the original message belongs to. Available only if the chat is a
+	// supergroup or a channel.
+	//
+	// optional
+	Chat *Chat `json:"chat,omitempty"`
+
+	// MessageID unique message identifier inside the original chat. Available
+	// only if the original chat is a supergroup or a channel.
+	//
+	// optional
+	MessageID int `json:"message_id,omitempty"`
+
+	// LinkPreviewOptions options used for link preview generation for the
+	// original message, if it is a text message
+	//
+	// optional
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+
+	// Animation message is an animation, information about the animation
+	//
+	// optional
+	Animation *Animation `json:"animation,omitempty"`
+
+	// Audio message is an audio file, information about the file
+	//
+	// optional
+	Audio *Audio `json:"audio,omitempty"`
+
+	// Document message is a general file, information about the file
+	//
+	// optional
+	Document *Document `json:"document,omitempty"`
+
+	// PaidMedia message contains paid media; information about the paid media
+	//
+	// optional
+	PaidMedia *PaidMediaInfo `json:"paid_media,omitempty"`
+
+	// Photo message is a photo, available sizes of the photo
+	//
+	// optional
+	Photo []PhotoSize `json:"photo,omitempty"`
+
+	// Sticker message is a sticker, information about the sticker
+	//
+	// optional
+	Sticker *Sticker `json:"sticker,omitempty"`
+
+	// Story message is a forwarded story
+	//
+	// optional
+	Story *Story `json:"story,omitempty"`
+
+	// Video message is a video, information about the video
+	//
+	// optional
+	Video *Video `json:"video,omitempty"`
+
+	// VideoNote message is a video note, information about the video message
+	//
+	// optional
+	VideoNote *VideoNote `json:"video_note,omitempty"`
+
+	// Voice message is a voice message, information about the file
+	//
+	// optional
+	Voice *Voice `json:"voice,omitempty"`
+
+	// HasMediaSpoiler True, if the message media is covered by a spoiler
+	// animation
+	//
+	// optional
+	HasMediaSpoiler bool `json:"has_media_spoiler,omitempty"`
+
+	// Contact message is a shared contact, information about the contact
+	//
+	// optional
+	Contact *Contact `json:"contact,omitempty"`
+
+	// Dice message is a dice with random value
+	//
+	// optional
+	Dice *Dice `json:"dice,omitempty"`
+
+	// Game message is a game, information about the game. More about games »
+	//
+	// optional
+	Game *Game `json:"game,omitempty"`
+
+	// Giveaway message is a scheduled giveaway, information about the giveaway
+	//
+	// optional
+	Giveaway *Giveaway `json:"giveaway,omitempty"`
+
+	// GiveawayWinners a giveaway with public winners was completed
+	//
+	// optional
+	GiveawayWinners *GiveawayWinners `json:"giveaway_winners,omitempty"`
+
+	// Invoice message is an invoice for a payment, information about the
+	// invoice. More about payments »
+	//
+	// optional
+	Invoice *Invoice `json:"invoice,omitempty"`
+
+	// Location message is a shared location, information about the location
+	//
+	// optional
+	Location *Location `json:"location,omitempty"`
+
+	// Poll message is a native poll, information about the poll
+	//
+	// optional
+	Poll *Poll `json:"poll,omitempty"`
+
+	// Venue message is a venue, information about the venue
+	//
+	// optional
+	Venue *Venue `json:"venue,omitempty"`
+}
+
+// ForumTopic represents a forum topic.
+type ForumTopic struct {
+	// MessageThreadID unique identifier of the forum topic
+	MessageThreadID int `json:"message_thread_id"`
+
+	// Name of the topic
+	Name string `json:"name"`
+
+	// IconColor color of the topic icon in RGB format
+	IconColor int `json:"icon_color"`
+
+	// IconCustomEmojiID unique identifier of the custom emoji shown as the
+	// topic icon
+	//
+	// optional
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicClosed represents a service message about a forum topic closed
+// in the chat. Currently holds no information.
+type ForumTopicClosed struct{}
+
+// ForumTopicCreated represents a service message about a new forum topic
+// created in the chat.
+type ForumTopicCreated struct {
+	// Name of the topic
+	Name string `json:"name"`
+
+	// IconColor color of the topic icon in RGB format
+	IconColor int `json:"icon_color"`
+
+	// IconCustomEmojiID unique identifier of the custom emoji shown as the
+	// topic icon
+	//
+	// optional
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+// ForumTopicReopened represents a service message about a forum topic
+// reopened in the chat. Currently holds no information.
+type ForumTopicReopened struct{}
+
+// GeneralForumTopicHidden represents a service message about General forum
+// topic hidden in the chat. Currently holds no information.
+type GeneralForumTopicHidden struct{}
+
+// GeneralForumTopicUnhidden represents a service message about General forum
+// topic unhidden in the chat. Currently holds no information.
+type GeneralForumTopicUnhidden struct{}
+
+// Gift represents a gift that can be sent by the bot.
+type Gift struct {
+	// ID unique identifier of the gift
+	ID string `json:"id"`
+
+	// Sticker the sticker that represents the gift
+	Sticker Sticker `json:"sticker"`
+
+	// StarCount the number of Telegram Stars that must be paid to send the
+	// sticker
+	StarCount int `json:"star_count"`
+
+	// UpgradeStarCount the number of Telegram Stars that must be paid to
+	// upgrade the gift to a unique one
+	//
+	// optional
+	UpgradeStarCount int `json:"upgrade_star_count,omitempty"`
+
+	// TotalCount the total number of the gifts of this type that can be sent;
+	// for limited gifts only
+	//
+	// optional
+	TotalCount int `json:"total_count,omitempty"`
+
+	// RemainingCount the number of remaining gifts of this type that can be
+	// sent; for limited gifts only
+	//
+	// optional
+	RemainingCount int `json:"remaining_count,omitempty"`
+}
+
+// Gifts represent a list of gifts.
+type Gifts struct {
+	// Gifts the list of gifts
+	Gifts []Gift `json:"gifts"`
+}
+
+// Giveaway represents a message about a scheduled giveaway.
+type Giveaway struct {
+	// Chats the list of chats which the user must join to participate in the
+	// giveaway
+	Chats []Chat `json:"chats"`
+
+	// WinnersSelectionDate point in time (Unix timestamp) when winners of the
+	// giveaway will be selected
+	WinnersSelectionDate int `json:"winners_selection_date"`
+
+	// WinnerCount the number of users which are supposed to be selected as
+	// winners of the giveaway
+	WinnerCount int `json:"winner_count"`
+
+	// OnlyNewMembers True, if only users who join the chats after the giveaway
+	// started should be eligible to win
+	//
+	// optional
+	OnlyNewMembers bool `json:"only_new_members,omitempty"`
+
+	// HasPublicWinners True, if the list of giveaway winners will be visible to
+	// everyone
+	//
+	// optional
+	HasPublicWinners bool `json:"has_public_winners,omitempty"`
+
+	// PrizeDescription description of additional giveaway prize
+	//
+	// optional
+	PrizeDescription string `json:"prize_description,omitempty"`
+
+	// CountryCodes a list of two-letter ISO 3166-1 alpha-2 country codes
+	// indicating the countries from which eligible users for the giveaway must
+	// come. If empty, then all users can participate in the giveaway. Users
+	// with a phone number that was bought on Fragment can always participate in
+	// giveaways.
+	//
+	// optional
+	CountryCodes []string `json:"country_codes,omitempty"`
+
+	// PrizeStarCount the number of Telegram Stars to be split between giveaway
+	// winners; for Telegram Star giveaways only
+	//
+	// optional
+	PrizeStarCount int `json:"prize_star_count,omitempty"`
+
+	// PremiumSubscriptionMonthCount the number of months the Telegram Premium
+	// subscription won from the giveaway will be active for; for Telegram
+	// Premium giveaways only
+	//
+	// optional
+	PremiumSubscriptionMonthCount int `json:"premium_subscription_month_count,omitempty"`
+}
+
+// GiveawayCompleted represents a service message about the completion of a
+// giveaway without public winners.
+type GiveawayCompleted struct {
+	// WinnerCount number of winners in the giveaway
+	WinnerCount int `json:"winner_count"`
+
+	// UnclaimedPrizeCount number of undistributed prizes
+	//
+	// optional
+	UnclaimedPrizeCount int `json:"unclaimed_prize_count,omitempty"`
+
+	// GiveawayMessage message with the giveaway that was completed, if it
+	// wasn't deleted
+	//
+	// optional
+	GiveawayMessage *Message `json:"giveaway_message,omitempty"`
+
+	// IsStarGiveaway True, if the giveaway is a Telegram Star giveaway.
+	// Otherwise, currently, the giveaway is a Telegram Premium giveaway.
+	//
+	// optional
+	IsStarGiveaway bool `json:"is_star_giveaway,omitempty"`
+}
+
+// GiveawayCreated represents a service message about the creation of a
+// scheduled giveaway.
+type GiveawayCreated struct {
+	// PrizeStarCount the number of Telegram Stars to be split between giveaway
+	// winners; for Telegram Star giveaways only
+	//
+	// optional
+	PrizeStarCount int `json:"prize_star_count,omitempty"`
+}
+
+// GiveawayWinners represents a message about the completion of a giveaway
+// with public winners.
+type GiveawayWinners struct {
+	// Chat the chat that created the giveaway
+	Chat Chat `json:"chat"`
+
+	// GiveawayMessageID identifier of the message with the giveaway in the chat
+	GiveawayMessageID int `json:"giveaway_message_id"`
+
+	// WinnersSelectionDate point in time (Unix timestamp) when winners of the
+	// giveaway were selected
+	WinnersSelectionDate int `json:"winners_selection_date"`
+
+	// WinnerCount total number of winners in the giveaway
+	WinnerCount int `json:"winner_count"`
+
+	// Winners list of up to 100 winners of the giveaway
+	Winners []User `json:"winners"`
+
+	// AdditionalChatCount the number of other chats the user had to join in
+	// order to be eligible for the giveaway
+	//
+	// optional
+	AdditionalChatCount int `json:"additional_chat_count,omitempty"`
+
+	// PrizeStarCount the number of Telegram Stars that were split between
+	// giveaway winners; for Telegram Star giveaways only
+	//
+	// optional
+	PrizeStarCount int `json:"prize_star_count,omitempty"`
+
+	// PremiumSubscriptionMonthCount the number of months the Telegram Premium
+	// subscription won from the giveaway will be active for; for Telegram
+	// Premium giveaways only
+	//
+	// optional
+	PremiumSubscriptionMonthCount int `json:"premium_subscription_month_count,omitempty"`
+
+	// UnclaimedPrizeCount number of undistributed prizes
+	//
+	// optional
+	UnclaimedPrizeCount int `json:"unclaimed_prize_count,omitempty"`
+
+	// OnlyNewMembers True, if only users who had joined the chats after the
+	// giveaway started were eligible to win
+	//
+	// optional
+	OnlyNewMembers bool `json:"only_new_members,omitempty"`
+
+	// WasRefunded True, if the giveaway was canceled because the payment for it
+	// was refunded
+	//
+	// optional
+	WasRefunded bool `json:"was_refunded,omitempty"`
+
+	// PrizeDescription description of additional giveaway prize
+	//
+	// optional
+	PrizeDescription string `json:"prize_description,omitempty"`
+}
+
+// InaccessibleMessage describes a message that was deleted or is otherwise
+// inaccessible to the bot.
+type InaccessibleMessage struct {
+	// Chat the message belonged to
+	Chat Chat `json:"chat"`
+
+	// MessageID unique message identifier inside the chat
+	MessageID int `json:"message_id"`
+
+	// Date always 0. The field can be used to differentiate regular and
+	// inaccessible messages.
+	Date int `json:"date"`
+}
+
+// InlineQueryResultsButton represents a button to be shown above inline
+// query results. You must use exactly one of the optional fields.
+type InlineQueryResultsButton struct {
+	// Text label text on the button
+	Text string `json:"text"`
+
+	// WebApp description of the Web App that will be launched when the user
+	// presses the button. The Web App will be able to switch back to the inline
+	// mode using the method switchInlineQuery inside the Web App.
+	//
+	// optional
+	WebApp *WebAppInfo `json:"web_app,omitempty"`
+
+	// StartParameter deep-linking parameter for the /start message sent to the
+	// bot when a user presses the button. 1-64 characters, only A-Z, a-z, 0-9,
+	// _ and - are allowed. Example: An inline bot that sends YouTube videos can
+	// ask the user to connect the bot to their YouTube account to adapt search
+	// results accordingly. To do this, it displays a 'Connect your YouTube
+	// account' button above the results, or even before showing any. The user
+	// presses the button, switches to a private chat with the bot and, in doing
+	// so, passes a start parameter that instructs the bot to return an OAuth
+	// link. Once done, the bot can offer a switch_inline button so that the
+	// user can easily return to the chat where they wanted to use the bot's
+	// inline capabilities.
+	//
+	// optional
+	StartParameter string `json:"start_parameter,omitempty"`
+}
+
+// InputPaidMedia describes the paid media to be sent. Currently, it can be
+// one of
+//
+// It is one of InputPaidMediaPhoto or InputPaidMediaVideo, merged in a
+// single type.
+type InputPaidMedia struct {
+	// Type of the media, must be photo
+	Type string `json:"type"`
+
+	// Media file to send. Pass a file_id to send a file that exists on the
+	// Telegram servers (recommended), pass an HTTP URL for Telegram to get a
+	// file from the Internet, or pass “attach://<file_attach_name>” to
+	// upload a new one using multipart/form-data under <file_attach_name> name.
+	// More information on Sending Files »
+	Media string `json:"media"`
+
+	// Thumbnail of the file sent; can be ignored if thumbnail generation for
+	// the file is supported server-side. The thumbnail should be in JPEG format
+	// and less than 200 kB in size. A thumbnail's width and height should not
+	// exceed 320. Ignored if the file is not uploaded using
+	// multipart/form-data. Thumbnails can't be reused and can be only uploaded
+	// as a new file, so you can pass “attach://<file_attach_name>” if the
+	// thumbnail was uploaded using multipart/form-data under
+	// <file_attach_name>. More information on Sending Files »
+	//
+	// optional
+	Thumbnail string `json:"thumbnail,omitempty"`
+
+	// Cover for the video in the message. Pass a file_id to send a file that
+	// exists on the Telegram servers (recommended), pass an HTTP URL for
+	// Telegram to get a file from the Internet, or pass
+	// “attach://<file_attach_name>” to upload a new one using
+	// multipart/form-data under <file_attach_name> name. More information on
+	// Sending Files »
+	//
+	// optional
+	Cover string `json:"cover,omitempty"`
+
+	// StartTimestamp start timestamp for the video in the message
+	//
+	// optional
+	StartTimestamp int `json:"start_timestamp,omitempty"`
+
+	// Width video width
+	//
+	// optional
+	Width int `json:"width,omitempty"`
+
+	// Height video height
+	//
+	// optional
+	Height int `json:"height,omitempty"`
+
+	// Duration video duration in seconds
+	//
+	// optional
+	Duration int `json:"duration,omitempty"`
+
+	// SupportsStreaming pass True if the uploaded video is suitable for
+	// streaming
+	//
+	// optional
+	SupportsStreaming bool `json:"supports_streaming,omitempty"`
+}
+
+// InputPollOption contains information about one answer option in a poll to
+// be sent.
+type InputPollOption struct {
+	// Text option text, 1-100 characters
+	Text string `json:"text"`
+
+	// TextParseMode mode for parsing entities in the text. See formatting
+	// options for more details. Currently, only custom emoji entities are
+	// allowed
+	//
+	// optional
+	TextParseMode string `json:"text_parse_mode,omitempty"`
+
+	// TextEntities a JSON-serialized list of special entities that appear in
+	// the poll option text. It can be specified instead of text_parse_mode
+	//
+	// optional
+	TextEntities []MessageEntity `json:"text_entities,omitempty"`
+}
+
+// KeyboardButtonRequestChat defines the criteria used to request a suitable
+// chat. Information about the selected chat will be shared with the bot when
+// the corresponding button is pressed. The bot will be granted requested
+// rights in the chat if appropriate. More about requesting chats ».
+type KeyboardButtonRequestChat struct {
+	// RequestID signed 32-bit identifier of the request, which will be received
+	// back in the ChatShared object. Must be unique within the message
+	RequestID int `json:"request_id"`
+
+	// ChatIsChannel pass True to request a channel chat, pass False to request
+	// a group or a supergroup chat.
+	ChatIsChannel bool `json:"chat_is_channel"`
+
+	// ChatIsForum pass True to request a forum supergroup, pass False to
+	// request a non-forum chat. If not specified, no additional restrictions
+	// are applied.
+	//
+	// optional
+	ChatIsForum bool `json:"chat_is_forum,omitempty"`
+
+	// ChatHasUsername pass True to request a supergroup or a channel with a
+	// username, pass False to request a chat without a username. If not
+	// specified, no additional restrictions are applied.
+	//
+	// optional
+	ChatHasUsername bool `json:"chat_has_username,omitempty"`
+
+	// ChatIsCreated pass True to request a chat owned by the user. Otherwise,
+	// no additional restrictions are applied.
+	//
+	// optional
+	ChatIsCreated bool `json:"chat_is_created,omitempty"`
+
+	// UserAdministratorRights a JSON-serialized object listing the required
+	// administrator rights of the user in the chat. The rights must be a
+	// superset of bot_administrator_rights. If not specified, no additional
+	// restrictions are applied.
+	//
+	// optional
+	UserAdministratorRights *ChatAdministratorRights `json:"user_administrator_rights,omitempty"`
+
+	// BotAdministratorRights a JSON-serialized object listing the required
+	// administrator rights of the bot in the chat. The rights must be a subset
+	// of user_administrator_rights. If not specified, no additional
+	// restrictions are applied.
+	//
+	// optional
+	BotAdministratorRights *ChatAdministratorRights `json:"bot_administrator_rights,omitempty"`
+
+	// BotIsMember pass True to request a chat with the bot as a member.
+	// Otherwise, no additional restrictions are applied.
+	//
+	// optional
+	BotIsMember bool `json:"bot_is_member,omitempty"`
+
+	// RequestTitle pass True to request the chat's title
+	//
+	// optional
+	RequestTitle bool `json:"request_title,omitempty"`
+
+	// RequestUsername pass True to request the chat's username
+	//
+	// optional
+	RequestUsername bool `json:"request_username,omitempty"`
+
+	// RequestPhoto pass True to request the chat's photo
+	//
+	// optional
+	RequestPhoto bool `json:"request_photo,omitempty"`
+}
+
+// KeyboardButtonRequestUsers defines the criteria used to request suitable
+// users. Information about the selected users will be shared with the bot
+// when the corresponding button is pressed. More about requesting users »
+type KeyboardButtonRequestUsers struct {
+	// RequestID signed 32-bit identifier of the request that will be received
+	// back in the UsersShared object. Must be unique within the message
+	RequestID int `json:"request_id"`
+
+	// UserIsBot pass True to request bots, pass False to request regular users.
+	// If not specified, no additional restrictions are applied.
+	//
+	// optional
+	UserIsBot bool `json:"user_is_bot,omitempty"`
+
+	// UserIsPremium pass True to request premium users, pass False to request
+	// non-premium users. If not specified, no additional restrictions are
+	// applied.
+	//
+	// optional
+	UserIsPremium bool `json:"user_is_premium,omitempty"`
+
+	// MaxQuantity the maximum number of users to be selected; 1-10. Defaults to
+	// 1.
+	//
+	// optional
+	MaxQuantity int `json:"max_quantity,omitempty"`
+
+	// RequestName pass True to request the users' first and last names
+	//
+	// optional
+	RequestName bool `json:"request_name,omitempty"`
+
+	// RequestUsername pass True to request the users' usernames
+	//
+	// optional
+	RequestUsername bool `json:"request_username,omitempty"`
+
+	// RequestPhoto pass True to request the users' photos
+	//
+	// optional
+	RequestPhoto bool `json:"request_photo,omitempty"`
+}
+
+// LinkPreviewOptions describes the options used for link preview generation.
+type LinkPreviewOptions struct {
+	// IsDisabled True, if the link preview is disabled
+	//
+	// optional
+	IsDisabled bool `json:"is_disabled,omitempty"`
+
+	// URL to use for the link preview. If empty, then the first URL found in
+	// the message text will be used
+	//
+	// optional
+	URL string `json:"url,omitempty"`
+
+	// PreferSmallMedia True, if the media in the link preview is supposed to be
+	// shrunk; ignored if the URL isn't explicitly specified or media size
+	// change isn't supported for the preview
+	//
+	// optional
+	PreferSmallMedia bool `json:"prefer_small_media,omitempty"`
+
+	// PreferLargeMedia True, if the media in the link preview is supposed to be
+	// enlarged; ignored if the URL isn't explicitly specified or media size
+	// change isn't supported for the preview
+	//
+	// optional
+	PreferLargeMedia bool `json:"prefer_large_media,omitempty"`
+
+	// ShowAboveText True, if the link preview must be shown above the message
+	// text; otherwise, the link preview will be shown below the message text
+	//
+	// optional
+	ShowAboveText bool `json:"show_above_text,omitempty"`
+}
+
+// MessageOrigin describes the origin of a message. It can be one of
+//
+// It is one of MessageOriginUser, MessageOriginHiddenUser, MessageOriginChat
+// or MessageOriginChannel, merged in a single type.
+type MessageOrigin struct {
+	// Type of the message origin
+	Type string `json:"type"`
+
+	// Date the message was sent originally in Unix time
+	Date int `json:"date"`
+
+	// SenderUser user that sent the message originally
+	//
+	// optional
+	SenderUser *User `json:"sender_user,omitempty"`
+
+	// SenderUserName name of the user that sent the message originally
+	//
+	// optional
+	SenderUserName string `json:"sender_user_name,omitempty"`
+
+	// SenderChat chat that sent the message originally
+	//
+	// optional
+	SenderChat *Chat `json:"sender_chat,omitempty"`
+
+	// AuthorSignature for messages originally sent by an anonymous chat
+	// administrator, original message author signature
+	//
+	// optional
+	AuthorSignature string `json:"author_signature,omitempty"`
+
+	// Chat channel chat to which the message was originally sent
+	//
+	// optional
+	Chat *Chat `json:"chat,omitempty"`
+
+	// MessageID unique message identifier inside the chat
+	//
+	// optional
+	MessageID int `json:"message_id,omitempty"`
+}
+
+// MessageReactionCountUpdated represents reaction changes on a message with
+// anonymous reactions.
+type MessageReactionCountUpdated struct {
+	// Chat the chat containing the message
+	Chat Chat `json:"chat"`
+
+	// MessageID unique message identifier inside the chat
+	MessageID int `json:"message_id"`
+
+	// Date of the change in Unix time
+	Date int `json:"date"`
+
+	// Reactions list of reactions that are present on the message
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// MessageReactionUpdated represents a change of a reaction on a message
+// performed by a user.
+type MessageReactionUpdated struct {
+	// Chat the chat containing the message the user reacted to
+	Chat Chat `json:"chat"`
+
+	// MessageID unique identifier of the message inside the chat
+	MessageID int `json:"message_id"`
+
+	// User the user that changed the reaction, if the user isn't anonymous
+	//
+	// optional
+	User *User `json:"user,omitempty"`
+
+	// ActorChat the chat on behalf of which the reaction was changed, if the
+	// user is anonymous
+	//
+	// optional
+	ActorChat *Chat `json:"actor_chat,omitempty"`
+
+	// Date of the change in Unix time
+	Date int `json:"date"`
+
+	// OldReaction previous list of reaction types that were set by the user
+	OldReaction []ReactionType `json:"old_reaction"`
+
+	// NewReaction new list of reaction types that have been set by the user
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+// PaidMedia describes paid media. Currently, it can be one of
+//
+// It is one of PaidMediaPreview, PaidMediaPhoto or PaidMediaVideo, merged in
+// a single type.
+type PaidMedia struct {
+	// Type of the paid media
+	Type string `json:"type"`
+
+	// Width media width as defined by the sender
+	//
+	// optional
+	Width int `json:"width,omitempty"`
+
+	// Height media height as defined by the sender
+	//
+	// optional
+	Height int `json:"height,omitempty"`
+
+	// Duration of the media in seconds as defined by the sender
+	//
+	// optional
+	Duration int `json:"duration,omitempty"`
+
+	// Photo the photo
+	//
+	// optional
+	Photo []PhotoSize `json:"photo,omitempty"`
+
+	// Video the video
+	//
+	// optional
+	Video *Video `json:"video,omitempty"`
+}
+
+// PaidMediaInfo describes the paid media added to a message.
+type PaidMediaInfo struct {
+	// StarCount the number of Telegram Stars that must be paid to buy access to
+	// the media
+	StarCount int `json:"star_count"`
+
+	// PaidMedia information about the paid media
+	PaidMedia []PaidMedia `json:"paid_media"`
+}
+
+// PaidMediaPurchased contains information about a paid media purchase.
+type PaidMediaPurchased struct {
+	// From user who purchased the media
+	From User `json:"from"`
+
+	// PaidMediaPayload bot-specified paid media payload
+	PaidMediaPayload string `json:"paid_media_payload"`
+}
+
+// PreparedInlineMessage describes an inline message to be sent by a user of
+// a Mini App.
+type PreparedInlineMessage struct {
+	// ID unique identifier of the prepared message
+	ID string `json:"id"`
+
+	// ExpirationDate expiration date of the prepared message, in Unix time.
+	// Expired prepared messages can no longer be used
+	ExpirationDate int `json:"expiration_date"`
+}
+
+// ReactionCount represents a reaction added to a message along with the
+// number of times it was added.
+type ReactionCount struct {
+	// Type of the reaction
+	Type ReactionType `json:"type"`
+
+	// TotalCount number of times the reaction was added
+	TotalCount int `json:"total_count"`
+}
+
+// ReactionType describes the type of a reaction. Currently, it can be one of
+//
+// It is one of ReactionTypeEmoji, ReactionTypeCustomEmoji or
+// ReactionTypePaid, merged in a single type.
+type ReactionType struct {
+	// Type of the reaction
+	Type string `json:"type"`
+
+	// Emoji reaction emoji. Currently, it can be one of "👍", "👎", "❤",
+	// "🔥", "🥰", "👏", "😁", "🤔", "🤯", "😱", "🤬", "😢",
+	// "🎉", "🤩", "🤮", "💩", "🙏", "👌", "🕊", "🤡", "🥱",
+	// "🥴", "😍", "🐳", "❤‍🔥", "🌚", "🌭", "💯", "🤣",
+	// "⚡", "🍌", "🏆", "💔", "🤨", "😐", "🍓", "🍾", "💋",
+	// "🖕", "😈", "😴", "😭", "🤓", "👻", "👨‍💻", "👀",
+	// "🎃", "🙈", "😇", "😨", "🤝", "✍", "🤗", "🫡", "🎅",
+	// "🎄", "☃", "💅", "🤪", "🗿", "🆒", "💘", "🙉", "🦄",
+	// "😘", "💊", "🙊", "😎", "👾", "🤷‍♂", "🤷",
+	// "🤷‍♀", "😡"
+	//
+	// optional
+	Emoji string `json:"emoji,omitempty"`
+
+	// CustomEmojiID custom emoji identifier
+	//
+	// optional
+	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
+}
+
+// RefundedPayment contains basic information about a refunded payment.
+type RefundedPayment struct {
+	// Currency three-letter ISO 4217 currency code, or “XTR” for payments
+	// in Telegram Stars. Currently, always “XTR”
+	Currency string `json:"currency"`
+
+	// TotalAmount total refunded price in the smallest units of the currency
+	// (integer, not float/double). For example, for a price of US$ 1.45,
+	// total_amount = 145. See the exp parameter in currencies.json, it shows
+	// the number of digits past the decimal point for each currency (2 for the
+	// majority of currencies).
+	TotalAmount int `json:"total_amount"`
+
+	// InvoicePayload bot-specified invoice payload
+	InvoicePayload string `json:"invoice_payload"`
+
+	// TelegramPaymentChargeID telegram payment identifier
+	TelegramPaymentChargeID string `json:"telegram_payment_charge_id"`
+
+	// ProviderPaymentChargeID provider payment identifier
+	//
+	// optional
+	ProviderPaymentChargeID string `json:"provider_payment_charge_id,omitempty"`
+}
+
+// ReplyParameters describes reply parameters for the message that is being
+// sent.
+type ReplyParameters struct {
+	// MessageID identifier of the message that will be replied to in the
+	// current chat, or in the chat chat_id if it is specified
+	MessageID int `json:"message_id"`
+
+	// ChatID if the message to be replied to is from a different chat, unique
+	// identifier for the chat or username of the channel (in the format
+	// @channel_username). Not supported for messages sent on behalf of a
+	// business account.
+	//
+	// optional
+	ChatID int64 `json:"-"`
+
+	// ChannelUsername is the username of the chat, used if ChatID is 0.
+	//
+	// optional
+	ChannelUsername string `json:"-"`
+
+	// AllowSendingWithoutReply pass True if the message should be sent even if
+	// the specified message to be replied to is not found. Always False for
+	// replies in another chat or forum topic. Always True for messages sent on
+	// behalf of a business account.
+	//
+	// optional
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
+
+	// Quote quoted part of the message to be replied to; 0-1024 characters
+	// after entities parsing. The quote must be an exact substring of the
+	// message to be replied to, including bold, italic, underline,
+	// strikethrough, spoiler, and custom_emoji entities. The message will fail
+	// to send if the quote isn't found in the original message.
+	//
+	// optional
+	Quote string `json:"quote,omitempty"`
+
+	// QuoteParseMode mode for parsing entities in the quote. See formatting
+	// options for more details.
+	//
+	// optional
+	QuoteParseMode string `json:"quote_parse_mode,omitempty"`
+
+	// QuoteEntities a JSON-serialized list of special entities that appear in
+	// the quote. It can be specified instead of quote_parse_mode.
+	//
+	// optional
+	QuoteEntities []MessageEntity `json:"quote_entities,omitempty"`
+
+	// QuotePosition position of the quote in the original message in UTF-16
+	// code units
+	//
+	// optional
+	QuotePosition int `json:"quote_position,omitempty"`
+}
+
+// MarshalJSON encodes ChatID, or the username if it is 0.
+func (t ReplyParameters) MarshalJSON() ([]byte, error) {
+	type plain ReplyParameters
+
+	return json.Marshal(struct {
+		plain
+		ChatID interface{} `json:"chat_id,omitempty"`
+	}{plain(t), chatIDValue(t.ChatID, t.ChannelUsername)})
+}
+
+// UnmarshalJSON decodes ChatID, which may be a username.
+func (t *ReplyParameters) UnmarshalJSON(data []byte) error {
+	type plain ReplyParameters
+
+	v := struct {
+		*plain
+		ChatID json.RawMessage `json:"chat_id"`
+	}{plain: (*plain)(t)}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	return setChatIDValue(v.ChatID, &t.ChatID, &t.ChannelUsername)
+}
+
+// RevenueWithdrawalState describes the state of a revenue withdrawal
+// operation. Currently, it can be one of
+//
+// It is one of RevenueWithdrawalStatePending,
+// RevenueWithdrawalStateSucceeded or RevenueWithdrawalStateFailed, merged in
+// a single type.
+type RevenueWithdrawalState struct {
+	// Type of the state
+	Type string `json:"type"`
+
+	// Date the withdrawal was completed in Unix time
+	//
+	// optional
+	Date int `json:"date,omitempty"`
+
+	// URL an HTTPS URL that can be used to see transaction details
+	//
+	// optional
+	URL string `json:"url,omitempty"`
+}
+
+// SharedUser contains information about a user that was shared with the bot
+// using a KeyboardButtonRequestUsers button.
+type SharedUser struct {
+	// UserID identifier of the shared user. This number may have more than 32
+	// significant bits and some programming languages may have
+	// difficulty/silent defects in interpreting it. But it has at most 52
+	// significant bits, so 64-bit integers or double-precision float types are
+	// safe for storing these identifiers. The bot may not have access to the
+	// user and could be unable to use this identifier, unless the user is
+	// already known to the bot by some other means.
+	UserID int64 `json:"user_id"`
+
+	// FirstName first name of the user, if the name was requested by the bot
+	//
+	// optional
+	FirstName string `json:"first_name,omitempty"`
+
+	// LastName last name of the user, if the name was requested by the bot
+	//
+	// optional
+	LastName string `json:"last_name,omitempty"`
+
+	// Username of the user, if the username was requested by the bot
+	//
+	// optional
+	Username string `json:"username,omitempty"`
+
+	// Photo available sizes of the chat photo, if the photo was requested by
+	// the bot
+	//
+	// optional
+	Photo []PhotoSize `json:"photo,omitempty"`
+}
+
+// StarTransaction describes a Telegram Star transaction. Note that if the
+// buyer initiates a chargeback with the payment provider from whom they
+// acquired Stars (e.g., Apple, Google) following this transaction, the
+// refunded Stars will be deducted from the bot's balance. This is outside of
+// Telegram's control.
+type StarTransaction struct {
+	// ID unique identifier of the transaction. Coincides with the identifier of
+	// the original transaction for refund transactions. Coincides with
+	// SuccessfulPayment.telegram_payment_charge_id for successful incoming
+	// payments from users.
+	ID string `json:"id"`
+
+	// Amount integer amount of Telegram Stars transferred by the transaction
+	Amount int `json:"amount"`
+
+	// NanostarAmount the number of 1/1000000000 shares of Telegram Stars
+	// transferred by the transaction; from 0 to 999999999
+	//
+	// optional
+	NanostarAmount int `json:"nanostar_amount,omitempty"`
+
+	// Date the transaction was created in Unix time
+	Date int `json:"date"`
+
+	// Source of an incoming transaction (e.g., a user purchasing goods or
+	// services, Fragment refunding a failed withdrawal). Only for incoming
+	// transactions
+	//
+	// optional
+	Source *TransactionPartner `json:"source,omitempty"`
+
+	// Receiver of an outgoing transaction (e.g., a user for a purchase refund,
+	// Fragment for a withdrawal). Only for outgoing transactions
+	//
+	// optional
+	Receiver *TransactionPartner `json:"receiver,omitempty"`
+}
+
+// StarTransactions contains a list of Telegram Star transactions.
+type StarTransactions struct {
+	// Transactions the list of transactions
+	Transactions []StarTransaction `json:"transactions"`
+}
+
+// Story represents a story.
+type Story struct {
+	// Chat that posted the story
+	Chat Chat `json:"chat"`
+
+	// ID unique identifier for the story in the chat
+	ID int `json:"id"`
+}
+
+// SwitchInlineQueryChosenChat represents an inline button that switches the
+// current user to inline mode in a chosen chat, with an optional default
+// inline query.
+type SwitchInlineQueryChosenChat struct {
+	// Query the default inline query to be inserted in the input field. If left
+	// empty, only the bot's username will be inserted
+	//
+	// optional
+	Query string `json:"query,omitempty"`
+
+	// AllowUserChats True, if private chats with users can be chosen
+	//
+	// optional
+	AllowUserChats bool `json:"allow_user_chats,omitempty"`
+
+	// AllowBotChats True, if private chats with bots can be chosen
+	//
+	// optional
+	AllowBotChats bool `json:"allow_bot_chats,omitempty"`
+
+	// AllowGroupChats True, if group and supergroup chats can be chosen
+	//
+	// optional
+	AllowGroupChats bool `json:"allow_group_chats,omitempty"`
+
+	// AllowChannelChats True, if channel chats can be chosen
+	//
+	// optional
+	AllowChannelChats bool `json:"allow_channel_chats,omitempty"`
+}
+
+// TextQuote contains information about the quoted part of a message that is
+// replied to by the given message.
+type TextQuote struct {
+	// Text of the quoted part of a message that is replied to by the given
+	// message
+	Text string `json:"text"`
+
+	// Entities special entities that appear in the quote. Currently, only bold,
+	// italic, underline, strikethrough, spoiler, and custom_emoji entities are
+	// kept in quotes.
+	//
+	// optional
+	Entities []MessageEntity `json:"entities,omitempty"`
+
+	// Position approximate quote position in the original message in UTF-16
+	// code units as specified by the sender
+	Position int `json:"position"`
+
+	// IsManual True, if the quote was chosen manually by the message sender.
+	// Otherwise, the quote was added automatically by the server.
+	//
+	// optional
+	IsManual bool `json:"is_manual,omitempty"`
+}
+
+// TransactionPartner describes the source of a transaction, or its recipient
+// for outgoing transactions. Currently, it can be one of
+//
+// It is one of TransactionPartnerUser, TransactionPartnerChat,
+// TransactionPartnerAffiliateProgram, TransactionPartnerFragment,
+// TransactionPartnerTelegramAds, TransactionPartnerTelegramApi or
+// TransactionPartnerOther, merged in a single type.
+type TransactionPartner struct {
+	// Type of the transaction partner
+	Type string `json:"type"`
+
+	// User information about the user
+	//
+	// optional
+	User *User `json:"user,omitempty"`
+
+	// Affiliate information about the affiliate that received a commission via
+	// this transaction
+	//
+	// optional
+	Affiliate *AffiliateInfo `json:"affiliate,omitempty"`
+
+	// InvoicePayload bot-specified invoice payload
+	//
+	// optional
+	InvoicePayload string `json:"invoice_payload,omitempty"`
+
+	// SubscriptionPeriod the duration of the paid subscription
+	//
+	// optional
+	SubscriptionPeriod int `json:"subscription_period,omitempty"`
+
+	// PaidMedia information about the paid media bought by the user
+	//
+	// optional
+	PaidMedia []PaidMedia `json:"paid_media,omitempty"`
+
+	// PaidMediaPayload bot-specified paid media payload
+	//
+	// optional
+	PaidMediaPayload string `json:"paid_media_payload,omitempty"`
+
+	// Gift the gift sent to the user by the bot
+	//
+	// optional
+	Gift *Gift `json:"gift,omitempty"`
+
+	// Chat information about the chat
+	//
+	// optional
+	Chat *Chat `json:"chat,omitempty"`
+
+	// SponsorUser information about the bot that sponsored the affiliate
+	// program
+	//
+	// optional
+	SponsorUser *User `json:"sponsor_user,omitempty"`
+
+	// CommissionPerMille the number of Telegram Stars received by the bot for
+	// each 1000 Telegram Stars received by the affiliate program sponsor from
+	// referred users
+	//
+	// optional
+	CommissionPerMille int `json:"commission_per_mille,omitempty"`
+
+	// WithdrawalState state of the transaction if the transaction is outgoing
+	//
+	// optional
+	WithdrawalState *RevenueWithdrawalState `json:"withdrawal_state,omitempty"`
+
+	// RequestCount the number of successful requests that exceeded regular
+	// limits and were therefore billed
+	//
+	// optional
+	RequestCount int `json:"request_count,omitempty"`
+}
+
+// UserChatBoosts represents a list of boosts added to a chat by a user.
+type UserChatBoosts struct {
+	// Boosts the list of boosts added to the chat by the user
+	Boosts []ChatBoost `json:"boosts"`
+}
+
+// UsersShared contains information about the users whose identifiers were
+// shared with the bot using a KeyboardButtonRequestUsers button.
+type UsersShared struct {
+	// RequestID identifier of the request
+	RequestID int `json:"request_id"`
+
+	// Users information about users shared with the bot.
+	Users []SharedUser `json:"users"`
+}
+
+// WriteAccessAllowed represents a service message about a user allowing a
+// bot to write messages after adding it to the attachment menu, launching a
+// Web App from a link, or accepting an explicit request from a Web App sent
+// by the method requestWriteAccess.
+type WriteAccessAllowed struct {
+	// FromRequest True, if the access was granted after the user accepted an
+	// explicit request from a Web App sent by the method requestWriteAccess
+	//
+	// optional
+	FromRequest bool `json:"from_request,omitempty"`
+
+	// WebAppName name of the Web App, if the access was granted when the Web
+	// App was launched from a link
+	//
+	// optional
+	WebAppName string `json:"web_app_name,omitempty"`
+
+	// FromAttachmentMenu True, if the access was granted when the bot was added
+	// to the attachment or side menu
+	//
+	// optional
+	FromAttachmentMenu bool `json:"from_attachment_menu,omitempty"`
+}
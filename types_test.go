@@ -1,6 +1,9 @@
 package tgbotapi
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -333,6 +336,7 @@ var (
 	_ Chattable = SetChatTitleConfig{}
 	_ Chattable = SetGameScoreConfig{}
 	_ Chattable = SetMyDefaultAdministratorRightsConfig{}
+	_ Chattable = SetPassportDataErrorsConfig{}
 	_ Chattable = ShippingConfig{}
 	_ Chattable = StickerConfig{}
 	_ Chattable = StopMessageLiveLocationConfig{}
@@ -365,9 +369,11 @@ var (
 	_ Fileable = (*UploadStickerConfig)(nil)
 	_ Fileable = (*NewStickerSetConfig)(nil)
 	_ Fileable = (*AddStickerConfig)(nil)
+	_ Fileable = (*ReplaceStickerConfig)(nil)
 	_ Fileable = (*MediaGroupConfig)(nil)
 	_ Fileable = (*WebhookConfig)(nil)
 	_ Fileable = (*SetStickerSetThumbConfig)(nil)
+	_ Fileable = (*SetStickerSetThumbnailConfig)(nil)
 )
 
 // Ensure all RequestFileData types are correct.
@@ -379,3 +385,72 @@ var (
 	_ RequestFileData = (*FileID)(nil)
 	_ RequestFileData = (*fileAttach)(nil)
 )
+
+func TestReplyParametersChatID(t *testing.T) {
+	tests := []struct {
+		parameters ReplyParameters
+		json       string
+	}{
+		{ReplyParameters{MessageID: 1}, `{"message_id":1}`},
+		{ReplyParameters{MessageID: 1, ChatID: -100}, `{"message_id":1,"chat_id":-100}`},
+		{ReplyParameters{MessageID: 1, ChannelUsername: "@channel"}, `{"message_id":1,"chat_id":"@channel"}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.parameters)
+		if err != nil || string(data) != test.json {
+			t.Errorf("got %s (%v), expected %s", data, err, test.json)
+		}
+
+		var parameters ReplyParameters
+		if err := json.Unmarshal([]byte(test.json), &parameters); err != nil || !reflect.DeepEqual(parameters, test.parameters) {
+			t.Errorf("%s: got %+v (%v)", test.json, parameters, err)
+		}
+	}
+}
+
+func TestInputMediaThumbnail(t *testing.T) {
+	media := NewInputMediaDocument(FilePath("tests/image.jpg"))
+	media.Thumbnail = FilePath("tests/image.jpg")
+
+	data, err := json.Marshal(prepareInputMediaParam(media, 1))
+	if err != nil || !strings.Contains(string(data), `"thumbnail":"attach://file-1-thumb"`) {
+		t.Errorf("unexpected media %s (%v)", data, err)
+	}
+
+	files := prepareInputMediaFile(media, 1)
+	if len(files) != 2 || files[0].Name != "file-1" || files[1].Name != "file-1-thumb" {
+		t.Errorf("unexpected files %+v", files)
+	}
+
+	media.Thumbnail, media.Thumb = nil, FilePath("tests/image.jpg")
+	data, err = json.Marshal(prepareInputMediaParam(media, 1))
+	if err != nil || !strings.Contains(string(data), `"thumbnail":"attach://file-1-thumb"`) {
+		t.Errorf("deprecated Thumb not sent as thumbnail %s (%v)", data, err)
+	}
+	if files := prepareInputMediaFile(media, 1); len(files) != 2 {
+		t.Errorf("deprecated Thumb not uploaded %+v", files)
+	}
+}
+
+func TestDeprecatedThumbFields(t *testing.T) {
+	document := NewDocument(ChatID, FilePath("tests/image.jpg"))
+	document.Thumb = FilePath("tests/image.jpg")
+
+	files := document.files()
+	if len(files) != 2 || files[1].Name != "thumbnail" || files[1].Data != document.Thumb {
+		t.Errorf("deprecated Thumb not uploaded as thumbnail %+v", files)
+	}
+
+	article := NewInlineQueryResultArticle("id", "title", "text")
+	article.ThumbURL = "https://example.com/thumb.jpg"
+	article.ThumbWidth = 10
+	contact := &InlineQueryResultContact{Type: "contact", ID: "contact", ThumbnailURL: "new", ThumbURL: "old"}
+
+	params, err := InlineConfig{InlineQueryID: "query", Results: []interface{}{article, contact}}.Params()
+	if err != nil ||
+		!strings.Contains(params["results"], `"thumbnail_url":"https://example.com/thumb.jpg","thumbnail_width":10`) ||
+		!strings.Contains(params["results"], `"thumbnail_url":"new"`) {
+		t.Errorf("unexpected results %s (%v)", params["results"], err)
+	}
+}
@@ -0,0 +1,328 @@
+package tgbotapi
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Web App init data errors
+var (
+	ErrWebAppDataHash      = errors.New("web app data hash is not valid")
+	ErrWebAppDataExpired   = errors.New("web app data is expired")
+	ErrWebAppDataSignature = errors.New("web app data signature is not valid")
+)
+
+// Public keys of Telegram, verifying the Ed25519 signature of init data.
+var (
+	// WebAppPublicKey is the key of the production environment.
+	WebAppPublicKey = mustDecodePublicKey("e7bf03a2fa4602af4580703d88dda5bb59f32ed8b02a56c187fe7d34caed242d")
+	// WebAppTestPublicKey is the key of the test environment.
+	WebAppTestPublicKey = mustDecodePublicKey("40055058a4ee38156a06562e52eece92a771bcd8346a8c4615cb7376eddf72ec")
+)
+
+func mustDecodePublicKey(s string) ed25519.PublicKey {
+	key, err := hex.DecodeString(s)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		panic("invalid public key " + s)
+	}
+
+	return key
+}
+
+// WebAppInitDataHeader is the header WebAppMiddleware reads the init data
+// from. The data may also be sent as "Authorization: tma <init data>".
+const WebAppInitDataHeader = "X-Telegram-Init-Data"
+
+// WebAppInitData is the data a Mini App receives when it is launched.
+//
+// https://core.telegram.org/bots/webapps#webappinitdata
+type WebAppInitData struct {
+	// QueryID is a unique identifier for the Web App session, required for
+	// sending messages via AnswerWebAppQueryConfig.
+	//
+	// optional
+	QueryID string
+	// User is the current user.
+	//
+	// optional
+	User *User
+	// Receiver is the chat partner of the current user in private chats,
+	// for Web Apps launched via the attachment menu.
+	//
+	// optional
+	Receiver *User
+	// Chat is the chat where the Web App was launched via the attachment
+	// menu, for groups, supergroups and channels.
+	//
+	// optional
+	Chat *Chat
+	// ChatType is the type of the chat the Web App was opened from.
+	//
+	// optional
+	ChatType string
+	// ChatInstance is a global identifier of the chat the Web App was opened
+	// from.
+	//
+	// optional
+	ChatInstance string
+	// StartParam is the value of the startattach or startapp parameter of
+	// the link used to launch the Web App.
+	//
+	// optional
+	StartParam string
+	// CanSendAfter is the number of seconds after which a message can be
+	// sent via AnswerWebAppQueryConfig.
+	//
+	// optional
+	CanSendAfter int
+	// AuthDate is the unix time when the data was created.
+	AuthDate int
+	// Hash is the signature of all the other fields, made with the bot
+	// token.
+	Hash string
+	// Signature is the Ed25519 signature of all the other fields except
+	// Hash, made by Telegram and encoded in base64url.
+	//
+	// optional
+	Signature string
+}
+
+// AuthTime returns AuthDate as a time.
+func (d WebAppInitData) AuthTime() time.Time {
+	return time.Unix(int64(d.AuthDate), 0)
+}
+
+// ParseWebAppInitData parses init data without validating it.
+func ParseWebAppInitData(telegramInitData string) (WebAppInitData, error) {
+	values, err := url.ParseQuery(telegramInitData)
+	if err != nil {
+		return WebAppInitData{}, fmt.Errorf("error parsing data %w", err)
+	}
+
+	return parseWebAppInitData(values)
+}
+
+func parseWebAppInitData(values url.Values) (WebAppInitData, error) {
+	data := WebAppInitData{
+		QueryID:      values.Get("query_id"),
+		ChatType:     values.Get("chat_type"),
+		ChatInstance: values.Get("chat_instance"),
+		StartParam:   values.Get("start_param"),
+		Hash:         values.Get("hash"),
+		Signature:    values.Get("signature"),
+	}
+
+	objects := []struct {
+		key   string
+		value interface{}
+	}{
+		{"user", &data.User},
+		{"receiver", &data.Receiver},
+		{"chat", &data.Chat},
+	}
+	for _, object := range objects {
+		if value := values.Get(object.key); value != "" {
+			if err := json.Unmarshal([]byte(value), object.value); err != nil {
+				return data, fmt.Errorf("error parsing %s %w", object.key, err)
+			}
+		}
+	}
+
+	numbers := []struct {
+		key   string
+		value *int
+	}{
+		{"can_send_after", &data.CanSendAfter},
+		{"auth_date", &data.AuthDate},
+	}
+	for _, number := range numbers {
+		if value := values.Get(number.key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return data, fmt.Errorf("error parsing %s %w", number.key, err)
+			}
+			*number.value = n
+		}
+	}
+
+	return data, nil
+}
+
+// ValidateWebAppInitData validates init data with the bot token and parses
+// it. Data older than maxAge is rejected with ErrWebAppDataExpired, unless
+// maxAge is 0.
+//
+// https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+func ValidateWebAppInitData(token, telegramInitData string, maxAge time.Duration) (WebAppInitData, error) {
+	values, err := url.ParseQuery(telegramInitData)
+	if err != nil {
+		return WebAppInitData{}, fmt.Errorf("error parsing data %w", err)
+	}
+
+	if err := checkWebAppHash(token, values); err != nil {
+		return WebAppInitData{}, err
+	}
+
+	data, err := parseWebAppInitData(values)
+	if err != nil {
+		return data, err
+	}
+
+	return data, checkWebAppAuthDate(data, maxAge)
+}
+
+// ValidateWebAppInitDataSignature validates init data with its Ed25519
+// signature and parses it. Unlike ValidateWebAppInitData it doesn't need the
+// bot token, only the bot ID (the number before the colon of the token) and
+// the public key of Telegram, WebAppPublicKey or WebAppTestPublicKey. Data
+// older than maxAge is rejected with ErrWebAppDataExpired, unless maxAge is
+// 0.
+//
+// https://core.telegram.org/bots/webapps#validating-data-for-third-party-use
+func ValidateWebAppInitDataSignature(botID int64, publicKey ed25519.PublicKey, telegramInitData string, maxAge time.Duration) (WebAppInitData, error) {
+	values, err := url.ParseQuery(telegramInitData)
+	if err != nil {
+		return WebAppInitData{}, fmt.Errorf("error parsing data %w", err)
+	}
+
+	if err := checkWebAppSignature(botID, publicKey, values); err != nil {
+		return WebAppInitData{}, err
+	}
+
+	data, err := parseWebAppInitData(values)
+	if err != nil {
+		return data, err
+	}
+
+	return data, checkWebAppAuthDate(data, maxAge)
+}
+
+// webAppDataCheckString joins the sorted fields of init data, except the
+// excluded ones, with newlines.
+func webAppDataCheckString(values url.Values, exclude ...string) string {
+	fields := make([]string, 0, len(values))
+	for k, v := range values {
+		excluded := false
+		for _, e := range exclude {
+			excluded = excluded || k == e
+		}
+
+		if !excluded && len(v) > 0 {
+			fields = append(fields, k+"="+v[0])
+		}
+	}
+
+	sort.Strings(fields)
+
+	return strings.Join(fields, "\n")
+}
+
+func checkWebAppHash(token string, values url.Values) error {
+	expected, err := hex.DecodeString(values.Get("hash"))
+	if err != nil || len(expected) == 0 {
+		return ErrWebAppDataHash
+	}
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(token))
+
+	hash := hmac.New(sha256.New, secret.Sum(nil))
+	hash.Write([]byte(webAppDataCheckString(values, "hash")))
+
+	if !hmac.Equal(hash.Sum(nil), expected) {
+		return ErrWebAppDataHash
+	}
+
+	return nil
+}
+
+// webAppSignatureCheckString returns the data signed by Telegram, the bot ID
+// followed by the fields of init data except the hash and the signature.
+func webAppSignatureCheckString(botID int64, values url.Values) string {
+	return strconv.FormatInt(botID, 10) + ":WebAppData\n" + webAppDataCheckString(values, "hash", "signature")
+}
+
+func checkWebAppSignature(botID int64, publicKey ed25519.PublicKey, values url.Values) error {
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(values.Get("signature"), "="))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return ErrWebAppDataSignature
+	}
+
+	if !ed25519.Verify(publicKey, []byte(webAppSignatureCheckString(botID, values)), signature) {
+		return ErrWebAppDataSignature
+	}
+
+	return nil
+}
+
+func checkWebAppAuthDate(data WebAppInitData, maxAge time.Duration) error {
+	if maxAge > 0 && time.Since(data.AuthTime()) > maxAge {
+		return ErrWebAppDataExpired
+	}
+
+	return nil
+}
+
+type webAppInitDataKey struct{}
+
+// ContextWithWebAppInitData returns a copy of a context holding init data.
+func ContextWithWebAppInitData(ctx context.Context, data WebAppInitData) context.Context {
+	return context.WithValue(ctx, webAppInitDataKey{}, data)
+}
+
+// WebAppInitDataFromContext returns the init data set by WebAppMiddleware.
+func WebAppInitDataFromContext(ctx context.Context) (WebAppInitData, bool) {
+	data, ok := ctx.Value(webAppInitDataKey{}).(WebAppInitData)
+
+	return data, ok
+}
+
+// WebAppMiddleware validates the init data sent by Mini Apps in the
+// WebAppInitDataHeader header with the bot token, and makes it available to
+// the next handler through WebAppInitDataFromContext. Requests without valid
+// data are rejected with 401 Unauthorized.
+func WebAppMiddleware(token string, maxAge time.Duration) func(http.Handler) http.Handler {
+	return webAppMiddleware(func(telegramInitData string) (WebAppInitData, error) {
+		return ValidateWebAppInitData(token, telegramInitData, maxAge)
+	})
+}
+
+// WebAppSignatureMiddleware is like WebAppMiddleware, but validates the init
+// data with ValidateWebAppInitDataSignature, without the bot token.
+func WebAppSignatureMiddleware(botID int64, publicKey ed25519.PublicKey, maxAge time.Duration) func(http.Handler) http.Handler {
+	return webAppMiddleware(func(telegramInitData string) (WebAppInitData, error) {
+		return ValidateWebAppInitDataSignature(botID, publicKey, telegramInitData, maxAge)
+	})
+}
+
+func webAppMiddleware(validate func(string) (WebAppInitData, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			telegramInitData := r.Header.Get(WebAppInitDataHeader)
+			if auth := r.Header.Get("Authorization"); telegramInitData == "" && strings.HasPrefix(auth, "tma ") {
+				telegramInitData = strings.TrimPrefix(auth, "tma ")
+			}
+
+			data, err := validate(telegramInitData)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ContextWithWebAppInitData(r.Context(), data)))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package tgbotapi
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	webAppToken    = "5473903189:AAFnHnISQMP5UQQ5MEaoEWvxeiwNgz2CN2U"
+	webAppInitData = "query_id=AAG1bpMJAAAAALVukwmZ_H2t&user=%7B%22id%22%3A160657077%2C%22first_name%22%3A%22Yury%20R%22%2C%22last_name%22%3A%22%22%2C%22username%22%3A%22crashiura%22%2C%22language_code%22%3A%22en%22%7D&auth_date=1656804462&hash=8d6960760a573d3212deb05e20d1a34959c83d24c1bc44bb26dde49a42aa9b34"
+)
+
+// signWebAppInitData adds the hash made with the bot token to init data.
+func signWebAppInitData(token string, values url.Values) string {
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(token))
+
+	hash := hmac.New(sha256.New, secret.Sum(nil))
+	hash.Write([]byte(webAppDataCheckString(values, "hash")))
+	values.Set("hash", hex.EncodeToString(hash.Sum(nil)))
+
+	return values.Encode()
+}
+
+func TestValidateWebAppInitData(t *testing.T) {
+	data, err := ValidateWebAppInitData(webAppToken, webAppInitData, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.QueryID != "AAG1bpMJAAAAALVukwmZ_H2t" || data.User == nil ||
+		data.User.ID != 160657077 || data.User.UserName != "crashiura" || data.AuthDate != 1656804462 {
+		t.Errorf("unexpected data %+v", data)
+	}
+
+	if _, err := ValidateWebAppInitData(webAppToken, webAppInitData, time.Hour); err != ErrWebAppDataExpired {
+		t.Errorf("expected expired data, got %v", err)
+	}
+
+	if _, err := ValidateWebAppInitData("123:other", webAppInitData, 0); err != ErrWebAppDataHash {
+		t.Errorf("expected invalid hash, got %v", err)
+	}
+}
+
+func TestWebAppMiddleware(t *testing.T) {
+	handler := WebAppMiddleware(webAppToken, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := WebAppInitDataFromContext(r.Context())
+		if !ok || data.StartParam != "ref" || data.Chat == nil || data.Chat.ID != -100 {
+			t.Errorf("unexpected data %+v", data)
+		}
+	}))
+
+	fresh := signWebAppInitData(webAppToken, url.Values{
+		"chat":        {`{"id":-100,"type":"supergroup","title":"Group"}`},
+		"start_param": {"ref"},
+		"auth_date":   {strconv.FormatInt(time.Now().Unix(), 10)},
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+	}{
+		{"header", WebAppInitDataHeader, fresh, http.StatusOK},
+		{"authorization", "Authorization", "tma " + fresh, http.StatusOK},
+		{"expired", WebAppInitDataHeader, webAppInitData, http.StatusUnauthorized},
+		{"missing", "", "", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.header != "" {
+				r.Header.Set(test.header, test.value)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != test.status {
+				t.Errorf("got status %d, expected %d", w.Code, test.status)
+			}
+		})
+	}
+}
+
+func TestValidateWebAppInitDataSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := url.Values{
+		"user":      {`{"id":1,"first_name":"Ada"}`},
+		"auth_date": {strconv.FormatInt(time.Now().Unix(), 10)},
+		"hash":      {"ignored"},
+	}
+	signature := ed25519.Sign(privateKey, []byte("12345:WebAppData\n"+webAppDataCheckString(values, "hash")))
+	values.Set("signature", base64.RawURLEncoding.EncodeToString(signature))
+
+	data, err := ValidateWebAppInitDataSignature(12345, publicKey, values.Encode(), time.Minute)
+	if err != nil || data.User == nil || data.User.FirstName != "Ada" || data.Signature == "" {
+		t.Errorf("unexpected data %+v (%v)", data, err)
+	}
+
+	if _, err := ValidateWebAppInitDataSignature(54321, publicKey, values.Encode(), 0); err != ErrWebAppDataSignature {
+		t.Errorf("signature of another bot accepted: %v", err)
+	}
+
+	if _, err := ValidateWebAppInitDataSignature(12345, WebAppPublicKey, values.Encode(), 0); err != ErrWebAppDataSignature {
+		t.Errorf("signature of another key accepted: %v", err)
+	}
+}